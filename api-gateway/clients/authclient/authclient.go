@@ -1,24 +1,24 @@
 package authclient
 
 import (
+	"api-gateway/clients/dialer"
+	"api-gateway/config"
 	proto "api-gateway/proto/auth"
 	"log"
-
-	"google.golang.org/grpc"
-	"google.golang.org/grpc/credentials/insecure"
+	"log/slog"
 )
 
 var (
 	AuthServiceClient proto.AuthServiceClient
 )
 
-func InitAuthClient() {
-	conn, err := grpc.Dial("localhost:9003", grpc.WithTransportCredentials(insecure.NewCredentials()), grpc.WithBlock())
+func InitAuthClient(upstream config.Upstream) {
+	conn, err := dialer.Dial("auth", upstream, proto.AuthService_AuthenticateUser_FullMethodName, proto.AuthService_LoginUser_FullMethodName)
 
 	if err != nil {
-		log.Fatal("Failed to connect to the Auth Microservice")
+		log.Fatalf("Failed to configure the Auth Microservice client: %v", err)
 	}
 
-	log.Printf("gRPC Client connected to Auth Microservice")
+	slog.Info("grpc client configured", "upstream", "auth", "target", upstream.Target)
 	AuthServiceClient = proto.NewAuthServiceClient(conn)
 }
@@ -0,0 +1,190 @@
+package breaker
+
+import (
+	"sort"
+	"sync"
+	"time"
+)
+
+type State string
+
+const (
+	Closed   State = "closed"
+	Open     State = "open"
+	HalfOpen State = "half_open"
+)
+
+var (
+	mutex    sync.Mutex
+	breakers = make(map[string]*Breaker)
+)
+
+type Breaker struct {
+	name        string
+	threshold   int
+	openTimeout time.Duration
+	now         func() time.Time
+
+	mutex      sync.Mutex
+	state      State
+	generation uint64
+	failures   int
+	openedAt   time.Time
+	probing    bool
+}
+
+// Ticket identifies a call admitted by Allow. Results are only counted for
+// tickets issued since the last state change, so a slow call admitted while
+// the breaker was closed cannot close it again after it opened.
+type Ticket struct {
+	generation uint64
+	probe      bool
+}
+
+type Snapshot struct {
+	Name       string
+	State      State
+	Failures   int
+	RetryAfter time.Duration
+}
+
+func New(name string, threshold int, openTimeout time.Duration) *Breaker {
+	breaker := &Breaker{name: name, threshold: threshold, openTimeout: openTimeout, now: time.Now, state: Closed}
+
+	mutex.Lock()
+	breakers[name] = breaker
+	mutex.Unlock()
+
+	return breaker
+}
+
+func Snapshots() []Snapshot {
+	mutex.Lock()
+	defer mutex.Unlock()
+
+	snapshots := make([]Snapshot, 0, len(breakers))
+
+	for _, breaker := range breakers {
+		snapshots = append(snapshots, breaker.Snapshot())
+	}
+
+	sort.Slice(snapshots, func(i, j int) bool {
+		return snapshots[i].Name < snapshots[j].Name
+	})
+
+	return snapshots
+}
+
+func (b *Breaker) Name() string {
+	return b.name
+}
+
+// Allow reports whether a call may go through. While the breaker is open it
+// returns how long the caller should wait before trying again; once the open
+// timeout has elapsed a single probe is let through in the half-open state.
+// The returned ticket must be handed back to Record or Ignore.
+func (b *Breaker) Allow() (Ticket, time.Duration, bool) {
+	b.mutex.Lock()
+	defer b.mutex.Unlock()
+
+	switch b.state {
+	case Open:
+		remaining := b.openTimeout - b.now().Sub(b.openedAt)
+
+		if remaining > 0 {
+			return Ticket{}, remaining, false
+		}
+
+		b.transition(HalfOpen)
+		b.probing = true
+
+		return Ticket{generation: b.generation, probe: true}, 0, true
+	case HalfOpen:
+		if b.probing {
+			return Ticket{}, time.Second, false
+		}
+
+		b.probing = true
+
+		return Ticket{generation: b.generation, probe: true}, 0, true
+	default:
+		return Ticket{generation: b.generation}, 0, true
+	}
+}
+
+// Record counts the result of an admitted call. Results of calls admitted
+// before the last state change are dropped, and while half-open only the
+// probe decides whether the breaker closes or opens again.
+func (b *Breaker) Record(ticket Ticket, success bool) {
+	b.mutex.Lock()
+	defer b.mutex.Unlock()
+
+	if ticket.generation != b.generation {
+		return
+	}
+
+	switch b.state {
+	case HalfOpen:
+		if !ticket.probe {
+			return
+		}
+
+		b.probing = false
+
+		if success {
+			b.transition(Closed)
+		} else {
+			b.transition(Open)
+		}
+	case Closed:
+		if success {
+			b.failures = 0
+
+			return
+		}
+
+		b.failures++
+
+		if b.failures >= b.threshold {
+			b.transition(Open)
+		}
+	}
+}
+
+// Ignore releases a half-open probe without changing the breaker state, for
+// calls that ended before the upstream could answer (e.g. the client went away).
+func (b *Breaker) Ignore(ticket Ticket) {
+	b.mutex.Lock()
+	defer b.mutex.Unlock()
+
+	if ticket.probe && ticket.generation == b.generation {
+		b.probing = false
+	}
+}
+
+func (b *Breaker) transition(state State) {
+	b.state = state
+	b.generation++
+
+	switch state {
+	case Open:
+		b.openedAt = b.now()
+	case Closed:
+		b.failures = 0
+	}
+}
+
+func (b *Breaker) Snapshot() Snapshot {
+	b.mutex.Lock()
+	defer b.mutex.Unlock()
+
+	snapshot := Snapshot{Name: b.name, State: b.state, Failures: b.failures}
+
+	if b.state == Open {
+		if remaining := b.openTimeout - b.now().Sub(b.openedAt); remaining > 0 {
+			snapshot.RetryAfter = remaining
+		}
+	}
+
+	return snapshot
+}
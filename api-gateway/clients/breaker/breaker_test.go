@@ -0,0 +1,152 @@
+package breaker
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type clock struct {
+	now time.Time
+}
+
+func (c *clock) advance(duration time.Duration) {
+	c.now = c.now.Add(duration)
+}
+
+func newTestBreaker(t *testing.T, threshold int, openTimeout time.Duration) (*Breaker, *clock) {
+	fake := &clock{now: time.Unix(1700000000, 0)}
+	breaker := New(t.Name(), threshold, openTimeout)
+	breaker.now = func() time.Time { return fake.now }
+
+	return breaker, fake
+}
+
+func admit(t *testing.T, breaker *Breaker) Ticket {
+	ticket, _, ok := breaker.Allow()
+	assert.True(t, ok)
+
+	return ticket
+}
+
+func trip(t *testing.T, breaker *Breaker) {
+	for i := 0; i < breaker.threshold; i++ {
+		breaker.Record(admit(t, breaker), false)
+	}
+
+	assert.Equal(t, Open, breaker.Snapshot().State)
+}
+
+func TestBreakerShouldOpenAfterConsecutiveFailures(t *testing.T) {
+	breaker, _ := newTestBreaker(t, 3, 10*time.Second)
+
+	breaker.Record(admit(t, breaker), false)
+	breaker.Record(admit(t, breaker), false)
+	breaker.Record(admit(t, breaker), true)
+	breaker.Record(admit(t, breaker), false)
+	breaker.Record(admit(t, breaker), false)
+
+	assert.Equal(t, Snapshot{Name: t.Name(), State: Closed, Failures: 2}, breaker.Snapshot())
+
+	breaker.Record(admit(t, breaker), false)
+	_, retryAfter, ok := breaker.Allow()
+
+	assert.False(t, ok)
+	assert.Equal(t, 10*time.Second, retryAfter)
+	assert.Equal(t, Snapshot{Name: t.Name(), State: Open, Failures: 3, RetryAfter: 10 * time.Second}, breaker.Snapshot())
+}
+
+func TestBreakerShouldLetASingleProbeThroughOnceTheOpenTimeoutElapsed(t *testing.T) {
+	breaker, fake := newTestBreaker(t, 1, 10*time.Second)
+	trip(t, breaker)
+
+	fake.advance(4 * time.Second)
+	_, retryAfter, ok := breaker.Allow()
+
+	assert.False(t, ok)
+	assert.Equal(t, 6*time.Second, retryAfter)
+
+	fake.advance(6 * time.Second)
+	probe, _, ok1 := breaker.Allow()
+	_, _, ok2 := breaker.Allow()
+
+	assert.True(t, ok1)
+	assert.True(t, probe.probe)
+	assert.False(t, ok2)
+	assert.Equal(t, HalfOpen, breaker.Snapshot().State)
+
+	breaker.Record(probe, true)
+
+	assert.Equal(t, Snapshot{Name: t.Name(), State: Closed}, breaker.Snapshot())
+}
+
+func TestBreakerShouldReopenWhenTheProbeFails(t *testing.T) {
+	breaker, fake := newTestBreaker(t, 1, 10*time.Second)
+	trip(t, breaker)
+
+	fake.advance(10 * time.Second)
+	probe := admit(t, breaker)
+	fake.advance(time.Second)
+	breaker.Record(probe, false)
+	_, retryAfter, ok := breaker.Allow()
+
+	assert.False(t, ok)
+	assert.Equal(t, 10*time.Second, retryAfter)
+	assert.Equal(t, Open, breaker.Snapshot().State)
+}
+
+func TestBreakerShouldReleaseAnIgnoredProbe(t *testing.T) {
+	breaker, fake := newTestBreaker(t, 1, 10*time.Second)
+	trip(t, breaker)
+
+	fake.advance(10 * time.Second)
+	breaker.Ignore(admit(t, breaker))
+	probe, _, ok := breaker.Allow()
+
+	assert.True(t, ok)
+	assert.True(t, probe.probe)
+	assert.Equal(t, HalfOpen, breaker.Snapshot().State)
+}
+
+func TestBreakerShouldIgnoreResultsOfCallsAdmittedBeforeAStateChange(t *testing.T) {
+	breaker, fake := newTestBreaker(t, 2, 10*time.Second)
+	slow := admit(t, breaker)
+	late := admit(t, breaker)
+	trip(t, breaker)
+
+	breaker.Record(slow, true)
+
+	assert.Equal(t, Open, breaker.Snapshot().State)
+
+	fake.advance(5 * time.Second)
+	breaker.Record(late, false)
+	_, retryAfter, _ := breaker.Allow()
+
+	assert.Equal(t, 5*time.Second, retryAfter)
+
+	fake.advance(5 * time.Second)
+	probe := admit(t, breaker)
+	breaker.Record(slow, true)
+	breaker.Ignore(late)
+	_, _, ok := breaker.Allow()
+
+	assert.Equal(t, HalfOpen, breaker.Snapshot().State)
+	assert.False(t, ok)
+
+	breaker.Record(probe, false)
+
+	assert.Equal(t, Open, breaker.Snapshot().State)
+}
+
+func TestBreakerShouldIgnoreAStaleProbeAfterItClosed(t *testing.T) {
+	breaker, fake := newTestBreaker(t, 1, 10*time.Second)
+	trip(t, breaker)
+
+	fake.advance(10 * time.Second)
+	probe := admit(t, breaker)
+	breaker.Record(probe, true)
+	breaker.Record(probe, false)
+
+	assert.Equal(t, Closed, breaker.Snapshot().State)
+}
@@ -4,10 +4,11 @@ import (
 	"api-gateway/clients/authclient"
 	"api-gateway/clients/orderclient"
 	"api-gateway/clients/productclient"
+	"api-gateway/config"
 )
 
-func InitClients() {
-	productclient.InitProductClient()
-	orderclient.InitOrderClient()
-	authclient.InitAuthClient()
+func InitClients(upstreams config.Upstreams) {
+	productclient.InitProductClient(upstreams.Product)
+	orderclient.InitOrderClient(upstreams.Order)
+	authclient.InitAuthClient(upstreams.Auth)
 }
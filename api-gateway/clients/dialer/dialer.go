@@ -0,0 +1,179 @@
+package dialer
+
+import (
+	"api-gateway/clients/breaker"
+	"api-gateway/config"
+	"crypto/tls"
+	"crypto/x509"
+	"encoding/json"
+	"fmt"
+	"os"
+	"strings"
+	"sync"
+	"time"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/connectivity"
+	"google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/keepalive"
+)
+
+var (
+	mutex       sync.Mutex
+	connections = make(map[string]*grpc.ClientConn)
+)
+
+type methodName struct {
+	Service string `json:"service"`
+	Method  string `json:"method"`
+}
+
+type retryPolicy struct {
+	MaxAttempts          int      `json:"maxAttempts"`
+	InitialBackoff       string   `json:"initialBackoff"`
+	MaxBackoff           string   `json:"maxBackoff"`
+	BackoffMultiplier    float64  `json:"backoffMultiplier"`
+	RetryableStatusCodes []string `json:"retryableStatusCodes"`
+}
+
+type methodConfig struct {
+	Name        []methodName `json:"name"`
+	RetryPolicy *retryPolicy `json:"retryPolicy,omitempty"`
+}
+
+type serviceConfig struct {
+	MethodConfig []methodConfig `json:"methodConfig"`
+}
+
+func Dial(name string, upstream config.Upstream, idempotentMethods ...string) (*grpc.ClientConn, error) {
+	transport, err := transportCredentials(upstream.TLS)
+
+	if err != nil {
+		return nil, err
+	}
+
+	retryConfig, err := retryServiceConfig(upstream.Retry, idempotentMethods)
+
+	if err != nil {
+		return nil, err
+	}
+
+	circuit := breaker.New(name, upstream.Breaker.FailureThreshold, time.Duration(upstream.Breaker.OpenTimeout))
+
+	conn, err := grpc.Dial(upstream.Target,
+		grpc.WithTransportCredentials(transport),
+		grpc.WithDefaultServiceConfig(retryConfig),
+		grpc.WithKeepaliveParams(keepalive.ClientParameters{
+			Time:                time.Duration(upstream.Keepalive),
+			Timeout:             10 * time.Second,
+			PermitWithoutStream: true,
+		}),
+		grpc.WithChainUnaryInterceptor(
+			requestIDInterceptor,
+			metricsInterceptor(name),
+			bulkheadInterceptor(name, upstream.MaxConcurrent),
+			breakerInterceptor(circuit),
+			deadlineInterceptor(time.Duration(upstream.Deadline)),
+		),
+		grpc.WithChainStreamInterceptor(streamRequestIDInterceptor, streamMetricsInterceptor(name), streamBreakerInterceptor(circuit)))
+
+	if err != nil {
+		return nil, err
+	}
+
+	mutex.Lock()
+	connections[name] = conn
+	mutex.Unlock()
+
+	return conn, nil
+}
+
+func Readiness() map[string]connectivity.State {
+	mutex.Lock()
+	defer mutex.Unlock()
+
+	states := make(map[string]connectivity.State, len(connections))
+
+	for name, conn := range connections {
+		state := conn.GetState()
+
+		if state == connectivity.Idle {
+			conn.Connect()
+		}
+
+		states[name] = state
+	}
+
+	return states
+}
+
+func retryServiceConfig(retry config.Retry, idempotentMethods []string) (string, error) {
+	if retry.MaxAttempts < 2 || len(idempotentMethods) == 0 {
+		return `{}`, nil
+	}
+
+	names := make([]methodName, 0, len(idempotentMethods))
+
+	for _, fullMethod := range idempotentMethods {
+		parts := strings.Split(strings.TrimPrefix(fullMethod, "/"), "/")
+
+		if len(parts) != 2 {
+			return "", fmt.Errorf("invalid method name %s", fullMethod)
+		}
+
+		names = append(names, methodName{Service: parts[0], Method: parts[1]})
+	}
+
+	policy := &retryPolicy{
+		MaxAttempts:          retry.MaxAttempts,
+		InitialBackoff:       fmt.Sprintf("%.3fs", time.Duration(retry.InitialBackoff).Seconds()),
+		MaxBackoff:           fmt.Sprintf("%.3fs", time.Duration(retry.MaxBackoff).Seconds()),
+		BackoffMultiplier:    2,
+		RetryableStatusCodes: []string{"UNAVAILABLE"},
+	}
+
+	encoded, err := json.Marshal(serviceConfig{MethodConfig: []methodConfig{{Name: names, RetryPolicy: policy}}})
+
+	if err != nil {
+		return "", err
+	}
+
+	return string(encoded), nil
+}
+
+func transportCredentials(settings config.TLS) (credentials.TransportCredentials, error) {
+	if !settings.Enabled {
+		return insecure.NewCredentials(), nil
+	}
+
+	tlsConfig := &tls.Config{ServerName: settings.ServerName, MinVersion: tls.VersionTLS12}
+
+	if len(settings.CAFile) != 0 {
+		pem, err := os.ReadFile(settings.CAFile)
+
+		if err != nil {
+			return nil, err
+		}
+
+		pool := x509.NewCertPool()
+
+		if !pool.AppendCertsFromPEM(pem) {
+			return nil, fmt.Errorf("no certificates found in %s", settings.CAFile)
+		}
+
+		tlsConfig.RootCAs = pool
+	}
+
+	if len(settings.CertFile) != 0 {
+		certificate, err := tls.LoadX509KeyPair(settings.CertFile, settings.KeyFile)
+
+		if err != nil {
+			return nil, err
+		}
+
+		tlsConfig.Certificates = []tls.Certificate{certificate}
+	}
+
+	return credentials.NewTLS(tlsConfig), nil
+}
@@ -0,0 +1,171 @@
+package dialer
+
+import (
+	"api-gateway/clients/breaker"
+	"api-gateway/metrics"
+	"api-gateway/requestid"
+	"context"
+	"fmt"
+	"io"
+	"sync"
+	"time"
+
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/durationpb"
+)
+
+var (
+	breakerFailures = map[codes.Code]bool{
+		codes.Unavailable:       true,
+		codes.DeadlineExceeded:  true,
+		codes.ResourceExhausted: true,
+		codes.Internal:          true,
+	}
+)
+
+func deadlineInterceptor(deadline time.Duration) grpc.UnaryClientInterceptor {
+	return func(ctx context.Context, method string, req, reply interface{}, conn *grpc.ClientConn, invoker grpc.UnaryInvoker, opts ...grpc.CallOption) error {
+		if _, ok := ctx.Deadline(); !ok {
+			var cancel context.CancelFunc
+			ctx, cancel = context.WithTimeout(ctx, deadline)
+			defer cancel()
+		}
+
+		return invoker(ctx, method, req, reply, conn, opts...)
+	}
+}
+
+func bulkheadInterceptor(name string, maxConcurrent int) grpc.UnaryClientInterceptor {
+	slots := make(chan struct{}, maxConcurrent)
+
+	return func(ctx context.Context, method string, req, reply interface{}, conn *grpc.ClientConn, invoker grpc.UnaryInvoker, opts ...grpc.CallOption) error {
+		select {
+		case slots <- struct{}{}:
+			defer func() { <-slots }()
+		default:
+			return unavailableError(fmt.Sprintf("too many concurrent requests to the %s service", name), time.Second)
+		}
+
+		return invoker(ctx, method, req, reply, conn, opts...)
+	}
+}
+
+func breakerInterceptor(circuit *breaker.Breaker) grpc.UnaryClientInterceptor {
+	return func(ctx context.Context, method string, req, reply interface{}, conn *grpc.ClientConn, invoker grpc.UnaryInvoker, opts ...grpc.CallOption) error {
+		ticket, retryAfter, ok := circuit.Allow()
+
+		if !ok {
+			return unavailableError(fmt.Sprintf("the %s service is unavailable, circuit breaker is open", circuit.Name()), retryAfter)
+		}
+
+		err := invoker(ctx, method, req, reply, conn, opts...)
+		settle(circuit, ticket, err)
+
+		return err
+	}
+}
+
+func streamBreakerInterceptor(circuit *breaker.Breaker) grpc.StreamClientInterceptor {
+	return func(ctx context.Context, desc *grpc.StreamDesc, conn *grpc.ClientConn, method string, streamer grpc.Streamer, opts ...grpc.CallOption) (grpc.ClientStream, error) {
+		ticket, retryAfter, ok := circuit.Allow()
+
+		if !ok {
+			return nil, unavailableError(fmt.Sprintf("the %s service is unavailable, circuit breaker is open", circuit.Name()), retryAfter)
+		}
+
+		stream, err := streamer(ctx, desc, conn, method, opts...)
+
+		if err != nil {
+			settle(circuit, ticket, err)
+			return nil, err
+		}
+
+		return &breakerStream{ClientStream: stream, circuit: circuit, ticket: ticket}, nil
+	}
+}
+
+// breakerStream holds the breaker ticket until the stream ends, since most
+// upstream failures only surface once the first message is received.
+type breakerStream struct {
+	grpc.ClientStream
+	circuit *breaker.Breaker
+	ticket  breaker.Ticket
+	once    sync.Once
+}
+
+func (s *breakerStream) RecvMsg(message interface{}) error {
+	err := s.ClientStream.RecvMsg(message)
+
+	if err != nil {
+		s.once.Do(func() {
+			if err == io.EOF {
+				s.circuit.Record(s.ticket, true)
+			} else {
+				settle(s.circuit, s.ticket, err)
+			}
+		})
+	}
+
+	return err
+}
+
+func settle(circuit *breaker.Breaker, ticket breaker.Ticket, err error) {
+	switch code := status.Code(err); {
+	case code == codes.Canceled:
+		circuit.Ignore(ticket)
+	default:
+		circuit.Record(ticket, !breakerFailures[code])
+	}
+}
+
+func unavailableError(message string, retryAfter time.Duration) error {
+	rpcStatus := status.New(codes.Unavailable, message)
+
+	if detailed, err := rpcStatus.WithDetails(&errdetails.RetryInfo{RetryDelay: durationpb.New(retryAfter)}); err == nil {
+		rpcStatus = detailed
+	}
+
+	return rpcStatus.Err()
+}
+
+func requestIDInterceptor(ctx context.Context, method string, req, reply interface{}, conn *grpc.ClientConn, invoker grpc.UnaryInvoker, opts ...grpc.CallOption) error {
+	return invoker(outgoingRequestID(ctx), method, req, reply, conn, opts...)
+}
+
+func streamRequestIDInterceptor(ctx context.Context, desc *grpc.StreamDesc, conn *grpc.ClientConn, method string, streamer grpc.Streamer, opts ...grpc.CallOption) (grpc.ClientStream, error) {
+	return streamer(outgoingRequestID(ctx), desc, conn, method, opts...)
+}
+
+func outgoingRequestID(ctx context.Context) context.Context {
+	if id := requestid.FromContext(ctx); len(id) != 0 {
+		return metadata.AppendToOutgoingContext(ctx, requestid.MetadataKey, id)
+	}
+
+	return ctx
+}
+
+func metricsInterceptor(name string) grpc.UnaryClientInterceptor {
+	return func(ctx context.Context, method string, req, reply interface{}, conn *grpc.ClientConn, invoker grpc.UnaryInvoker, opts ...grpc.CallOption) error {
+		start := time.Now()
+
+		err := invoker(ctx, method, req, reply, conn, opts...)
+		metrics.ObserveClientRPC(name, method, status.Code(err), time.Since(start))
+
+		return err
+	}
+}
+
+func streamMetricsInterceptor(name string) grpc.StreamClientInterceptor {
+	return func(ctx context.Context, desc *grpc.StreamDesc, conn *grpc.ClientConn, method string, streamer grpc.Streamer, opts ...grpc.CallOption) (grpc.ClientStream, error) {
+		start := time.Now()
+
+		stream, err := streamer(ctx, desc, conn, method, opts...)
+		metrics.ObserveClientRPC(name, method, status.Code(err), time.Since(start))
+
+		return stream, err
+	}
+}
@@ -0,0 +1,67 @@
+package dialer
+
+import (
+	"api-gateway/clients/breaker"
+	"context"
+	"io"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+type fakeStream struct {
+	grpc.ClientStream
+	errs []error
+}
+
+func (s *fakeStream) RecvMsg(message interface{}) error {
+	err := s.errs[0]
+	s.errs = s.errs[1:]
+
+	return err
+}
+
+func openStream(t *testing.T, circuit *breaker.Breaker, errs ...error) grpc.ClientStream {
+	streamer := func(ctx context.Context, desc *grpc.StreamDesc, conn *grpc.ClientConn, method string, opts ...grpc.CallOption) (grpc.ClientStream, error) {
+		return &fakeStream{errs: errs}, nil
+	}
+
+	stream, err := streamBreakerInterceptor(circuit)(context.Background(), &grpc.StreamDesc{}, nil, "/inventory/Watch", streamer)
+	assert.NoError(t, err)
+
+	return stream
+}
+
+func TestStreamBreakerShouldRecordTheFirstReceiveFailure(t *testing.T) {
+	circuit := breaker.New(t.Name(), 2, 10*time.Second)
+	unavailable := status.Error(codes.Unavailable, "connection refused")
+
+	stream := openStream(t, circuit, nil, unavailable, unavailable)
+	assert.NoError(t, stream.RecvMsg(nil))
+	assert.Equal(t, 0, circuit.Snapshot().Failures)
+
+	assert.Equal(t, unavailable, stream.RecvMsg(nil))
+	assert.Equal(t, unavailable, stream.RecvMsg(nil))
+	assert.Equal(t, 1, circuit.Snapshot().Failures)
+
+	stream = openStream(t, circuit, unavailable)
+	stream.RecvMsg(nil)
+
+	assert.Equal(t, breaker.Open, circuit.Snapshot().State)
+}
+
+func TestStreamBreakerShouldRecordACleanEndAsASuccess(t *testing.T) {
+	circuit := breaker.New(t.Name(), 2, 10*time.Second)
+
+	openStream(t, circuit, status.Error(codes.Internal, "boom")).RecvMsg(nil)
+	assert.Equal(t, 1, circuit.Snapshot().Failures)
+
+	openStream(t, circuit, io.EOF).RecvMsg(nil)
+	openStream(t, circuit, status.Error(codes.Canceled, "client went away")).RecvMsg(nil)
+
+	assert.Equal(t, breaker.Snapshot{Name: t.Name(), State: breaker.Closed}, circuit.Snapshot())
+}
@@ -1,24 +1,24 @@
 package orderclient
 
 import (
+	"api-gateway/clients/dialer"
+	"api-gateway/config"
 	proto "api-gateway/proto/order"
 	"log"
-
-	"google.golang.org/grpc"
-	"google.golang.org/grpc/credentials/insecure"
+	"log/slog"
 )
 
 var (
 	OrderServiceClient proto.OrderServiceClient
 )
 
-func InitOrderClient() {
-	conn, err := grpc.Dial("localhost:9002", grpc.WithTransportCredentials(insecure.NewCredentials()), grpc.WithBlock())
+func InitOrderClient(upstream config.Upstream) {
+	conn, err := dialer.Dial("order", upstream, proto.OrderService_GetAllOrdersByUserId_FullMethodName)
 
 	if err != nil {
-		log.Fatal("Failed to connect to the Order Microservice")
+		log.Fatalf("Failed to configure the Order Microservice client: %v", err)
 	}
 
-	log.Printf("gRPC Client connected to Order Microservice")
+	slog.Info("grpc client configured", "upstream", "order", "target", upstream.Target)
 	OrderServiceClient = proto.NewOrderServiceClient(conn)
 }
@@ -1,24 +1,42 @@
 package productclient
 
 import (
+	"api-gateway/clients/dialer"
+	"api-gateway/config"
 	proto "api-gateway/proto/product"
 	"log"
-
-	"google.golang.org/grpc"
-	"google.golang.org/grpc/credentials/insecure"
+	"log/slog"
 )
 
 var (
 	ProductServiceClient proto.ProductServiceClient
 )
 
-func InitProductClient() {
-	conn, err := grpc.Dial("localhost:9001", grpc.WithTransportCredentials(insecure.NewCredentials()), grpc.WithBlock())
+var (
+	idempotentMethods = []string{
+		proto.ProductService_GetAllProducts_FullMethodName,
+		proto.ProductService_GetProduct_FullMethodName,
+		proto.ProductService_ListLowStockProducts_FullMethodName,
+		proto.ProductService_ListDeletedProducts_FullMethodName,
+		proto.ProductService_GetAllCategories_FullMethodName,
+		proto.ProductService_GetCategory_FullMethodName,
+		proto.ProductService_GetSku_FullMethodName,
+		proto.ProductService_GetAllWarehouses_FullMethodName,
+		proto.ProductService_GetWarehouse_FullMethodName,
+		proto.ProductService_GetPriceHistory_FullMethodName,
+		proto.ProductService_GetAllPromotions_FullMethodName,
+		proto.ProductService_PriceCart_FullMethodName,
+		proto.ProductService_ExportProducts_FullMethodName,
+	}
+)
+
+func InitProductClient(upstream config.Upstream) {
+	conn, err := dialer.Dial("product", upstream, idempotentMethods...)
 
 	if err != nil {
-		log.Fatal("Failed to connect to the Product Microservice")
+		log.Fatalf("Failed to configure the Product Microservice client: %v", err)
 	}
 
-	log.Printf("gRPC Client connected to Product Microservice")
+	slog.Info("grpc client configured", "upstream", "product", "target", upstream.Target)
 	ProductServiceClient = proto.NewProductServiceClient(conn)
 }
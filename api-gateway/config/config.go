@@ -0,0 +1,458 @@
+package config
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net"
+	"os"
+	"strconv"
+	"time"
+)
+
+const (
+	AnonymousRole = "ANONYMOUS"
+)
+
+var (
+	logLevels      = map[string]bool{"debug": true, "info": true, "warn": true, "error": true}
+	rateLimitRoles = map[string]bool{"ADMIN": true, "REGULAR": true, "INVENTORY": true, AnonymousRole: true}
+)
+
+type Duration time.Duration
+
+func (d *Duration) UnmarshalJSON(data []byte) error {
+	var value string
+
+	if err := json.Unmarshal(data, &value); err != nil {
+		return fmt.Errorf("duration must be a string such as \"5s\": %w", err)
+	}
+
+	parsed, err := time.ParseDuration(value)
+
+	if err != nil {
+		return err
+	}
+
+	*d = Duration(parsed)
+
+	return nil
+}
+
+type TLS struct {
+	Enabled    bool   `json:"enabled"`
+	CertFile   string `json:"certFile"`
+	KeyFile    string `json:"keyFile"`
+	CAFile     string `json:"caFile"`
+	ServerName string `json:"serverName"`
+}
+
+type Retry struct {
+	MaxAttempts    int      `json:"maxAttempts"`
+	InitialBackoff Duration `json:"initialBackoff"`
+	MaxBackoff     Duration `json:"maxBackoff"`
+}
+
+type Breaker struct {
+	FailureThreshold int      `json:"failureThreshold"`
+	OpenTimeout      Duration `json:"openTimeout"`
+}
+
+type Upstream struct {
+	Target        string   `json:"target"`
+	Deadline      Duration `json:"deadline"`
+	Keepalive     Duration `json:"keepalive"`
+	MaxConcurrent int      `json:"maxConcurrent"`
+	Retry         Retry    `json:"retry"`
+	Breaker       Breaker  `json:"breaker"`
+	TLS           TLS      `json:"tls"`
+}
+
+type Upstreams struct {
+	Product Upstream `json:"product"`
+	Order   Upstream `json:"order"`
+	Auth    Upstream `json:"auth"`
+}
+
+type Features struct {
+	InventoryStream   bool `json:"inventoryStream"`
+	ImportExport      bool `json:"importExport"`
+	InternalStockPath bool `json:"internalStockPath"`
+}
+
+type Limit struct {
+	Requests int      `json:"requests"`
+	Per      Duration `json:"per"`
+}
+
+type RateLimitRule struct {
+	Route string `json:"route"`
+	Role  string `json:"role"`
+	Limit
+}
+
+type RateLimits struct {
+	Enabled           bool            `json:"enabled"`
+	TrustForwardedFor bool            `json:"trustForwardedFor"`
+	Authentication    Limit           `json:"authentication"`
+	Default           Limit           `json:"default"`
+	Rules             []RateLimitRule `json:"rules"`
+}
+
+type Log struct {
+	Level  string `json:"level"`
+	Format string `json:"format"`
+}
+
+type Config struct {
+	ListenAddress string     `json:"listenAddress"`
+	TLS           TLS        `json:"tls"`
+	InternalToken string     `json:"internalToken"`
+	Upstreams     Upstreams  `json:"upstreams"`
+	Features      Features   `json:"features"`
+	RateLimits    RateLimits `json:"rateLimits"`
+	Log           Log        `json:"log"`
+}
+
+func Default() Config {
+	retry := Retry{MaxAttempts: 3, InitialBackoff: Duration(100 * time.Millisecond), MaxBackoff: Duration(time.Second)}
+	breaker := Breaker{FailureThreshold: 5, OpenTimeout: Duration(30 * time.Second)}
+
+	return Config{
+		ListenAddress: ":8080",
+		Upstreams: Upstreams{
+			Product: Upstream{Target: "localhost:9001", Deadline: Duration(5 * time.Second), Keepalive: Duration(time.Minute), MaxConcurrent: 100, Retry: retry, Breaker: breaker},
+			Order:   Upstream{Target: "localhost:9002", Deadline: Duration(10 * time.Second), Keepalive: Duration(5 * time.Minute), MaxConcurrent: 50, Retry: retry, Breaker: breaker},
+			Auth:    Upstream{Target: "localhost:9003", Deadline: Duration(3 * time.Second), Keepalive: Duration(time.Minute), MaxConcurrent: 200, Retry: retry, Breaker: breaker},
+		},
+		Features: Features{InventoryStream: true, ImportExport: true},
+		RateLimits: RateLimits{
+			Enabled:        true,
+			Authentication: Limit{Requests: 1200, Per: Duration(time.Minute)},
+			Default:        Limit{Requests: 120, Per: Duration(time.Minute)},
+			Rules: []RateLimitRule{
+				{Route: "/login", Limit: Limit{Requests: 10, Per: Duration(time.Minute)}},
+				{Route: "/register", Limit: Limit{Requests: 5, Per: Duration(time.Minute)}},
+				{Role: "ADMIN", Limit: Limit{Requests: 600, Per: Duration(time.Minute)}},
+			},
+		},
+		Log: Log{Level: "info", Format: "text"},
+	}
+}
+
+func Load(path string) (*Config, error) {
+	cfg := Default()
+
+	if len(path) != 0 {
+		file, err := os.Open(path)
+
+		if err != nil {
+			return nil, err
+		}
+		defer file.Close()
+
+		decoder := json.NewDecoder(file)
+		decoder.DisallowUnknownFields()
+
+		if err := decoder.Decode(&cfg); err != nil {
+			return nil, fmt.Errorf("invalid config file %s: %w", path, err)
+		}
+	}
+
+	if err := cfg.applyEnv(); err != nil {
+		return nil, err
+	}
+
+	if err := cfg.Validate(); err != nil {
+		return nil, err
+	}
+
+	return &cfg, nil
+}
+
+func (cfg *Config) applyEnv() error {
+	setString(&cfg.ListenAddress, "GATEWAY_LISTEN_ADDRESS")
+	setString(&cfg.Log.Level, "LOG_LEVEL")
+	setString(&cfg.Log.Format, "LOG_FORMAT")
+	setString(&cfg.TLS.CertFile, "GATEWAY_TLS_CERT_FILE")
+	setString(&cfg.TLS.KeyFile, "GATEWAY_TLS_KEY_FILE")
+	setString(&cfg.InternalToken, "INTERNAL_API_TOKEN")
+
+	if err := setBool(&cfg.TLS.Enabled, "GATEWAY_TLS_ENABLED"); err != nil {
+		return err
+	}
+
+	upstreams := map[string]*Upstream{
+		"PRODUCT_SERVICE": &cfg.Upstreams.Product,
+		"ORDER_SERVICE":   &cfg.Upstreams.Order,
+		"AUTH_SERVICE":    &cfg.Upstreams.Auth,
+	}
+
+	for prefix, upstream := range upstreams {
+		setString(&upstream.Target, prefix+"_ADDRESS")
+		setString(&upstream.TLS.CAFile, prefix+"_TLS_CA_FILE")
+		setString(&upstream.TLS.CertFile, prefix+"_TLS_CERT_FILE")
+		setString(&upstream.TLS.KeyFile, prefix+"_TLS_KEY_FILE")
+		setString(&upstream.TLS.ServerName, prefix+"_TLS_SERVER_NAME")
+
+		if err := setBool(&upstream.TLS.Enabled, prefix+"_TLS_ENABLED"); err != nil {
+			return err
+		}
+
+		integers := map[string]*int{
+			prefix + "_MAX_CONCURRENT":            &upstream.MaxConcurrent,
+			prefix + "_RETRY_MAX_ATTEMPTS":        &upstream.Retry.MaxAttempts,
+			prefix + "_BREAKER_FAILURE_THRESHOLD": &upstream.Breaker.FailureThreshold,
+		}
+
+		for name, integer := range integers {
+			if err := setInt(integer, name); err != nil {
+				return err
+			}
+		}
+
+		durations := map[string]*Duration{
+			prefix + "_DEADLINE":              &upstream.Deadline,
+			prefix + "_KEEPALIVE":             &upstream.Keepalive,
+			prefix + "_RETRY_INITIAL_BACKOFF": &upstream.Retry.InitialBackoff,
+			prefix + "_RETRY_MAX_BACKOFF":     &upstream.Retry.MaxBackoff,
+			prefix + "_BREAKER_OPEN_TIMEOUT":  &upstream.Breaker.OpenTimeout,
+		}
+
+		for name, duration := range durations {
+			if err := setDuration(duration, name); err != nil {
+				return err
+			}
+		}
+	}
+
+	if err := setBool(&cfg.RateLimits.Enabled, "RATE_LIMIT_ENABLED"); err != nil {
+		return err
+	}
+
+	if err := setBool(&cfg.RateLimits.TrustForwardedFor, "RATE_LIMIT_TRUST_FORWARDED_FOR"); err != nil {
+		return err
+	}
+
+	if err := setInt(&cfg.RateLimits.Default.Requests, "RATE_LIMIT_REQUESTS"); err != nil {
+		return err
+	}
+
+	if err := setDuration(&cfg.RateLimits.Default.Per, "RATE_LIMIT_PER"); err != nil {
+		return err
+	}
+
+	if err := setInt(&cfg.RateLimits.Authentication.Requests, "RATE_LIMIT_AUTHENTICATION_REQUESTS"); err != nil {
+		return err
+	}
+
+	if err := setDuration(&cfg.RateLimits.Authentication.Per, "RATE_LIMIT_AUTHENTICATION_PER"); err != nil {
+		return err
+	}
+
+	features := map[string]*bool{
+		"FEATURE_INVENTORY_STREAM":    &cfg.Features.InventoryStream,
+		"FEATURE_IMPORT_EXPORT":       &cfg.Features.ImportExport,
+		"FEATURE_INTERNAL_STOCK_PATH": &cfg.Features.InternalStockPath,
+	}
+
+	for name, feature := range features {
+		if err := setBool(feature, name); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (cfg *Config) Validate() error {
+	if _, _, err := net.SplitHostPort(cfg.ListenAddress); err != nil {
+		return fmt.Errorf("invalid listen address %q: %w", cfg.ListenAddress, err)
+	}
+
+	if !logLevels[cfg.Log.Level] {
+		return fmt.Errorf("unknown log level %s", cfg.Log.Level)
+	}
+
+	if cfg.Log.Format != "text" && cfg.Log.Format != "json" {
+		return fmt.Errorf("unknown log format %s, expected text or json", cfg.Log.Format)
+	}
+
+	if err := cfg.TLS.validate("gateway", true); err != nil {
+		return err
+	}
+
+	if cfg.Features.InternalStockPath && len(cfg.InternalToken) == 0 {
+		return errors.New("internal token is required when the internal stock path is enabled")
+	}
+
+	upstreams := map[string]Upstream{
+		"product": cfg.Upstreams.Product,
+		"order":   cfg.Upstreams.Order,
+		"auth":    cfg.Upstreams.Auth,
+	}
+
+	for name, upstream := range upstreams {
+		if _, _, err := net.SplitHostPort(upstream.Target); err != nil {
+			return fmt.Errorf("invalid %s upstream target %q: %w", name, upstream.Target, err)
+		}
+
+		if upstream.Deadline <= 0 {
+			return fmt.Errorf("%s upstream deadline must be greater than 0", name)
+		}
+
+		if upstream.Keepalive < Duration(10*time.Second) {
+			return fmt.Errorf("%s upstream keepalive must be at least 10s", name)
+		}
+
+		if upstream.Retry.MaxAttempts < 1 || upstream.Retry.MaxAttempts > 5 {
+			return fmt.Errorf("%s upstream retry attempts must be between 1 and 5", name)
+		}
+
+		if upstream.Retry.InitialBackoff <= 0 || upstream.Retry.MaxBackoff < upstream.Retry.InitialBackoff {
+			return fmt.Errorf("%s upstream retry backoff must be positive and max backoff cannot be below the initial backoff", name)
+		}
+
+		if upstream.MaxConcurrent < 1 {
+			return fmt.Errorf("%s upstream max concurrent requests must be at least 1", name)
+		}
+
+		if upstream.Breaker.FailureThreshold < 1 {
+			return fmt.Errorf("%s upstream breaker failure threshold must be at least 1", name)
+		}
+
+		if upstream.Breaker.OpenTimeout < Duration(time.Second) {
+			return fmt.Errorf("%s upstream breaker open timeout must be at least 1s", name)
+		}
+
+		if err := upstream.TLS.validate(name+" upstream", false); err != nil {
+			return err
+		}
+	}
+
+	return cfg.RateLimits.validate()
+}
+
+func (limits RateLimits) validate() error {
+	if err := limits.Authentication.validate("authentication rate limit"); err != nil {
+		return err
+	}
+
+	if err := limits.Default.validate("default rate limit"); err != nil {
+		return err
+	}
+
+	for idx, rule := range limits.Rules {
+		if len(rule.Route) == 0 && len(rule.Role) == 0 {
+			return fmt.Errorf("rate limit rule %d needs a route or a role", idx)
+		}
+
+		if len(rule.Role) != 0 && !rateLimitRoles[rule.Role] {
+			return fmt.Errorf("rate limit rule %d has an unknown role %s", idx, rule.Role)
+		}
+
+		if err := rule.Limit.validate(fmt.Sprintf("rate limit rule %d", idx)); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (limit Limit) validate(name string) error {
+	if limit.Requests < 1 {
+		return fmt.Errorf("%s must allow at least 1 request", name)
+	}
+
+	if limit.Per <= 0 {
+		return fmt.Errorf("%s period must be greater than 0", name)
+	}
+
+	return nil
+}
+
+func (tls TLS) validate(name string, server bool) error {
+	if !tls.Enabled {
+		return nil
+	}
+
+	if server && (len(tls.CertFile) == 0 || len(tls.KeyFile) == 0) {
+		return fmt.Errorf("%s tls requires a certificate and a key file", name)
+	}
+
+	if (len(tls.CertFile) == 0) != (len(tls.KeyFile) == 0) {
+		return fmt.Errorf("%s tls certificate and key file must be set together", name)
+	}
+
+	for _, path := range []string{tls.CertFile, tls.KeyFile, tls.CAFile} {
+		if len(path) == 0 {
+			continue
+		}
+
+		if _, err := os.Stat(path); err != nil {
+			return fmt.Errorf("%s tls file %s is not readable: %w", name, path, err)
+		}
+	}
+
+	return nil
+}
+
+func setString(field *string, name string) {
+	if value, ok := os.LookupEnv(name); ok {
+		*field = value
+	}
+}
+
+func setBool(field *bool, name string) error {
+	value, ok := os.LookupEnv(name)
+
+	if !ok {
+		return nil
+	}
+
+	parsed, err := strconv.ParseBool(value)
+
+	if err != nil {
+		return fmt.Errorf("invalid value %q for %s: %w", value, name, err)
+	}
+
+	*field = parsed
+
+	return nil
+}
+
+func setInt(field *int, name string) error {
+	value, ok := os.LookupEnv(name)
+
+	if !ok {
+		return nil
+	}
+
+	parsed, err := strconv.Atoi(value)
+
+	if err != nil {
+		return fmt.Errorf("invalid value %q for %s: %w", value, name, err)
+	}
+
+	*field = parsed
+
+	return nil
+}
+
+func setDuration(field *Duration, name string) error {
+	value, ok := os.LookupEnv(name)
+
+	if !ok {
+		return nil
+	}
+
+	parsed, err := time.ParseDuration(value)
+
+	if err != nil {
+		return fmt.Errorf("invalid value %q for %s: %w", value, name, err)
+	}
+
+	*field = Duration(parsed)
+
+	return nil
+}
@@ -0,0 +1,150 @@
+package config
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func writeConfig(t *testing.T, content string) string {
+	path := filepath.Join(t.TempDir(), "config.json")
+	assert.NoError(t, os.WriteFile(path, []byte(content), 0600))
+
+	return path
+}
+
+func TestShouldLoadUseTheDefaults(t *testing.T) {
+	cfg, err := Load("")
+
+	assert.NoError(t, err)
+	assert.Equal(t, Default(), *cfg)
+}
+
+func TestShouldLoadMergeFileAndEnvironment(t *testing.T) {
+	path := writeConfig(t, `{"listenAddress": ":7000", "upstreams": {"order": {"target": "orders:9002", "deadline": "20s", "keepalive": "1m", "maxConcurrent": 10, "retry": {"maxAttempts": 2, "initialBackoff": "50ms", "maxBackoff": "500ms"}, "breaker": {"failureThreshold": 3, "openTimeout": "10s"}}}}`)
+	t.Setenv("GATEWAY_LISTEN_ADDRESS", ":7100")
+	t.Setenv("PRODUCT_SERVICE_ADDRESS", "products:9001")
+	t.Setenv("PRODUCT_SERVICE_DEADLINE", "2s")
+	t.Setenv("PRODUCT_SERVICE_BREAKER_FAILURE_THRESHOLD", "8")
+	t.Setenv("ORDER_SERVICE_RETRY_MAX_ATTEMPTS", "4")
+	t.Setenv("RATE_LIMIT_REQUESTS", "30")
+	t.Setenv("RATE_LIMIT_PER", "10s")
+	t.Setenv("RATE_LIMIT_TRUST_FORWARDED_FOR", "true")
+	t.Setenv("RATE_LIMIT_AUTHENTICATION_REQUESTS", "300")
+	t.Setenv("FEATURE_IMPORT_EXPORT", "false")
+	t.Setenv("FEATURE_INTERNAL_STOCK_PATH", "true")
+	t.Setenv("INTERNAL_API_TOKEN", "secret")
+	t.Setenv("LOG_FORMAT", "json")
+
+	cfg, err := Load(path)
+
+	assert.NoError(t, err)
+	assert.Equal(t, ":7100", cfg.ListenAddress)
+	assert.Equal(t, "products:9001", cfg.Upstreams.Product.Target)
+	assert.Equal(t, Duration(2*time.Second), cfg.Upstreams.Product.Deadline)
+	assert.Equal(t, 8, cfg.Upstreams.Product.Breaker.FailureThreshold)
+	assert.Equal(t, "orders:9002", cfg.Upstreams.Order.Target)
+	assert.Equal(t, Duration(20*time.Second), cfg.Upstreams.Order.Deadline)
+	assert.Equal(t, 4, cfg.Upstreams.Order.Retry.MaxAttempts)
+	assert.Equal(t, Duration(50*time.Millisecond), cfg.Upstreams.Order.Retry.InitialBackoff)
+	assert.Equal(t, 3, cfg.Upstreams.Order.Breaker.FailureThreshold)
+	assert.Equal(t, Default().Upstreams.Auth, cfg.Upstreams.Auth)
+	assert.Equal(t, Limit{Requests: 30, Per: Duration(10 * time.Second)}, cfg.RateLimits.Default)
+	assert.True(t, cfg.RateLimits.TrustForwardedFor)
+	assert.Equal(t, Limit{Requests: 300, Per: Duration(time.Minute)}, cfg.RateLimits.Authentication)
+	assert.Equal(t, Features{InventoryStream: true, ImportExport: false, InternalStockPath: true}, cfg.Features)
+	assert.Equal(t, "secret", cfg.InternalToken)
+	assert.Equal(t, Log{Level: "info", Format: "json"}, cfg.Log)
+}
+
+func TestShouldLoadThrowAnErrorIfTheEnvironmentIsMalformed(t *testing.T) {
+	tests := []struct {
+		name     string
+		variable string
+		value    string
+		message  string
+	}{
+		{"integer", "AUTH_SERVICE_MAX_CONCURRENT", "many", `invalid value "many" for AUTH_SERVICE_MAX_CONCURRENT: strconv.Atoi: parsing "many": invalid syntax`},
+		{"duration", "ORDER_SERVICE_BREAKER_OPEN_TIMEOUT", "30", `invalid value "30" for ORDER_SERVICE_BREAKER_OPEN_TIMEOUT: time: missing unit in duration "30"`},
+		{"boolean", "RATE_LIMIT_ENABLED", "sometimes", `invalid value "sometimes" for RATE_LIMIT_ENABLED: strconv.ParseBool: parsing "sometimes": invalid syntax`},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			t.Setenv(test.variable, test.value)
+
+			_, err := Load("")
+
+			assert.EqualError(t, err, test.message)
+		})
+	}
+}
+
+func TestShouldLoadThrowAnErrorIfTheFileIsInvalid(t *testing.T) {
+	_, err1 := Load(filepath.Join(t.TempDir(), "missing.json"))
+	_, err2 := Load(writeConfig(t, `{"upstreams": {"product": {"deadline": 5}}}`))
+	_, err3 := Load(writeConfig(t, `{"listenAddres": ":8080"}`))
+
+	assert.Error(t, err1)
+	assert.Error(t, err2)
+	assert.Error(t, err3)
+}
+
+func TestShouldValidateRejectInvalidSettings(t *testing.T) {
+	tests := []struct {
+		name    string
+		modify  func(cfg *Config)
+		message string
+	}{
+		{"listen address", func(cfg *Config) { cfg.ListenAddress = "8080" }, `invalid listen address "8080": address 8080: missing port in address`},
+		{"log level", func(cfg *Config) { cfg.Log.Level = "verbose" }, "unknown log level verbose"},
+		{"log format", func(cfg *Config) { cfg.Log.Format = "xml" }, "unknown log format xml, expected text or json"},
+		{"gateway tls", func(cfg *Config) { cfg.TLS.Enabled = true }, "gateway tls requires a certificate and a key file"},
+		{"internal token", func(cfg *Config) { cfg.Features.InternalStockPath = true }, "internal token is required when the internal stock path is enabled"},
+		{"upstream target", func(cfg *Config) { cfg.Upstreams.Order.Target = "orders" }, `invalid order upstream target "orders": address orders: missing port in address`},
+		{"zero deadline", func(cfg *Config) { cfg.Upstreams.Product.Deadline = 0 }, "product upstream deadline must be greater than 0"},
+		{"negative deadline", func(cfg *Config) { cfg.Upstreams.Auth.Deadline = Duration(-time.Second) }, "auth upstream deadline must be greater than 0"},
+		{"keepalive", func(cfg *Config) { cfg.Upstreams.Product.Keepalive = Duration(9 * time.Second) }, "product upstream keepalive must be at least 10s"},
+		{"no retry attempts", func(cfg *Config) { cfg.Upstreams.Order.Retry.MaxAttempts = 0 }, "order upstream retry attempts must be between 1 and 5"},
+		{"too many retry attempts", func(cfg *Config) { cfg.Upstreams.Order.Retry.MaxAttempts = 6 }, "order upstream retry attempts must be between 1 and 5"},
+		{"retry initial backoff", func(cfg *Config) { cfg.Upstreams.Auth.Retry.InitialBackoff = 0 }, "auth upstream retry backoff must be positive and max backoff cannot be below the initial backoff"},
+		{"retry max backoff", func(cfg *Config) { cfg.Upstreams.Auth.Retry.MaxBackoff = Duration(50 * time.Millisecond) }, "auth upstream retry backoff must be positive and max backoff cannot be below the initial backoff"},
+		{"max concurrent", func(cfg *Config) { cfg.Upstreams.Product.MaxConcurrent = 0 }, "product upstream max concurrent requests must be at least 1"},
+		{"breaker threshold", func(cfg *Config) { cfg.Upstreams.Product.Breaker.FailureThreshold = 0 }, "product upstream breaker failure threshold must be at least 1"},
+		{"breaker open timeout", func(cfg *Config) { cfg.Upstreams.Order.Breaker.OpenTimeout = Duration(500 * time.Millisecond) }, "order upstream breaker open timeout must be at least 1s"},
+		{"upstream tls", func(cfg *Config) {
+			cfg.Upstreams.Auth.TLS = TLS{Enabled: true, CertFile: "client.pem"}
+		}, "auth upstream tls certificate and key file must be set together"},
+		{"upstream tls files", func(cfg *Config) {
+			cfg.Upstreams.Auth.TLS = TLS{Enabled: true, CAFile: "missing-ca.pem"}
+		}, "auth upstream tls file missing-ca.pem is not readable: stat missing-ca.pem: no such file or directory"},
+		{"authentication rate limit", func(cfg *Config) { cfg.RateLimits.Authentication.Requests = 0 }, "authentication rate limit must allow at least 1 request"},
+		{"default rate limit requests", func(cfg *Config) { cfg.RateLimits.Default.Requests = 0 }, "default rate limit must allow at least 1 request"},
+		{"default rate limit period", func(cfg *Config) { cfg.RateLimits.Default.Per = 0 }, "default rate limit period must be greater than 0"},
+		{"rate limit rule target", func(cfg *Config) {
+			cfg.RateLimits.Rules = append(cfg.RateLimits.Rules, RateLimitRule{Limit: Limit{Requests: 1, Per: Duration(time.Second)}})
+		}, "rate limit rule 3 needs a route or a role"},
+		{"rate limit rule role", func(cfg *Config) { cfg.RateLimits.Rules[2].Role = "ROOT" }, "rate limit rule 2 has an unknown role ROOT"},
+		{"rate limit rule requests", func(cfg *Config) { cfg.RateLimits.Rules[0].Requests = 0 }, "rate limit rule 0 must allow at least 1 request"},
+		{"rate limit rule period", func(cfg *Config) { cfg.RateLimits.Rules[1].Per = Duration(-time.Minute) }, "rate limit rule 1 period must be greater than 0"},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			cfg := Default()
+			test.modify(&cfg)
+
+			assert.EqualError(t, cfg.Validate(), test.message)
+		})
+	}
+}
+
+func TestShouldValidateAcceptAnonymousRateLimitRules(t *testing.T) {
+	cfg := Default()
+	cfg.RateLimits.Rules = append(cfg.RateLimits.Rules, RateLimitRule{Route: "/products", Role: AnonymousRole, Limit: Limit{Requests: 30, Per: Duration(time.Minute)}})
+
+	assert.NoError(t, cfg.Validate())
+}
@@ -5,10 +5,16 @@ import (
 )
 
 type RegisterUserRequest struct {
-	Name     string         `json:"name"`
-	Email    string         `json:"email"`
-	Password string         `json:"password"`
-	UserType proto.UserType `json:"user_type"`
+	Name     string `json:"name"`
+	Email    string `json:"email"`
+	Password string `json:"password"`
+}
+
+type CreateUserRequest struct {
+	Name     string          `json:"name"`
+	Email    string          `json:"email"`
+	Password string          `json:"password"`
+	UserType *proto.UserType `json:"user_type"`
 }
 
 type RegisterUserResponse struct {
@@ -0,0 +1,8 @@
+package dto
+
+type Breaker struct {
+	Upstream          string `json:"upstream"`
+	State             string `json:"state"`
+	Failures          int    `json:"failures"`
+	RetryAfterSeconds int64  `json:"retryAfterSeconds,omitempty"`
+}
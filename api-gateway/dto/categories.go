@@ -0,0 +1,29 @@
+package dto
+
+type Category struct {
+	Id          int32  `json:"id"`
+	Name        string `json:"name"`
+	Description string `json:"description"`
+	ParentId    int32  `json:"parentId"`
+}
+
+type CreateCategoryRequest struct {
+	Name        string `json:"name"`
+	Description string `json:"description"`
+	ParentId    int32  `json:"parentId"`
+}
+
+type UpdateCategoryRequest struct {
+	Name        *string `json:"name"`
+	Description *string `json:"description"`
+	ParentId    *int32  `json:"parentId"`
+}
+
+type DeletedCategoryResponse struct {
+	Id int32 `json:"id"`
+}
+
+type ProductCategoryResponse struct {
+	ProductId  int32 `json:"productId"`
+	CategoryId int32 `json:"categoryId"`
+}
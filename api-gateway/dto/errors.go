@@ -1,6 +1,120 @@
 package dto
 
+import (
+	"api-gateway/requestid"
+	"encoding/json"
+	"net/http"
+	"strconv"
+	"time"
+
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+const (
+	CodeInvalidArgument    = "invalid_argument"
+	CodeUnauthenticated    = "unauthenticated"
+	CodePermissionDenied   = "permission_denied"
+	CodeNotFound           = "not_found"
+	CodeAlreadyExists      = "already_exists"
+	CodeVersionConflict    = "version_conflict"
+	CodeFailedPrecondition = "failed_precondition"
+	CodeRateLimited        = "rate_limited"
+	CodeInternal           = "internal"
+	CodeUnavailable        = "unavailable"
+	CodeDeadlineExceeded   = "deadline_exceeded"
+)
+
+var (
+	rpcStatuses = map[codes.Code]int{
+		codes.InvalidArgument:    http.StatusBadRequest,
+		codes.Unknown:            http.StatusBadRequest,
+		codes.Unauthenticated:    http.StatusUnauthorized,
+		codes.PermissionDenied:   http.StatusForbidden,
+		codes.NotFound:           http.StatusNotFound,
+		codes.AlreadyExists:      http.StatusConflict,
+		codes.Aborted:            http.StatusPreconditionFailed,
+		codes.FailedPrecondition: http.StatusUnprocessableEntity,
+		codes.ResourceExhausted:  http.StatusTooManyRequests,
+		codes.Internal:           http.StatusInternalServerError,
+		codes.Unimplemented:      http.StatusNotImplemented,
+		codes.Unavailable:        http.StatusServiceUnavailable,
+		codes.DeadlineExceeded:   http.StatusGatewayTimeout,
+		codes.Canceled:           http.StatusRequestTimeout,
+	}
+
+	errorCodes = map[int]string{
+		http.StatusBadRequest:          CodeInvalidArgument,
+		http.StatusUnauthorized:        CodeUnauthenticated,
+		http.StatusForbidden:           CodePermissionDenied,
+		http.StatusNotFound:            CodeNotFound,
+		http.StatusConflict:            CodeAlreadyExists,
+		http.StatusPreconditionFailed:  CodeVersionConflict,
+		http.StatusUnprocessableEntity: CodeFailedPrecondition,
+		http.StatusTooManyRequests:     CodeRateLimited,
+		http.StatusServiceUnavailable:  CodeUnavailable,
+		http.StatusGatewayTimeout:      CodeDeadlineExceeded,
+	}
+)
+
 type Error struct {
-	Status  int    `json:"status"`
-	Message string `json:"message"`
+	Status     int           `json:"status"`
+	Code       string        `json:"code"`
+	Message    string        `json:"message"`
+	RequestID  string        `json:"requestId,omitempty"`
+	RetryAfter time.Duration `json:"-"`
+}
+
+func RPCError(err error) Error {
+	rpcStatus := status.Convert(err)
+	httpStatus, ok := rpcStatuses[rpcStatus.Code()]
+
+	if !ok {
+		httpStatus = http.StatusInternalServerError
+	}
+
+	res := Error{Status: httpStatus, Code: errorCode(httpStatus), Message: rpcStatus.Message()}
+
+	for _, detail := range rpcStatus.Details() {
+		if retryInfo, ok := detail.(*errdetails.RetryInfo); ok {
+			res.RetryAfter = retryInfo.GetRetryDelay().AsDuration()
+		}
+	}
+
+	return res
+}
+
+func (e *Error) WriteHeader(respWriter http.ResponseWriter) {
+	if len(e.RequestID) == 0 {
+		e.RequestID = respWriter.Header().Get(requestid.Header)
+	}
+
+	if e.RetryAfter > 0 {
+		respWriter.Header().Set("Retry-After", strconv.FormatInt(int64((e.RetryAfter+time.Second-1)/time.Second), 10))
+	}
+
+	respWriter.WriteHeader(e.Status)
+}
+
+func (e Error) MarshalJSON() ([]byte, error) {
+	type jsonError Error
+
+	if len(e.Code) == 0 {
+		e.Code = errorCode(e.Status)
+	}
+
+	return json.Marshal(jsonError(e))
+}
+
+func errorCode(httpStatus int) string {
+	if code, ok := errorCodes[httpStatus]; ok {
+		return code
+	}
+
+	if httpStatus >= http.StatusInternalServerError {
+		return CodeInternal
+	}
+
+	return CodeInvalidArgument
 }
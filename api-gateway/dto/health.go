@@ -0,0 +1,11 @@
+package dto
+
+const (
+	StatusReady    = "ready"
+	StatusNotReady = "not_ready"
+)
+
+type Readiness struct {
+	Status    string            `json:"status"`
+	Upstreams map[string]string `json:"upstreams"`
+}
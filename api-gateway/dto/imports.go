@@ -0,0 +1,30 @@
+package dto
+
+import (
+	"encoding/json"
+)
+
+// ImportProductRow is one product in an import file. Nil fields were missing
+// from the row and leave the existing product's value unchanged.
+type ImportProductRow struct {
+	Name             string       `json:"name"`
+	Description      *string      `json:"description"`
+	Price            *json.Number `json:"price"`
+	Currency         string       `json:"currency"`
+	Quantity         *int32       `json:"quantity"`
+	ReorderThreshold *int32       `json:"reorderThreshold"`
+}
+
+type ImportRowError struct {
+	Row     int32  `json:"row"`
+	Name    string `json:"name"`
+	Message string `json:"message"`
+}
+
+type ImportResult struct {
+	Created int32            `json:"created"`
+	Updated int32            `json:"updated"`
+	Failed  int32            `json:"failed"`
+	DryRun  bool             `json:"dryRun"`
+	Errors  []ImportRowError `json:"errors"`
+}
@@ -0,0 +1,71 @@
+package dto
+
+import (
+	proto "api-gateway/proto/product"
+	"currency"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math/big"
+	"strconv"
+)
+
+func PriceToMoney(price json.Number, code string) (*proto.Money, error) {
+	code, err := currency.Normalize(code)
+
+	if err != nil {
+		return nil, err
+	}
+
+	exponent, err := currency.Exponent(code)
+
+	if err != nil {
+		return nil, err
+	}
+
+	if len(price) == 0 {
+		price = "0"
+	}
+
+	amount, ok := new(big.Rat).SetString(price.String())
+
+	if !ok {
+		return nil, fmt.Errorf("invalid price %s", price)
+	}
+
+	amount.Mul(amount, new(big.Rat).SetInt(new(big.Int).Exp(big.NewInt(10), big.NewInt(int64(exponent)), nil)))
+
+	if !amount.IsInt() {
+		return nil, fmt.Errorf("price %s has too many decimal places for %s", price, code)
+	}
+
+	if !amount.Num().IsInt64() {
+		return nil, errors.New("price is out of range")
+	}
+
+	return &proto.Money{CurrencyCode: code, MinorUnits: amount.Num().Int64()}, nil
+}
+
+func PriceFromMoney(money *proto.Money) (json.Number, string, error) {
+	exponent, err := currency.Exponent(money.GetCurrencyCode())
+
+	if err != nil {
+		return "", "", err
+	}
+
+	units := money.GetMinorUnits()
+	sign := ""
+
+	if units < 0 {
+		sign = "-"
+		units = -units
+	}
+
+	if exponent == 0 {
+		return json.Number(sign + strconv.FormatInt(units, 10)), money.GetCurrencyCode(), nil
+	}
+
+	scale := new(big.Int).Exp(big.NewInt(10), big.NewInt(int64(exponent)), nil).Int64()
+
+	return json.Number(fmt.Sprintf("%s%d.%0*d", sign, units/scale, exponent, units%scale)), money.GetCurrencyCode(), nil
+}
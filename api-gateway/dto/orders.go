@@ -1,15 +1,57 @@
 package dto
 
+import (
+	orderproto "api-gateway/proto/order"
+	proto "api-gateway/proto/product"
+	"encoding/json"
+)
+
 type Cart struct {
-	Products []Product `json:"products"`
+	Products []CartProduct `json:"products"`
+}
+
+type CartProduct struct {
+	Id          int32       `json:"id"`
+	Name        string      `json:"name"`
+	Description string      `json:"description"`
+	Price       json.Number `json:"price"`
+	Discount    json.Number `json:"discount"`
+	Currency    string      `json:"currency"`
+	Quantity    int32       `json:"quantity"`
 }
 
 type CreateOrderRequest struct {
-	Products []Product `json:"products"`
+	Products    []Product `json:"products"`
+	CouponCodes []string  `json:"couponCodes"`
 }
 
 type CreateOrderResponse struct {
-	Id     int64 `json:"id"`
-	UserId int64 `json:"userId"`
-	Cart   Cart  `json:"cart"`
+	Id      int64       `json:"id"`
+	UserId  int64       `json:"userId"`
+	Cart    Cart        `json:"cart"`
+	Pricing *PricedCart `json:"pricing,omitempty"`
+}
+
+func CartProductFromProto(product *orderproto.Product) (CartProduct, error) {
+	price, currency, err := PriceFromMoney(&proto.Money{MinorUnits: product.UnitPrice.GetMinorUnits(), CurrencyCode: product.UnitPrice.GetCurrencyCode()})
+
+	if err != nil {
+		return CartProduct{}, err
+	}
+
+	discount, _, err := PriceFromMoney(&proto.Money{MinorUnits: product.Discount.GetMinorUnits(), CurrencyCode: currency})
+
+	if err != nil {
+		return CartProduct{}, err
+	}
+
+	return CartProduct{
+		Id:          int32(product.Id),
+		Name:        product.Name,
+		Description: product.Description,
+		Price:       price,
+		Discount:    discount,
+		Currency:    currency,
+		Quantity:    product.Quantity,
+	}, nil
 }
@@ -0,0 +1,28 @@
+package dto
+
+import (
+	"encoding/json"
+	"time"
+)
+
+type PriceChange struct {
+	Id          int32       `json:"id"`
+	ProductId   int32       `json:"productId"`
+	Price       json.Number `json:"price"`
+	Currency    string      `json:"currency"`
+	EffectiveAt time.Time   `json:"effectiveAt"`
+	AppliedAt   *time.Time  `json:"appliedAt,omitempty"`
+}
+
+type SchedulePriceChangeRequest struct {
+	Price       json.Number `json:"price"`
+	Currency    string      `json:"currency"`
+	EffectiveAt time.Time   `json:"effectiveAt"`
+}
+
+type PriceHistory struct {
+	Changes        []PriceChange `json:"changes"`
+	EffectivePrice json.Number   `json:"effectivePrice"`
+	Currency       string        `json:"currency"`
+	At             time.Time     `json:"at"`
+}
@@ -1,18 +1,39 @@
 package dto
 
+import (
+	"encoding/json"
+	"time"
+)
+
 type Product struct {
-	Id          int32   `json:"id"`
-	Name        string  `json:"name"`
-	Description string  `json:"description"`
-	Price       float64 `json:"price"`
-	Quantity    int32   `json:"quantity"`
+	Id               int32            `json:"id"`
+	Name             string           `json:"name"`
+	Description      string           `json:"description"`
+	Price            json.Number      `json:"price"`
+	Currency         string           `json:"currency,omitempty"`
+	Quantity         int32            `json:"quantity"`
+	Version          int64            `json:"version"`
+	Skus             []Sku            `json:"skus,omitempty"`
+	Stock            []WarehouseStock `json:"stock,omitempty"`
+	ReorderThreshold int32            `json:"reorderThreshold"`
+	DeletedAt        *time.Time       `json:"deletedAt,omitempty"`
 }
 
 type CreateProductRequest struct {
-	Name        string  `json:"name"`
-	Description string  `json:"description"`
-	Price       float64 `json:"price"`
-	Quantity    int32   `json:"quantity"`
+	Name             string      `json:"name"`
+	Description      string      `json:"description"`
+	Price            json.Number `json:"price"`
+	Currency         string      `json:"currency"`
+	Quantity         int32       `json:"quantity"`
+	ReorderThreshold int32       `json:"reorderThreshold"`
+}
+
+type UpdateProductDetailsRequest struct {
+	Name             *string      `json:"name"`
+	Description      *string      `json:"description"`
+	Price            *json.Number `json:"price"`
+	Currency         *string      `json:"currency"`
+	ReorderThreshold *int32       `json:"reorderThreshold"`
 }
 
 type DeletedProductResponse struct {
@@ -20,6 +41,20 @@ type DeletedProductResponse struct {
 }
 
 type UpdateProductQuantityRequest struct {
-	Id       int32 `json:"id"`
-	Quantity int32 `json:"quantity"`
+	Id          int32 `json:"id"`
+	SkuId       int32 `json:"skuId"`
+	WarehouseId int32 `json:"warehouseId"`
+	Quantity    int32 `json:"quantity"`
+}
+
+type RemoveOrderProductsRequest struct {
+	Products           []UpdateProductQuantityRequest `json:"products"`
+	AllocationStrategy string                         `json:"allocationStrategy"`
+}
+
+type InventoryUpdate struct {
+	ProductId int32     `json:"productId"`
+	Quantity  int32     `json:"quantity"`
+	Version   int64     `json:"version"`
+	ChangedAt time.Time `json:"changedAt"`
 }
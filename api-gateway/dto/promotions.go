@@ -0,0 +1,131 @@
+package dto
+
+import (
+	proto "api-gateway/proto/product"
+	"encoding/json"
+	"time"
+)
+
+type Promotion struct {
+	Id          int32        `json:"id"`
+	Name        string       `json:"name"`
+	Type        string       `json:"type"`
+	ProductId   int32        `json:"productId,omitempty"`
+	PercentOff  int32        `json:"percentOff,omitempty"`
+	AmountOff   *json.Number `json:"amountOff,omitempty"`
+	Currency    string       `json:"currency,omitempty"`
+	BuyQuantity int32        `json:"buyQuantity,omitempty"`
+	GetQuantity int32        `json:"getQuantity,omitempty"`
+	CouponCode  string       `json:"couponCode,omitempty"`
+	StartsAt    *time.Time   `json:"startsAt,omitempty"`
+	EndsAt      *time.Time   `json:"endsAt,omitempty"`
+}
+
+type CreatePromotionRequest struct {
+	Name        string       `json:"name"`
+	Type        string       `json:"type"`
+	ProductId   int32        `json:"productId"`
+	PercentOff  int32        `json:"percentOff"`
+	AmountOff   *json.Number `json:"amountOff"`
+	Currency    string       `json:"currency"`
+	BuyQuantity int32        `json:"buyQuantity"`
+	GetQuantity int32        `json:"getQuantity"`
+	CouponCode  string       `json:"couponCode"`
+	StartsAt    *time.Time   `json:"startsAt"`
+	EndsAt      *time.Time   `json:"endsAt"`
+}
+
+type DeletedPromotionResponse struct {
+	Id int32 `json:"id"`
+}
+
+type PriceCartRequest struct {
+	Products    []Product `json:"products"`
+	CouponCodes []string  `json:"couponCodes"`
+}
+
+type PricedCartLine struct {
+	ProductId     int64       `json:"productId"`
+	Name          string      `json:"name"`
+	Quantity      int32       `json:"quantity"`
+	UnitPrice     json.Number `json:"unitPrice"`
+	Subtotal      json.Number `json:"subtotal"`
+	Discount      json.Number `json:"discount"`
+	Total         json.Number `json:"total"`
+	PromotionId   int32       `json:"promotionId,omitempty"`
+	PromotionName string      `json:"promotionName,omitempty"`
+}
+
+type PricedCart struct {
+	Lines    []PricedCartLine `json:"lines"`
+	Currency string           `json:"currency"`
+	Subtotal json.Number      `json:"subtotal"`
+	Discount json.Number      `json:"discount"`
+	Total    json.Number      `json:"total"`
+}
+
+func CartToProto(products []Product) *proto.Cart {
+	cart := &proto.Cart{}
+
+	for _, product := range products {
+		cart.Products = append(cart.Products, &proto.CartProduct{
+			Id:          int64(product.Id),
+			Name:        product.Name,
+			Description: product.Description,
+			Quantity:    product.Quantity,
+		})
+	}
+
+	return cart
+}
+
+func PricedCartFromProto(priced *proto.PriceCartResponse) (PricedCart, error) {
+	res := PricedCart{Lines: make([]PricedCartLine, len(priced.Lines))}
+
+	for idx, line := range priced.Lines {
+		prices, err := pricesFromMoney(line.UnitPrice, line.Subtotal, line.Discount, line.Total)
+
+		if err != nil {
+			return PricedCart{}, err
+		}
+
+		res.Lines[idx] = PricedCartLine{
+			ProductId:     line.ProductId,
+			Name:          line.Name,
+			Quantity:      line.Quantity,
+			UnitPrice:     prices[0],
+			Subtotal:      prices[1],
+			Discount:      prices[2],
+			Total:         prices[3],
+			PromotionId:   line.PromotionId,
+			PromotionName: line.PromotionName,
+		}
+	}
+
+	totals, err := pricesFromMoney(priced.Subtotal, priced.Discount, priced.Total)
+
+	if err != nil {
+		return PricedCart{}, err
+	}
+
+	res.Currency = priced.Subtotal.GetCurrencyCode()
+	res.Subtotal, res.Discount, res.Total = totals[0], totals[1], totals[2]
+
+	return res, nil
+}
+
+func pricesFromMoney(amounts ...*proto.Money) ([]json.Number, error) {
+	prices := make([]json.Number, len(amounts))
+
+	for idx, amount := range amounts {
+		price, _, err := PriceFromMoney(amount)
+
+		if err != nil {
+			return nil, err
+		}
+
+		prices[idx] = price
+	}
+
+	return prices, nil
+}
@@ -0,0 +1,57 @@
+package dto
+
+import (
+	proto "api-gateway/proto/product"
+	"encoding/json"
+)
+
+type Sku struct {
+	Id         int32             `json:"id"`
+	ProductId  int32             `json:"productId"`
+	Code       string            `json:"code"`
+	Attributes map[string]string `json:"attributes"`
+	Price      *json.Number      `json:"price,omitempty"`
+	Currency   string            `json:"currency,omitempty"`
+	Quantity   int32             `json:"quantity"`
+}
+
+type CreateSkuRequest struct {
+	Code       string            `json:"code"`
+	Attributes map[string]string `json:"attributes"`
+	Price      *json.Number      `json:"price"`
+	Quantity   int32             `json:"quantity"`
+}
+
+type UpdateSkuRequest struct {
+	Code       *string            `json:"code"`
+	Attributes *map[string]string `json:"attributes"`
+	Price      *json.Number       `json:"price"`
+	ClearPrice bool               `json:"clearPrice"`
+}
+
+type DeletedSkuResponse struct {
+	Id int32 `json:"id"`
+}
+
+func SkuFromProto(sku *proto.Sku) (Sku, error) {
+	res := Sku{
+		Id:         sku.Id,
+		ProductId:  sku.ProductId,
+		Code:       sku.Code,
+		Attributes: sku.Attributes,
+		Quantity:   sku.Quantity,
+	}
+
+	if sku.PriceOverride != nil {
+		price, currency, err := PriceFromMoney(sku.PriceOverride)
+
+		if err != nil {
+			return Sku{}, err
+		}
+
+		res.Price = &price
+		res.Currency = currency
+	}
+
+	return res, nil
+}
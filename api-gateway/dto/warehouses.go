@@ -0,0 +1,34 @@
+package dto
+
+type Warehouse struct {
+	Id        int32   `json:"id"`
+	Name      string  `json:"name"`
+	Latitude  float64 `json:"latitude"`
+	Longitude float64 `json:"longitude"`
+	Priority  int32   `json:"priority"`
+	IsDefault bool    `json:"isDefault"`
+}
+
+type CreateWarehouseRequest struct {
+	Name      string  `json:"name"`
+	Latitude  float64 `json:"latitude"`
+	Longitude float64 `json:"longitude"`
+	Priority  int32   `json:"priority"`
+}
+
+type DeletedWarehouseResponse struct {
+	Id int32 `json:"id"`
+}
+
+type WarehouseStock struct {
+	WarehouseId int32 `json:"warehouseId"`
+	SkuId       int32 `json:"skuId,omitempty"`
+	Quantity    int32 `json:"quantity"`
+}
+
+type TransferStockRequest struct {
+	SkuId           int32 `json:"skuId"`
+	FromWarehouseId int32 `json:"fromWarehouseId"`
+	ToWarehouseId   int32 `json:"toWarehouseId"`
+	Quantity        int32 `json:"quantity"`
+}
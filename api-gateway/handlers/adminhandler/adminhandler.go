@@ -0,0 +1,27 @@
+package adminhandler
+
+import (
+	"api-gateway/clients/breaker"
+	"api-gateway/dto"
+	"encoding/json"
+	"math"
+	"net/http"
+)
+
+func GetBreakers(respWriter http.ResponseWriter, req *http.Request) {
+	respWriter.Header().Set("Content-Type", "application/json")
+
+	res := []dto.Breaker{}
+
+	for _, snapshot := range breaker.Snapshots() {
+		res = append(res, dto.Breaker{
+			Upstream:          snapshot.Name,
+			State:             string(snapshot.State),
+			Failures:          snapshot.Failures,
+			RetryAfterSeconds: int64(math.Ceil(snapshot.RetryAfter.Seconds())),
+		})
+	}
+
+	respWriter.WriteHeader(http.StatusOK)
+	json.NewEncoder(respWriter).Encode(res)
+}
@@ -6,7 +6,6 @@ import (
 	proto "api-gateway/proto/auth"
 	"encoding/json"
 	"net/http"
-	"strings"
 )
 
 func RegisterUser(respWriter http.ResponseWriter, req *http.Request) {
@@ -16,21 +15,23 @@ func RegisterUser(respWriter http.ResponseWriter, req *http.Request) {
 
 	if err := json.NewDecoder(req.Body).Decode(&newUser); err != nil {
 		errMessage := dto.Error{Status: http.StatusBadRequest, Message: err.Error()}
-		respWriter.WriteHeader(errMessage.Status)
+		errMessage.WriteHeader(respWriter)
 		json.NewEncoder(respWriter).Encode(errMessage)
 		return
 	}
 
+	// Self-registered accounts are always regular users, other roles are
+	// handed out by an admin through CreateUser.
 	registeredUser, err := authclient.AuthServiceClient.RegisterUser(req.Context(), &proto.RegisterUserRequest{
 		Name:     newUser.Name,
 		Email:    newUser.Email,
 		Password: newUser.Password,
-		UserType: newUser.UserType,
+		UserType: proto.UserType_REGULAR,
 	})
 
 	if err != nil {
-		errMessage := dto.Error{Status: http.StatusBadRequest, Message: strings.Replace(err.Error(), "rpc error: code = Unknown desc = ", "", 1)}
-		respWriter.WriteHeader(errMessage.Status)
+		errMessage := dto.RPCError(err)
+		errMessage.WriteHeader(respWriter)
 		json.NewEncoder(respWriter).Encode(errMessage)
 		return
 	}
@@ -46,6 +47,57 @@ func RegisterUser(respWriter http.ResponseWriter, req *http.Request) {
 	json.NewEncoder(respWriter).Encode(res)
 }
 
+func CreateUser(respWriter http.ResponseWriter, req *http.Request) {
+	respWriter.Header().Set("Content-Type", "application/json")
+
+	var newUser dto.CreateUserRequest
+
+	if err := json.NewDecoder(req.Body).Decode(&newUser); err != nil {
+		errMessage := dto.Error{Status: http.StatusBadRequest, Message: err.Error()}
+		errMessage.WriteHeader(respWriter)
+		json.NewEncoder(respWriter).Encode(errMessage)
+		return
+	}
+
+	if newUser.UserType == nil {
+		errMessage := dto.Error{Status: http.StatusBadRequest, Message: "user_type is required"}
+		errMessage.WriteHeader(respWriter)
+		json.NewEncoder(respWriter).Encode(errMessage)
+		return
+	}
+
+	if _, ok := proto.UserType_name[int32(*newUser.UserType)]; !ok {
+		errMessage := dto.Error{Status: http.StatusBadRequest, Message: "invalid user_type"}
+		errMessage.WriteHeader(respWriter)
+		json.NewEncoder(respWriter).Encode(errMessage)
+		return
+	}
+
+	createdUser, err := authclient.AuthServiceClient.RegisterUser(req.Context(), &proto.RegisterUserRequest{
+		Name:     newUser.Name,
+		Email:    newUser.Email,
+		Password: newUser.Password,
+		UserType: *newUser.UserType,
+	})
+
+	if err != nil {
+		errMessage := dto.RPCError(err)
+		errMessage.WriteHeader(respWriter)
+		json.NewEncoder(respWriter).Encode(errMessage)
+		return
+	}
+
+	res := dto.RegisterUserResponse{
+		Id:       createdUser.Id,
+		Name:     createdUser.Name,
+		Email:    createdUser.Email,
+		UserType: createdUser.UserType,
+	}
+
+	respWriter.WriteHeader(http.StatusCreated)
+	json.NewEncoder(respWriter).Encode(res)
+}
+
 func LoginUser(respWriter http.ResponseWriter, req *http.Request) {
 	respWriter.Header().Set("Content-Type", "application/json")
 
@@ -53,7 +105,7 @@ func LoginUser(respWriter http.ResponseWriter, req *http.Request) {
 
 	if err := json.NewDecoder(req.Body).Decode(&userCredentials); err != nil {
 		errMessage := dto.Error{Status: http.StatusBadRequest, Message: err.Error()}
-		respWriter.WriteHeader(errMessage.Status)
+		errMessage.WriteHeader(respWriter)
 		json.NewEncoder(respWriter).Encode(errMessage)
 		return
 	}
@@ -64,8 +116,8 @@ func LoginUser(respWriter http.ResponseWriter, req *http.Request) {
 	})
 
 	if err != nil {
-		errMessage := dto.Error{Status: http.StatusBadRequest, Message: strings.Replace(err.Error(), "rpc error: code = Unknown desc = ", "", 1)}
-		respWriter.WriteHeader(errMessage.Status)
+		errMessage := dto.RPCError(err)
+		errMessage.WriteHeader(respWriter)
 		json.NewEncoder(respWriter).Encode(errMessage)
 		return
 	}
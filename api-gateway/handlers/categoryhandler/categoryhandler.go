@@ -0,0 +1,248 @@
+package categoryhandler
+
+import (
+	"api-gateway/clients/productclient"
+	"api-gateway/dto"
+	proto "api-gateway/proto/product"
+	"encoding/json"
+	"net/http"
+	"strconv"
+
+	"github.com/golang/protobuf/ptypes/empty"
+	"github.com/gorilla/mux"
+	"google.golang.org/protobuf/types/known/fieldmaskpb"
+)
+
+func GetAllCategories(respWriter http.ResponseWriter, req *http.Request) {
+	respWriter.Header().Set("Content-Type", "application/json")
+
+	var res []dto.Category
+
+	categories, err := productclient.ProductServiceClient.GetAllCategories(req.Context(), &empty.Empty{})
+
+	if err != nil {
+		errMessage := dto.RPCError(err)
+		errMessage.WriteHeader(respWriter)
+		json.NewEncoder(respWriter).Encode(errMessage)
+		return
+	}
+
+	for _, category := range categories.Categories {
+		res = append(res, dto.Category{Id: category.Id, Name: category.Name, Description: category.Description, ParentId: category.ParentId})
+	}
+
+	respWriter.WriteHeader(http.StatusOK)
+	json.NewEncoder(respWriter).Encode(res)
+}
+
+func CreateCategory(respWriter http.ResponseWriter, req *http.Request) {
+	respWriter.Header().Set("Content-Type", "application/json")
+
+	var newCategory dto.CreateCategoryRequest
+
+	if err := json.NewDecoder(req.Body).Decode(&newCategory); err != nil {
+		errMessage := dto.Error{Status: http.StatusBadRequest, Message: err.Error()}
+		errMessage.WriteHeader(respWriter)
+		json.NewEncoder(respWriter).Encode(errMessage)
+		return
+	}
+
+	createdCategory, err := productclient.ProductServiceClient.CreateCategory(req.Context(), &proto.CreateCategoryRequest{
+		Name:        newCategory.Name,
+		Description: newCategory.Description,
+		ParentId:    newCategory.ParentId})
+
+	if err != nil {
+		errMessage := dto.RPCError(err)
+		errMessage.WriteHeader(respWriter)
+		json.NewEncoder(respWriter).Encode(errMessage)
+		return
+	}
+
+	res := dto.Category{Id: createdCategory.Id, Name: createdCategory.Name, Description: createdCategory.Description, ParentId: createdCategory.ParentId}
+
+	respWriter.WriteHeader(http.StatusCreated)
+	json.NewEncoder(respWriter).Encode(res)
+}
+
+func GetCategory(respWriter http.ResponseWriter, req *http.Request) {
+	respWriter.Header().Set("Content-Type", "application/json")
+
+	params := mux.Vars(req)
+	categoryId, err := strconv.Atoi(params["id"])
+
+	if err != nil {
+		errMessage := dto.Error{Status: http.StatusBadRequest, Message: err.Error()}
+		errMessage.WriteHeader(respWriter)
+		json.NewEncoder(respWriter).Encode(errMessage)
+		return
+	}
+
+	category, err := productclient.ProductServiceClient.GetCategory(req.Context(), &proto.CategoryIdRequest{Id: int32(categoryId)})
+
+	if err != nil {
+		errMessage := dto.RPCError(err)
+		errMessage.WriteHeader(respWriter)
+		json.NewEncoder(respWriter).Encode(errMessage)
+		return
+	}
+
+	res := dto.Category{Id: category.Id, Name: category.Name, Description: category.Description, ParentId: category.ParentId}
+
+	respWriter.WriteHeader(http.StatusOK)
+	json.NewEncoder(respWriter).Encode(res)
+}
+
+func UpdateCategory(respWriter http.ResponseWriter, req *http.Request) {
+	respWriter.Header().Set("Content-Type", "application/json")
+
+	params := mux.Vars(req)
+	categoryId, err := strconv.Atoi(params["id"])
+
+	if err != nil {
+		errMessage := dto.Error{Status: http.StatusBadRequest, Message: err.Error()}
+		errMessage.WriteHeader(respWriter)
+		json.NewEncoder(respWriter).Encode(errMessage)
+		return
+	}
+
+	var body dto.UpdateCategoryRequest
+
+	if err := json.NewDecoder(req.Body).Decode(&body); err != nil {
+		errMessage := dto.Error{Status: http.StatusBadRequest, Message: err.Error()}
+		errMessage.WriteHeader(respWriter)
+		json.NewEncoder(respWriter).Encode(errMessage)
+		return
+	}
+
+	updateRequest := &proto.UpdateCategoryRequest{Id: int32(categoryId), UpdateMask: &fieldmaskpb.FieldMask{}}
+
+	if body.Name != nil {
+		updateRequest.Name = *body.Name
+		updateRequest.UpdateMask.Paths = append(updateRequest.UpdateMask.Paths, "name")
+	}
+
+	if body.Description != nil {
+		updateRequest.Description = *body.Description
+		updateRequest.UpdateMask.Paths = append(updateRequest.UpdateMask.Paths, "description")
+	}
+
+	if body.ParentId != nil {
+		updateRequest.ParentId = *body.ParentId
+		updateRequest.UpdateMask.Paths = append(updateRequest.UpdateMask.Paths, "parent_id")
+	}
+
+	updatedCategory, err := productclient.ProductServiceClient.UpdateCategory(req.Context(), updateRequest)
+
+	if err != nil {
+		errMessage := dto.RPCError(err)
+		errMessage.WriteHeader(respWriter)
+		json.NewEncoder(respWriter).Encode(errMessage)
+		return
+	}
+
+	res := dto.Category{Id: updatedCategory.Id, Name: updatedCategory.Name, Description: updatedCategory.Description, ParentId: updatedCategory.ParentId}
+
+	respWriter.WriteHeader(http.StatusOK)
+	json.NewEncoder(respWriter).Encode(res)
+}
+
+func DeleteCategory(respWriter http.ResponseWriter, req *http.Request) {
+	respWriter.Header().Set("Content-Type", "application/json")
+
+	params := mux.Vars(req)
+	categoryId, err := strconv.Atoi(params["id"])
+
+	if err != nil {
+		errMessage := dto.Error{Status: http.StatusBadRequest, Message: err.Error()}
+		errMessage.WriteHeader(respWriter)
+		json.NewEncoder(respWriter).Encode(errMessage)
+		return
+	}
+
+	deletedCategory, err := productclient.ProductServiceClient.DeleteCategory(req.Context(), &proto.CategoryIdRequest{Id: int32(categoryId)})
+
+	if err != nil {
+		errMessage := dto.RPCError(err)
+		errMessage.WriteHeader(respWriter)
+		json.NewEncoder(respWriter).Encode(errMessage)
+		return
+	}
+
+	res := dto.DeletedCategoryResponse{Id: deletedCategory.Id}
+
+	respWriter.WriteHeader(http.StatusOK)
+	json.NewEncoder(respWriter).Encode(res)
+}
+
+func AddProductToCategory(respWriter http.ResponseWriter, req *http.Request) {
+	respWriter.Header().Set("Content-Type", "application/json")
+
+	categoryId, productId, err := productCategoryParams(req)
+
+	if err != nil {
+		errMessage := dto.Error{Status: http.StatusBadRequest, Message: err.Error()}
+		errMessage.WriteHeader(respWriter)
+		json.NewEncoder(respWriter).Encode(errMessage)
+		return
+	}
+
+	link, err := productclient.ProductServiceClient.AddProductToCategory(req.Context(), &proto.ProductCategoryRequest{ProductId: productId, CategoryId: categoryId})
+
+	if err != nil {
+		errMessage := dto.RPCError(err)
+		errMessage.WriteHeader(respWriter)
+		json.NewEncoder(respWriter).Encode(errMessage)
+		return
+	}
+
+	res := dto.ProductCategoryResponse{ProductId: link.ProductId, CategoryId: link.CategoryId}
+
+	respWriter.WriteHeader(http.StatusOK)
+	json.NewEncoder(respWriter).Encode(res)
+}
+
+func RemoveProductFromCategory(respWriter http.ResponseWriter, req *http.Request) {
+	respWriter.Header().Set("Content-Type", "application/json")
+
+	categoryId, productId, err := productCategoryParams(req)
+
+	if err != nil {
+		errMessage := dto.Error{Status: http.StatusBadRequest, Message: err.Error()}
+		errMessage.WriteHeader(respWriter)
+		json.NewEncoder(respWriter).Encode(errMessage)
+		return
+	}
+
+	link, err := productclient.ProductServiceClient.RemoveProductFromCategory(req.Context(), &proto.ProductCategoryRequest{ProductId: productId, CategoryId: categoryId})
+
+	if err != nil {
+		errMessage := dto.RPCError(err)
+		errMessage.WriteHeader(respWriter)
+		json.NewEncoder(respWriter).Encode(errMessage)
+		return
+	}
+
+	res := dto.ProductCategoryResponse{ProductId: link.ProductId, CategoryId: link.CategoryId}
+
+	respWriter.WriteHeader(http.StatusOK)
+	json.NewEncoder(respWriter).Encode(res)
+}
+
+func productCategoryParams(req *http.Request) (int32, int32, error) {
+	params := mux.Vars(req)
+
+	categoryId, err := strconv.Atoi(params["id"])
+
+	if err != nil {
+		return 0, 0, err
+	}
+
+	productId, err := strconv.Atoi(params["productId"])
+
+	if err != nil {
+		return 0, 0, err
+	}
+
+	return int32(categoryId), int32(productId), nil
+}
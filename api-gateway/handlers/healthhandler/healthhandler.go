@@ -0,0 +1,32 @@
+package healthhandler
+
+import (
+	"api-gateway/clients/dialer"
+	"api-gateway/dto"
+	"encoding/json"
+	"net/http"
+
+	"google.golang.org/grpc/connectivity"
+)
+
+func Readyz(respWriter http.ResponseWriter, req *http.Request) {
+	respWriter.Header().Set("Content-Type", "application/json")
+
+	res := dto.Readiness{Status: dto.StatusReady, Upstreams: map[string]string{}}
+
+	for name, state := range dialer.Readiness() {
+		res.Upstreams[name] = state.String()
+
+		if state != connectivity.Ready {
+			res.Status = dto.StatusNotReady
+		}
+	}
+
+	if res.Status != dto.StatusReady {
+		respWriter.WriteHeader(http.StatusServiceUnavailable)
+	} else {
+		respWriter.WriteHeader(http.StatusOK)
+	}
+
+	json.NewEncoder(respWriter).Encode(res)
+}
@@ -2,13 +2,15 @@ package orderhandler
 
 import (
 	"api-gateway/clients/orderclient"
+	"api-gateway/clients/productclient"
 	"api-gateway/dto"
+	"api-gateway/metrics"
 	"api-gateway/middlewares"
 	proto "api-gateway/proto/order"
+	productproto "api-gateway/proto/product"
 	"encoding/json"
 	"net/http"
 	"strconv"
-	"strings"
 
 	"github.com/gorilla/mux"
 )
@@ -21,18 +23,31 @@ func CreateOrder(respWriter http.ResponseWriter, req *http.Request) {
 
 	if err := json.NewDecoder(req.Body).Decode(&newOrder); err != nil {
 		errMessage := dto.Error{Status: http.StatusBadRequest, Message: err.Error()}
-		respWriter.WriteHeader(errMessage.Status)
+		errMessage.WriteHeader(respWriter)
 		json.NewEncoder(respWriter).Encode(errMessage)
 		return
 	}
 
-	for _, product := range newOrder.Products {
+	priced, err := productclient.ProductServiceClient.PriceCart(req.Context(), &productproto.PriceCartRequest{
+		Cart:        dto.CartToProto(newOrder.Products),
+		CouponCodes: newOrder.CouponCodes,
+	})
+
+	if err != nil {
+		errMessage := dto.RPCError(err)
+		errMessage.WriteHeader(respWriter)
+		json.NewEncoder(respWriter).Encode(errMessage)
+		return
+	}
+
+	for idx, line := range priced.Lines {
 		products = append(products, &proto.Product{
-			Id:          int64(product.Id),
-			Name:        product.Name,
-			Description: product.Description,
-			Price:       product.Price,
-			Quantity:    product.Quantity,
+			Id:          line.ProductId,
+			Name:        line.Name,
+			Description: newOrder.Products[idx].Description,
+			Quantity:    line.Quantity,
+			UnitPrice:   &proto.Money{MinorUnits: line.UnitPrice.GetMinorUnits(), CurrencyCode: line.UnitPrice.GetCurrencyCode()},
+			Discount:    &proto.Money{MinorUnits: line.Discount.GetMinorUnits(), CurrencyCode: line.Discount.GetCurrencyCode()},
 		})
 	}
 
@@ -44,22 +59,36 @@ func CreateOrder(respWriter http.ResponseWriter, req *http.Request) {
 	})
 
 	if err != nil {
-		errMessage := dto.Error{Status: http.StatusBadRequest, Message: strings.Replace(err.Error(), "rpc error: code = Unknown desc = ", "", 1)}
-		respWriter.WriteHeader(errMessage.Status)
+		errMessage := dto.RPCError(err)
+		errMessage.WriteHeader(respWriter)
 		json.NewEncoder(respWriter).Encode(errMessage)
 		return
 	}
 
-	var dtoProducts []dto.Product
+	metrics.RecordOrderCreated()
+
+	var dtoProducts []dto.CartProduct
 
 	for _, product := range createdOrder.Cart.Products {
-		dtoProducts = append(dtoProducts, dto.Product{
-			Id:          int32(product.Id),
-			Name:        product.Name,
-			Description: product.Description,
-			Price:       product.Price,
-			Quantity:    product.Quantity,
-		})
+		converted, err := dto.CartProductFromProto(product)
+
+		if err != nil {
+			errMessage := dto.Error{Status: http.StatusInternalServerError, Message: err.Error()}
+			errMessage.WriteHeader(respWriter)
+			json.NewEncoder(respWriter).Encode(errMessage)
+			return
+		}
+
+		dtoProducts = append(dtoProducts, converted)
+	}
+
+	pricing, err := dto.PricedCartFromProto(priced)
+
+	if err != nil {
+		errMessage := dto.Error{Status: http.StatusInternalServerError, Message: err.Error()}
+		errMessage.WriteHeader(respWriter)
+		json.NewEncoder(respWriter).Encode(errMessage)
+		return
 	}
 
 	res := dto.CreateOrderResponse{
@@ -68,6 +97,7 @@ func CreateOrder(respWriter http.ResponseWriter, req *http.Request) {
 		Cart: dto.Cart{
 			Products: dtoProducts,
 		},
+		Pricing: &pricing,
 	}
 
 	respWriter.WriteHeader(http.StatusCreated)
@@ -77,38 +107,51 @@ func CreateOrder(respWriter http.ResponseWriter, req *http.Request) {
 func GetAllOrdersByUserId(respWriter http.ResponseWriter, req *http.Request) {
 	respWriter.Header().Set("Content-Type", "application/json")
 
-	var res []dto.CreateOrderResponse
-
 	params := mux.Vars(req)
 	userId, err := strconv.Atoi(params["userId"])
 
 	if err != nil {
 		errMessage := dto.Error{Status: http.StatusBadRequest, Message: err.Error()}
-		respWriter.WriteHeader(errMessage.Status)
+		errMessage.WriteHeader(respWriter)
 		json.NewEncoder(respWriter).Encode(errMessage)
 		return
 	}
 
-	orders, err := orderclient.OrderServiceClient.GetAllOrdersByUserId(req.Context(), &proto.GetAllOrdersByUserIdRequest{UserId: int64(userId)})
+	writeOrders(respWriter, req, int64(userId))
+}
+
+func GetMyOrders(respWriter http.ResponseWriter, req *http.Request) {
+	respWriter.Header().Set("Content-Type", "application/json")
+
+	writeOrders(respWriter, req, req.Context().Value(middlewares.USER_ID).(int64))
+}
+
+func writeOrders(respWriter http.ResponseWriter, req *http.Request, userId int64) {
+	var res []dto.CreateOrderResponse
+
+	orders, err := orderclient.OrderServiceClient.GetAllOrdersByUserId(req.Context(), &proto.GetAllOrdersByUserIdRequest{UserId: userId})
 
 	if err != nil {
-		errMessage := dto.Error{Status: http.StatusBadRequest, Message: strings.Replace(err.Error(), "rpc error: code = Unknown desc = ", "", 1)}
-		respWriter.WriteHeader(errMessage.Status)
+		errMessage := dto.RPCError(err)
+		errMessage.WriteHeader(respWriter)
 		json.NewEncoder(respWriter).Encode(errMessage)
 		return
 	}
 
 	for _, order := range orders.Orders {
-		var products []dto.Product
+		var products []dto.CartProduct
 
 		for _, product := range order.Cart.Products {
-			products = append(products, dto.Product{
-				Id:          int32(product.Id),
-				Name:        product.Name,
-				Description: product.Description,
-				Price:       product.Price,
-				Quantity:    product.Quantity,
-			})
+			converted, err := dto.CartProductFromProto(product)
+
+			if err != nil {
+				errMessage := dto.Error{Status: http.StatusInternalServerError, Message: err.Error()}
+				errMessage.WriteHeader(respWriter)
+				json.NewEncoder(respWriter).Encode(errMessage)
+				return
+			}
+
+			products = append(products, converted)
 		}
 
 		res = append(res, dto.CreateOrderResponse{
@@ -0,0 +1,28 @@
+package producthandler
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+	"strings"
+)
+
+func etag(version int64) string {
+	return strconv.Quote(strconv.FormatInt(version, 10))
+}
+
+func parseIfMatch(req *http.Request) (int64, error) {
+	header := strings.TrimSpace(req.Header.Get("If-Match"))
+
+	if header == "" || header == "*" {
+		return 0, nil
+	}
+
+	version, err := strconv.ParseInt(strings.Trim(strings.TrimPrefix(header, "W/"), "\""), 10, 64)
+
+	if err != nil || version <= 0 {
+		return 0, errors.New("invalid If-Match header")
+	}
+
+	return version, nil
+}
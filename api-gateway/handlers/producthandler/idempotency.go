@@ -0,0 +1,13 @@
+package producthandler
+
+import (
+	"net/http"
+
+	"google.golang.org/grpc/metadata"
+)
+
+func markReplayed(respWriter http.ResponseWriter, header metadata.MD) {
+	if len(header.Get("idempotent-replayed")) != 0 {
+		respWriter.Header().Set("Idempotent-Replayed", "true")
+	}
+}
@@ -0,0 +1,485 @@
+package producthandler
+
+import (
+	"api-gateway/clients/productclient"
+	"api-gateway/dto"
+	proto "api-gateway/proto/product"
+	"context"
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"log/slog"
+	"mime/multipart"
+	"net/http"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+const (
+	maxFormValueSize = 1 << 10
+)
+
+var (
+	csvExportHeader       = []string{"id", "name", "description", "price", "currency", "quantity", "reorderThreshold", "version"}
+	errImportStreamClosed = errors.New("the import stream was closed by the product service")
+)
+
+// ImportProducts reads the multipart upload part by part and sends every row
+// to the product service as soon as it is parsed. The dryRun and format
+// fields must come before the file part. Rows are imported as they arrive, so
+// a malformed file stops the import after the rows that came before it.
+func ImportProducts(respWriter http.ResponseWriter, req *http.Request) {
+	respWriter.Header().Set("Content-Type", "application/json")
+
+	parts, err := req.MultipartReader()
+
+	if err != nil {
+		errMessage := dto.Error{Status: http.StatusBadRequest, Message: err.Error()}
+		errMessage.WriteHeader(respWriter)
+		json.NewEncoder(respWriter).Encode(errMessage)
+		return
+	}
+
+	var dryRun bool
+	var format string
+	var file *multipart.Part
+
+	for file == nil {
+		part, err := parts.NextPart()
+
+		if err == io.EOF {
+			err = errors.New("an import file is required")
+		}
+
+		if err != nil {
+			errMessage := dto.Error{Status: http.StatusBadRequest, Message: err.Error()}
+			errMessage.WriteHeader(respWriter)
+			json.NewEncoder(respWriter).Encode(errMessage)
+			return
+		}
+
+		switch part.FormName() {
+		case "file":
+			file = part
+		case "format":
+			format, err = readFormValue(part)
+		case "dryRun":
+			var value string
+
+			if value, err = readFormValue(part); err == nil && value != "" {
+				if dryRun, err = strconv.ParseBool(value); err != nil {
+					err = errors.New("invalid dryRun value")
+				}
+			}
+		}
+
+		if err != nil {
+			errMessage := dto.Error{Status: http.StatusBadRequest, Message: err.Error()}
+			errMessage.WriteHeader(respWriter)
+			json.NewEncoder(respWriter).Encode(errMessage)
+			return
+		}
+	}
+	defer file.Close()
+
+	format = strings.ToLower(format)
+
+	if format == "" {
+		format = strings.TrimPrefix(strings.ToLower(filepath.Ext(file.FileName())), ".")
+	}
+
+	var readRows func(io.Reader, func(*proto.ImportProductRow) error) ([]dto.ImportRowError, error)
+
+	switch format {
+	case "csv":
+		readRows = readCsvRows
+	case "json":
+		readRows = readJsonRows
+	default:
+		errMessage := dto.Error{Status: http.StatusBadRequest, Message: "unsupported import format, expected csv or json"}
+		errMessage.WriteHeader(respWriter)
+		json.NewEncoder(respWriter).Encode(errMessage)
+		return
+	}
+
+	ctx, cancel := context.WithCancel(req.Context())
+	defer cancel()
+
+	stream, err := productclient.ProductServiceClient.ImportProducts(ctx)
+
+	if err != nil {
+		errMessage := dto.RPCError(err)
+		errMessage.WriteHeader(respWriter)
+		json.NewEncoder(respWriter).Encode(errMessage)
+		return
+	}
+
+	rowErrors, err := readRows(file, func(row *proto.ImportProductRow) error {
+		row.DryRun = dryRun
+
+		if err := stream.Send(row); err != nil {
+			return errImportStreamClosed
+		}
+
+		return nil
+	})
+
+	if err != nil && !errors.Is(err, errImportStreamClosed) {
+		cancel()
+
+		errMessage := dto.Error{Status: http.StatusBadRequest, Message: err.Error()}
+		errMessage.WriteHeader(respWriter)
+		json.NewEncoder(respWriter).Encode(errMessage)
+		return
+	}
+
+	// A failed send only reports io.EOF, the upstream status comes from here.
+	imported, err := stream.CloseAndRecv()
+
+	if err != nil {
+		errMessage := dto.RPCError(err)
+		errMessage.WriteHeader(respWriter)
+		json.NewEncoder(respWriter).Encode(errMessage)
+		return
+	}
+
+	for _, rowError := range imported.Errors {
+		rowErrors = append(rowErrors, dto.ImportRowError{Row: rowError.Row, Name: rowError.Name, Message: rowError.Message})
+	}
+
+	sort.SliceStable(rowErrors, func(i, j int) bool {
+		return rowErrors[i].Row < rowErrors[j].Row
+	})
+
+	res := dto.ImportResult{
+		Created: imported.Created,
+		Updated: imported.Updated,
+		Failed:  int32(len(rowErrors)),
+		DryRun:  dryRun,
+		Errors:  rowErrors,
+	}
+
+	respWriter.WriteHeader(http.StatusOK)
+	json.NewEncoder(respWriter).Encode(res)
+}
+
+// ExportProducts writes every product to the response as it arrives from the
+// product service. Only the first message is awaited before the headers go
+// out, so an upstream that fails straight away still gets an error status; a
+// failure after that aborts the connection rather than ending the file cleanly.
+func ExportProducts(respWriter http.ResponseWriter, req *http.Request) {
+	format := strings.ToLower(req.URL.Query().Get("format"))
+
+	if format == "" {
+		format = "json"
+	}
+
+	if format != "csv" && format != "json" {
+		respWriter.Header().Set("Content-Type", "application/json")
+		errMessage := dto.Error{Status: http.StatusBadRequest, Message: "unsupported export format, expected csv or json"}
+		errMessage.WriteHeader(respWriter)
+		json.NewEncoder(respWriter).Encode(errMessage)
+		return
+	}
+
+	var categoryId int
+
+	if category := req.URL.Query().Get("category"); category != "" {
+		var err error
+
+		if categoryId, err = strconv.Atoi(category); err != nil {
+			respWriter.Header().Set("Content-Type", "application/json")
+			errMessage := dto.Error{Status: http.StatusBadRequest, Message: err.Error()}
+			errMessage.WriteHeader(respWriter)
+			json.NewEncoder(respWriter).Encode(errMessage)
+			return
+		}
+	}
+
+	stream, err := productclient.ProductServiceClient.ExportProducts(req.Context(), &proto.ExportProductsRequest{CategoryId: int32(categoryId)})
+
+	var first *proto.CreateProductResponse
+
+	if err == nil {
+		if first, err = stream.Recv(); err == io.EOF {
+			err = nil
+		}
+	}
+
+	if err != nil {
+		slog.WarnContext(req.Context(), "product export failed", "error", err)
+
+		respWriter.Header().Set("Content-Type", "application/json")
+		errMessage := dto.RPCError(err)
+		errMessage.WriteHeader(respWriter)
+		json.NewEncoder(respWriter).Encode(errMessage)
+		return
+	}
+
+	if format == "csv" {
+		respWriter.Header().Set("Content-Type", "text/csv")
+	} else {
+		respWriter.Header().Set("Content-Type", "application/json")
+	}
+
+	respWriter.Header().Set("Content-Disposition", fmt.Sprintf("attachment; filename=\"products.%s\"", format))
+	respWriter.WriteHeader(http.StatusOK)
+
+	if err := writeExport(respWriter, first, stream, format); err != nil {
+		slog.WarnContext(req.Context(), "product export failed part way through", "error", err)
+		panic(http.ErrAbortHandler)
+	}
+}
+
+// writeExport writes first and then every product left on the stream, flushing
+// after each one. first is nil when the export is empty.
+func writeExport(writer io.Writer, first *proto.CreateProductResponse, stream proto.ProductService_ExportProductsClient, format string) error {
+	flusher, _ := writer.(http.Flusher)
+	csvWriter := csv.NewWriter(writer)
+
+	if format == "csv" {
+		csvWriter.Write(csvExportHeader)
+	} else if _, err := io.WriteString(writer, "["); err != nil {
+		return err
+	}
+
+	for product, count := first, 0; product != nil; count++ {
+		res, err := toProductResponse(product)
+
+		if err != nil {
+			return err
+		}
+
+		if format == "csv" {
+			csvWriter.Write([]string{
+				strconv.Itoa(int(res.Id)),
+				res.Name,
+				res.Description,
+				res.Price.String(),
+				res.Currency,
+				strconv.Itoa(int(res.Quantity)),
+				strconv.Itoa(int(res.ReorderThreshold)),
+				strconv.FormatInt(res.Version, 10),
+			})
+			csvWriter.Flush()
+			err = csvWriter.Error()
+		} else {
+			err = writeJsonElement(writer, res, count == 0)
+		}
+
+		if err != nil {
+			return err
+		}
+
+		if flusher != nil {
+			flusher.Flush()
+		}
+
+		if product, err = stream.Recv(); err == io.EOF {
+			break
+		}
+
+		if err != nil {
+			return err
+		}
+	}
+
+	if format == "csv" {
+		csvWriter.Flush()
+
+		return csvWriter.Error()
+	}
+
+	_, err := io.WriteString(writer, "]\n")
+
+	return err
+}
+
+func writeJsonElement(writer io.Writer, product dto.Product, first bool) error {
+	encoded, err := json.Marshal(product)
+
+	if err != nil {
+		return err
+	}
+
+	if !first {
+		if _, err := io.WriteString(writer, ","); err != nil {
+			return err
+		}
+	}
+
+	_, err = writer.Write(encoded)
+
+	return err
+}
+
+func readFormValue(part *multipart.Part) (string, error) {
+	defer part.Close()
+
+	value, err := io.ReadAll(io.LimitReader(part, maxFormValueSize))
+
+	return strings.TrimSpace(string(value)), err
+}
+
+// readCsvRows parses reader row by row and hands every valid row to send.
+// Rows that fail to parse are returned as row errors instead.
+func readCsvRows(reader io.Reader, send func(*proto.ImportProductRow) error) ([]dto.ImportRowError, error) {
+	csvReader := csv.NewReader(reader)
+	csvReader.FieldsPerRecord = -1
+	csvReader.TrimLeadingSpace = true
+
+	header, err := csvReader.Read()
+
+	if err != nil {
+		return nil, errors.New("the csv file must start with a header row")
+	}
+
+	columns := make(map[string]int)
+
+	for idx, column := range header {
+		columns[strings.ToLower(strings.TrimSpace(column))] = idx
+	}
+
+	if _, ok := columns["name"]; !ok {
+		return nil, errors.New("the csv header must contain a name column")
+	}
+
+	var rowErrors []dto.ImportRowError
+
+	for rowNumber := int32(1); ; rowNumber++ {
+		record, err := csvReader.Read()
+
+		if err == io.EOF {
+			break
+		}
+
+		if err != nil {
+			return rowErrors, err
+		}
+
+		field := func(name string) string {
+			if idx, ok := columns[strings.ToLower(name)]; ok && idx < len(record) {
+				return strings.TrimSpace(record[idx])
+			}
+
+			return ""
+		}
+
+		newProduct := dto.ImportProductRow{
+			Name:     field("name"),
+			Currency: field("currency"),
+		}
+
+		if _, ok := columns["description"]; ok {
+			description := field("description")
+			newProduct.Description = &description
+		}
+
+		if price := field("price"); price != "" {
+			amount := json.Number(price)
+			newProduct.Price = &amount
+		}
+
+		if newProduct.Quantity, err = parseInt32(field("quantity")); err == nil {
+			newProduct.ReorderThreshold, err = parseInt32(field("reorderThreshold"))
+		}
+
+		row, err := toImportRow(rowNumber, newProduct, err)
+
+		if err != nil {
+			rowErrors = append(rowErrors, dto.ImportRowError{Row: rowNumber, Name: newProduct.Name, Message: err.Error()})
+			continue
+		}
+
+		if err := send(row); err != nil {
+			return rowErrors, err
+		}
+	}
+
+	return rowErrors, nil
+}
+
+// readJsonRows decodes the array in reader one element at a time and hands
+// every valid row to send.
+func readJsonRows(reader io.Reader, send func(*proto.ImportProductRow) error) ([]dto.ImportRowError, error) {
+	decoder := json.NewDecoder(reader)
+
+	if token, err := decoder.Token(); err != nil || token != json.Delim('[') {
+		return nil, errors.New("the json file must contain an array of products")
+	}
+
+	var rowErrors []dto.ImportRowError
+
+	for rowNumber := int32(1); decoder.More(); rowNumber++ {
+		var newProduct dto.ImportProductRow
+
+		if err := decoder.Decode(&newProduct); err != nil {
+			return rowErrors, err
+		}
+
+		row, err := toImportRow(rowNumber, newProduct, nil)
+
+		if err != nil {
+			rowErrors = append(rowErrors, dto.ImportRowError{Row: rowNumber, Name: newProduct.Name, Message: err.Error()})
+			continue
+		}
+
+		if err := send(row); err != nil {
+			return rowErrors, err
+		}
+	}
+
+	if _, err := decoder.Token(); err != nil {
+		return rowErrors, err
+	}
+
+	return rowErrors, nil
+}
+
+func toImportRow(rowNumber int32, newProduct dto.ImportProductRow, err error) (*proto.ImportProductRow, error) {
+	if err != nil {
+		return nil, err
+	}
+
+	row := &proto.ImportProductRow{
+		Row:              rowNumber,
+		Name:             newProduct.Name,
+		Description:      newProduct.Description,
+		Quantity:         newProduct.Quantity,
+		ReorderThreshold: newProduct.ReorderThreshold,
+	}
+
+	if newProduct.Price != nil {
+		if row.Price, err = dto.PriceToMoney(*newProduct.Price, newProduct.Currency); err != nil {
+			return nil, err
+		}
+
+		// Without a currency the product service keeps the stored one, so
+		// the row must not claim the default currency it was converted with.
+		if len(strings.TrimSpace(newProduct.Currency)) == 0 {
+			row.Price.CurrencyCode = ""
+		}
+	}
+
+	return row, nil
+}
+
+func parseInt32(value string) (*int32, error) {
+	if value == "" {
+		return nil, nil
+	}
+
+	parsed, err := strconv.ParseInt(value, 10, 32)
+
+	if err != nil {
+		return nil, fmt.Errorf("invalid number %s", value)
+	}
+
+	converted := int32(parsed)
+
+	return &converted, nil
+}
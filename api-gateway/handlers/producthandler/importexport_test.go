@@ -0,0 +1,165 @@
+package producthandler
+
+import (
+	proto "api-gateway/proto/product"
+	"errors"
+	"io"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/grpc"
+)
+
+type exportStream struct {
+	grpc.ClientStream
+	products []*proto.CreateProductResponse
+	err      error
+}
+
+func (s *exportStream) Recv() (*proto.CreateProductResponse, error) {
+	if len(s.products) == 0 {
+		if s.err != nil {
+			return nil, s.err
+		}
+
+		return nil, io.EOF
+	}
+
+	product := s.products[0]
+	s.products = s.products[1:]
+
+	return product, nil
+}
+
+func collectRows(rows *[]*proto.ImportProductRow) func(*proto.ImportProductRow) error {
+	return func(row *proto.ImportProductRow) error {
+		*rows = append(*rows, row)
+		return nil
+	}
+}
+
+func exportedProduct(id int32, name string) *proto.CreateProductResponse {
+	return &proto.CreateProductResponse{Id: id, Name: name, Price: &proto.Money{MinorUnits: 1250, CurrencyCode: "EUR"}, Quantity: 3, Version: 2}
+}
+
+func TestReadCsvRowsShouldLeaveMissingColumnsUnset(t *testing.T) {
+	var rows []*proto.ImportProductRow
+	rowErrors, err := readCsvRows(strings.NewReader("name,price\nLamp,34.99\nDesk,\n"), collectRows(&rows))
+
+	assert.NoError(t, err)
+	assert.Empty(t, rowErrors)
+	assert.Equal(t, 2, len(rows))
+	assert.Equal(t, int64(3499), rows[0].Price.MinorUnits)
+	assert.Nil(t, rows[0].Description)
+	assert.Nil(t, rows[0].Quantity)
+	assert.Nil(t, rows[0].ReorderThreshold)
+	assert.Nil(t, rows[1].Price)
+}
+
+func TestReadCsvRowsShouldOnlySendACurrencyIfTheRowHasOne(t *testing.T) {
+	var rows []*proto.ImportProductRow
+	_, err := readCsvRows(strings.NewReader("name,price,currency\nLamp,34.99,\nDesk,12.5,eur\n"), collectRows(&rows))
+
+	assert.NoError(t, err)
+	assert.Equal(t, &proto.Money{MinorUnits: 3499}, rows[0].Price)
+	assert.Equal(t, &proto.Money{MinorUnits: 1250, CurrencyCode: "EUR"}, rows[1].Price)
+}
+
+func TestReadCsvRowsShouldKeepPresentColumns(t *testing.T) {
+	var rows []*proto.ImportProductRow
+	_, err := readCsvRows(strings.NewReader("name,description,quantity,reorderThreshold\nLamp,,0,2\n"), collectRows(&rows))
+
+	assert.NoError(t, err)
+	assert.Equal(t, "", *rows[0].Description)
+	assert.Equal(t, int32(0), *rows[0].Quantity)
+	assert.Equal(t, int32(2), *rows[0].ReorderThreshold)
+}
+
+func TestReadCsvRowsShouldSendRowsBeforeAMalformedLine(t *testing.T) {
+	var rows []*proto.ImportProductRow
+	_, err := readCsvRows(strings.NewReader("name,quantity\nLamp,1\n\"Desk,2\n"), collectRows(&rows))
+
+	assert.Error(t, err)
+	assert.Equal(t, 1, len(rows))
+	assert.Equal(t, "Lamp", rows[0].Name)
+}
+
+func TestReadCsvRowsShouldStopWhenSendingFails(t *testing.T) {
+	sent := 0
+	_, err := readCsvRows(strings.NewReader("name\nLamp\nDesk\n"), func(row *proto.ImportProductRow) error {
+		sent++
+		return errImportStreamClosed
+	})
+
+	assert.ErrorIs(t, err, errImportStreamClosed)
+	assert.Equal(t, 1, sent)
+}
+
+func TestReadJsonRowsShouldLeaveMissingFieldsUnset(t *testing.T) {
+	var rows []*proto.ImportProductRow
+	rowErrors, err := readJsonRows(strings.NewReader(`[{"name": "Lamp", "quantity": 0}, {"name": "Desk", "price": 1, "currency": "XYZ"}]`), collectRows(&rows))
+
+	assert.NoError(t, err)
+	assert.Equal(t, 1, len(rows))
+	assert.Equal(t, int32(0), *rows[0].Quantity)
+	assert.Nil(t, rows[0].Price)
+	assert.Nil(t, rows[0].Description)
+	assert.Equal(t, 1, len(rowErrors))
+	assert.Equal(t, int32(2), rowErrors[0].Row)
+}
+
+func TestReadJsonRowsShouldRejectAnythingButAnArray(t *testing.T) {
+	var rows []*proto.ImportProductRow
+	_, err1 := readJsonRows(strings.NewReader(`{"name": "Lamp"}`), collectRows(&rows))
+	_, err2 := readJsonRows(strings.NewReader(`[{"name": "Lamp"}, {"name": 5}]`), collectRows(&rows))
+
+	assert.EqualError(t, err1, "the json file must contain an array of products")
+	assert.Error(t, err2)
+	assert.Equal(t, 1, len(rows))
+}
+
+func TestWriteExportShouldStreamJson(t *testing.T) {
+	recorder := httptest.NewRecorder()
+	stream := &exportStream{products: []*proto.CreateProductResponse{exportedProduct(2, "Desk")}}
+
+	err := writeExport(recorder, exportedProduct(1, "Lamp"), stream, "json")
+
+	assert.NoError(t, err)
+	assert.True(t, recorder.Flushed)
+	assert.JSONEq(t, `[
+		{"id": 1, "name": "Lamp", "description": "", "price": 12.50, "currency": "EUR", "quantity": 3, "version": 2, "reorderThreshold": 0},
+		{"id": 2, "name": "Desk", "description": "", "price": 12.50, "currency": "EUR", "quantity": 3, "version": 2, "reorderThreshold": 0}
+	]`, recorder.Body.String())
+}
+
+func TestWriteExportShouldStreamCsv(t *testing.T) {
+	recorder := httptest.NewRecorder()
+	stream := &exportStream{products: []*proto.CreateProductResponse{exportedProduct(2, "Desk")}}
+
+	err := writeExport(recorder, exportedProduct(1, "Lamp"), stream, "csv")
+
+	assert.NoError(t, err)
+	assert.Equal(t, "id,name,description,price,currency,quantity,reorderThreshold,version\n1,Lamp,,12.50,EUR,3,0,2\n2,Desk,,12.50,EUR,3,0,2\n", recorder.Body.String())
+}
+
+func TestWriteExportShouldWriteAnEmptyExport(t *testing.T) {
+	json := httptest.NewRecorder()
+	csv := httptest.NewRecorder()
+
+	assert.NoError(t, writeExport(json, nil, &exportStream{}, "json"))
+	assert.NoError(t, writeExport(csv, nil, &exportStream{}, "csv"))
+	assert.Equal(t, "[]\n", json.Body.String())
+	assert.Equal(t, "id,name,description,price,currency,quantity,reorderThreshold,version\n", csv.Body.String())
+}
+
+func TestWriteExportShouldReturnAFailureAfterTheRowsAlreadyWritten(t *testing.T) {
+	recorder := httptest.NewRecorder()
+	failure := errors.New("upstream went away")
+
+	err := writeExport(recorder, exportedProduct(1, "Lamp"), &exportStream{err: failure}, "csv")
+
+	assert.ErrorIs(t, err, failure)
+	assert.Contains(t, recorder.Body.String(), "1,Lamp,,12.50,EUR,3,0,2\n")
+}
@@ -0,0 +1,187 @@
+package producthandler
+
+import (
+	"api-gateway/clients/productclient"
+	"api-gateway/dto"
+	proto "api-gateway/proto/product"
+	"encoding/json"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/gorilla/mux"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+func SchedulePriceChange(respWriter http.ResponseWriter, req *http.Request) {
+	respWriter.Header().Set("Content-Type", "application/json")
+
+	params := mux.Vars(req)
+	productId, err := strconv.Atoi(params["id"])
+
+	if err != nil {
+		errMessage := dto.Error{Status: http.StatusBadRequest, Message: err.Error()}
+		errMessage.WriteHeader(respWriter)
+		json.NewEncoder(respWriter).Encode(errMessage)
+		return
+	}
+
+	var body dto.SchedulePriceChangeRequest
+
+	if err := json.NewDecoder(req.Body).Decode(&body); err != nil {
+		errMessage := dto.Error{Status: http.StatusBadRequest, Message: err.Error()}
+		errMessage.WriteHeader(respWriter)
+		json.NewEncoder(respWriter).Encode(errMessage)
+		return
+	}
+
+	if body.EffectiveAt.IsZero() {
+		errMessage := dto.Error{Status: http.StatusBadRequest, Message: "effectiveAt is required"}
+		errMessage.WriteHeader(respWriter)
+		json.NewEncoder(respWriter).Encode(errMessage)
+		return
+	}
+
+	currency := body.Currency
+
+	if len(currency) == 0 {
+		currentProduct, err := productclient.ProductServiceClient.GetProduct(req.Context(), &proto.ProductIdRequest{Id: int32(productId)})
+
+		if err != nil {
+			errMessage := dto.RPCError(err)
+			errMessage.WriteHeader(respWriter)
+			json.NewEncoder(respWriter).Encode(errMessage)
+			return
+		}
+
+		currency = currentProduct.Price.GetCurrencyCode()
+	}
+
+	price, err := dto.PriceToMoney(body.Price, currency)
+
+	if err != nil {
+		errMessage := dto.Error{Status: http.StatusBadRequest, Message: err.Error()}
+		errMessage.WriteHeader(respWriter)
+		json.NewEncoder(respWriter).Encode(errMessage)
+		return
+	}
+
+	change, err := productclient.ProductServiceClient.SchedulePriceChange(req.Context(), &proto.SchedulePriceChangeRequest{
+		ProductId:   int32(productId),
+		Price:       price,
+		EffectiveAt: timestamppb.New(body.EffectiveAt)})
+
+	if err != nil {
+		errMessage := dto.RPCError(err)
+		errMessage.WriteHeader(respWriter)
+		json.NewEncoder(respWriter).Encode(errMessage)
+		return
+	}
+
+	res, err := toPriceChangeResponse(change)
+
+	if err != nil {
+		errMessage := dto.Error{Status: http.StatusInternalServerError, Message: err.Error()}
+		errMessage.WriteHeader(respWriter)
+		json.NewEncoder(respWriter).Encode(errMessage)
+		return
+	}
+
+	respWriter.WriteHeader(http.StatusCreated)
+	json.NewEncoder(respWriter).Encode(res)
+}
+
+func GetPriceHistory(respWriter http.ResponseWriter, req *http.Request) {
+	respWriter.Header().Set("Content-Type", "application/json")
+
+	params := mux.Vars(req)
+	productId, err := strconv.Atoi(params["id"])
+
+	if err != nil {
+		errMessage := dto.Error{Status: http.StatusBadRequest, Message: err.Error()}
+		errMessage.WriteHeader(respWriter)
+		json.NewEncoder(respWriter).Encode(errMessage)
+		return
+	}
+
+	historyRequest := &proto.GetPriceHistoryRequest{ProductId: int32(productId)}
+
+	if at := req.URL.Query().Get("at"); len(at) != 0 {
+		parsed, err := time.Parse(time.RFC3339, at)
+
+		if err != nil {
+			errMessage := dto.Error{Status: http.StatusBadRequest, Message: "at must be an RFC 3339 timestamp"}
+			errMessage.WriteHeader(respWriter)
+			json.NewEncoder(respWriter).Encode(errMessage)
+			return
+		}
+
+		historyRequest.At = timestamppb.New(parsed)
+	}
+
+	history, err := productclient.ProductServiceClient.GetPriceHistory(req.Context(), historyRequest)
+
+	if err != nil {
+		errMessage := dto.RPCError(err)
+		errMessage.WriteHeader(respWriter)
+		json.NewEncoder(respWriter).Encode(errMessage)
+		return
+	}
+
+	changes := make([]dto.PriceChange, len(history.Changes))
+
+	for idx, change := range history.Changes {
+		converted, err := toPriceChangeResponse(change)
+
+		if err != nil {
+			errMessage := dto.Error{Status: http.StatusInternalServerError, Message: err.Error()}
+			errMessage.WriteHeader(respWriter)
+			json.NewEncoder(respWriter).Encode(errMessage)
+			return
+		}
+
+		changes[idx] = converted
+	}
+
+	effectivePrice, currency, err := dto.PriceFromMoney(history.EffectivePrice)
+
+	if err != nil {
+		errMessage := dto.Error{Status: http.StatusInternalServerError, Message: err.Error()}
+		errMessage.WriteHeader(respWriter)
+		json.NewEncoder(respWriter).Encode(errMessage)
+		return
+	}
+
+	res := dto.PriceHistory{
+		Changes:        changes,
+		EffectivePrice: effectivePrice,
+		Currency:       currency,
+		At:             history.At.AsTime(),
+	}
+
+	respWriter.WriteHeader(http.StatusOK)
+	json.NewEncoder(respWriter).Encode(res)
+}
+
+func toPriceChangeResponse(change *proto.PriceChange) (dto.PriceChange, error) {
+	price, currency, err := dto.PriceFromMoney(change.Price)
+
+	if err != nil {
+		return dto.PriceChange{}, err
+	}
+
+	res := dto.PriceChange{
+		Id:          change.Id,
+		ProductId:   change.ProductId,
+		Price:       price,
+		Currency:    currency,
+		EffectiveAt: change.EffectiveAt.AsTime(),
+	}
+
+	if change.AppliedAt != nil {
+		appliedAt := change.AppliedAt.AsTime()
+		res.AppliedAt = &appliedAt
+	}
+
+	return res, nil
+}
@@ -7,28 +7,104 @@ import (
 	"encoding/json"
 	"net/http"
 	"strconv"
-	"strings"
+	"time"
 
 	"github.com/golang/protobuf/ptypes/empty"
 	"github.com/gorilla/mux"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/protobuf/types/known/fieldmaskpb"
 )
 
 func GetAllProducts(respWriter http.ResponseWriter, req *http.Request) {
 	respWriter.Header().Set("Content-Type", "application/json")
 
+	var categoryId int
+
+	if category := req.URL.Query().Get("category"); category != "" {
+		var err error
+
+		if categoryId, err = strconv.Atoi(category); err != nil {
+			errMessage := dto.Error{Status: http.StatusBadRequest, Message: err.Error()}
+			errMessage.WriteHeader(respWriter)
+			json.NewEncoder(respWriter).Encode(errMessage)
+			return
+		}
+	}
+
+	writeProducts(respWriter, req, int32(categoryId))
+}
+
+func GetAllProductsInCategory(respWriter http.ResponseWriter, req *http.Request) {
+	respWriter.Header().Set("Content-Type", "application/json")
+
+	params := mux.Vars(req)
+	categoryId, err := strconv.Atoi(params["id"])
+
+	if err != nil {
+		errMessage := dto.Error{Status: http.StatusBadRequest, Message: err.Error()}
+		errMessage.WriteHeader(respWriter)
+		json.NewEncoder(respWriter).Encode(errMessage)
+		return
+	}
+
+	writeProducts(respWriter, req, int32(categoryId))
+}
+
+func writeProducts(respWriter http.ResponseWriter, req *http.Request, categoryId int32) {
 	var res []dto.Product
 
-	products, err := productclient.ProductServiceClient.GetAllProducts(req.Context(), &empty.Empty{})
+	products, err := productclient.ProductServiceClient.GetAllProducts(req.Context(), &proto.GetAllProductsRequest{CategoryId: categoryId})
 
 	if err != nil {
-		errMessage := dto.Error{Status: http.StatusBadRequest, Message: strings.Replace(err.Error(), "rpc error: code = Unknown desc = ", "", 1)}
-		respWriter.WriteHeader(errMessage.Status)
+		errMessage := dto.RPCError(err)
+		errMessage.WriteHeader(respWriter)
 		json.NewEncoder(respWriter).Encode(errMessage)
 		return
 	}
 
 	for _, product := range products.Products {
-		res = append(res, dto.Product{Id: product.Id, Name: product.Name, Description: product.Description, Price: product.Price, Quantity: product.Quantity})
+		converted, err := toProductResponse(product)
+
+		if err != nil {
+			errMessage := dto.Error{Status: http.StatusInternalServerError, Message: err.Error()}
+			errMessage.WriteHeader(respWriter)
+			json.NewEncoder(respWriter).Encode(errMessage)
+			return
+		}
+
+		res = append(res, converted)
+	}
+
+	respWriter.WriteHeader(http.StatusOK)
+	json.NewEncoder(respWriter).Encode(res)
+}
+
+func GetLowStockProducts(respWriter http.ResponseWriter, req *http.Request) {
+	respWriter.Header().Set("Content-Type", "application/json")
+
+	var res []dto.Product
+
+	products, err := productclient.ProductServiceClient.ListLowStockProducts(req.Context(), &empty.Empty{})
+
+	if err != nil {
+		errMessage := dto.RPCError(err)
+		errMessage.WriteHeader(respWriter)
+		json.NewEncoder(respWriter).Encode(errMessage)
+		return
+	}
+
+	for _, product := range products.Products {
+		converted, err := toProductResponse(product)
+
+		if err != nil {
+			errMessage := dto.Error{Status: http.StatusInternalServerError, Message: err.Error()}
+			errMessage.WriteHeader(respWriter)
+			json.NewEncoder(respWriter).Encode(errMessage)
+			return
+		}
+
+		res = append(res, converted)
 	}
 
 	respWriter.WriteHeader(http.StatusOK)
@@ -42,25 +118,44 @@ func CreateProduct(respWriter http.ResponseWriter, req *http.Request) {
 
 	if err := json.NewDecoder(req.Body).Decode(&newProduct); err != nil {
 		errMessage := dto.Error{Status: http.StatusBadRequest, Message: err.Error()}
-		respWriter.WriteHeader(errMessage.Status)
+		errMessage.WriteHeader(respWriter)
+		json.NewEncoder(respWriter).Encode(errMessage)
+		return
+	}
+
+	price, err := dto.PriceToMoney(newProduct.Price, newProduct.Currency)
+
+	if err != nil {
+		errMessage := dto.Error{Status: http.StatusBadRequest, Message: err.Error()}
+		errMessage.WriteHeader(respWriter)
 		json.NewEncoder(respWriter).Encode(errMessage)
 		return
 	}
 
 	createdProduct, err := productclient.ProductServiceClient.CreateProduct(req.Context(), &proto.CreateProductRequest{
-		Name:        newProduct.Name,
-		Description: newProduct.Description,
-		Price:       newProduct.Price,
-		Quantity:    newProduct.Quantity})
+		Name:             newProduct.Name,
+		Description:      newProduct.Description,
+		Price:            price,
+		Quantity:         newProduct.Quantity,
+		ReorderThreshold: newProduct.ReorderThreshold})
 
 	if err != nil {
-		errMessage := dto.Error{Status: http.StatusBadRequest, Message: strings.Replace(err.Error(), "rpc error: code = Unknown desc = ", "", 1)}
-		respWriter.WriteHeader(errMessage.Status)
+		errMessage := dto.RPCError(err)
+		errMessage.WriteHeader(respWriter)
 		json.NewEncoder(respWriter).Encode(errMessage)
 		return
 	}
 
-	res := dto.Product{Id: createdProduct.Id, Name: createdProduct.Name, Description: createdProduct.Description, Price: createdProduct.Price, Quantity: createdProduct.Quantity}
+	respWriter.Header().Set("ETag", etag(createdProduct.Version))
+
+	res, err := toProductResponse(createdProduct)
+
+	if err != nil {
+		errMessage := dto.Error{Status: http.StatusInternalServerError, Message: err.Error()}
+		errMessage.WriteHeader(respWriter)
+		json.NewEncoder(respWriter).Encode(errMessage)
+		return
+	}
 
 	respWriter.WriteHeader(http.StatusCreated)
 	json.NewEncoder(respWriter).Encode(res)
@@ -74,7 +169,7 @@ func GetProduct(respWriter http.ResponseWriter, req *http.Request) {
 
 	if err != nil {
 		errMessage := dto.Error{Status: http.StatusBadRequest, Message: err.Error()}
-		respWriter.WriteHeader(errMessage.Status)
+		errMessage.WriteHeader(respWriter)
 		json.NewEncoder(respWriter).Encode(errMessage)
 		return
 	}
@@ -82,13 +177,132 @@ func GetProduct(respWriter http.ResponseWriter, req *http.Request) {
 	product, err := productclient.ProductServiceClient.GetProduct(req.Context(), &proto.ProductIdRequest{Id: int32(productId)})
 
 	if err != nil {
-		errMessage := dto.Error{Status: http.StatusBadRequest, Message: strings.Replace(err.Error(), "rpc error: code = Unknown desc = ", "", 1)}
-		respWriter.WriteHeader(errMessage.Status)
+		errMessage := dto.RPCError(err)
+		errMessage.WriteHeader(respWriter)
 		json.NewEncoder(respWriter).Encode(errMessage)
 		return
 	}
 
-	res := dto.Product{Id: product.Id, Name: product.Name, Description: product.Description, Price: product.Price, Quantity: product.Quantity}
+	respWriter.Header().Set("ETag", etag(product.Version))
+
+	res, err := toProductResponse(product)
+
+	if err != nil {
+		errMessage := dto.Error{Status: http.StatusInternalServerError, Message: err.Error()}
+		errMessage.WriteHeader(respWriter)
+		json.NewEncoder(respWriter).Encode(errMessage)
+		return
+	}
+
+	respWriter.WriteHeader(http.StatusOK)
+	json.NewEncoder(respWriter).Encode(res)
+}
+
+func UpdateProductDetails(respWriter http.ResponseWriter, req *http.Request) {
+	respWriter.Header().Set("Content-Type", "application/json")
+
+	params := mux.Vars(req)
+	productId, err := strconv.Atoi(params["id"])
+
+	if err != nil {
+		errMessage := dto.Error{Status: http.StatusBadRequest, Message: err.Error()}
+		errMessage.WriteHeader(respWriter)
+		json.NewEncoder(respWriter).Encode(errMessage)
+		return
+	}
+
+	var body dto.UpdateProductDetailsRequest
+
+	if err := json.NewDecoder(req.Body).Decode(&body); err != nil {
+		errMessage := dto.Error{Status: http.StatusBadRequest, Message: err.Error()}
+		errMessage.WriteHeader(respWriter)
+		json.NewEncoder(respWriter).Encode(errMessage)
+		return
+	}
+
+	expectedVersion, err := parseIfMatch(req)
+
+	if err != nil {
+		errMessage := dto.Error{Status: http.StatusBadRequest, Message: err.Error()}
+		errMessage.WriteHeader(respWriter)
+		json.NewEncoder(respWriter).Encode(errMessage)
+		return
+	}
+
+	updateRequest := &proto.UpdateProductDetailsRequest{Id: int32(productId), UpdateMask: &fieldmaskpb.FieldMask{}, ExpectedVersion: expectedVersion}
+
+	if body.Name != nil {
+		updateRequest.Name = *body.Name
+		updateRequest.UpdateMask.Paths = append(updateRequest.UpdateMask.Paths, "name")
+	}
+
+	if body.Description != nil {
+		updateRequest.Description = *body.Description
+		updateRequest.UpdateMask.Paths = append(updateRequest.UpdateMask.Paths, "description")
+	}
+
+	if body.ReorderThreshold != nil {
+		updateRequest.ReorderThreshold = *body.ReorderThreshold
+		updateRequest.UpdateMask.Paths = append(updateRequest.UpdateMask.Paths, "reorder_threshold")
+	}
+
+	if body.Price == nil && body.Currency != nil {
+		errMessage := dto.Error{Status: http.StatusBadRequest, Message: "currency can only be updated together with the price"}
+		errMessage.WriteHeader(respWriter)
+		json.NewEncoder(respWriter).Encode(errMessage)
+		return
+	}
+
+	if body.Price != nil {
+		var currency string
+
+		if body.Currency != nil {
+			currency = *body.Currency
+		} else {
+			currentProduct, err := productclient.ProductServiceClient.GetProduct(req.Context(), &proto.ProductIdRequest{Id: int32(productId)})
+
+			if err != nil {
+				errMessage := dto.RPCError(err)
+				errMessage.WriteHeader(respWriter)
+				json.NewEncoder(respWriter).Encode(errMessage)
+				return
+			}
+
+			currency = currentProduct.Price.GetCurrencyCode()
+		}
+
+		price, err := dto.PriceToMoney(*body.Price, currency)
+
+		if err != nil {
+			errMessage := dto.Error{Status: http.StatusBadRequest, Message: err.Error()}
+			errMessage.WriteHeader(respWriter)
+			json.NewEncoder(respWriter).Encode(errMessage)
+			return
+		}
+
+		updateRequest.Price = price
+		updateRequest.UpdateMask.Paths = append(updateRequest.UpdateMask.Paths, "price")
+	}
+
+	updatedProduct, err := productclient.ProductServiceClient.UpdateProductDetails(req.Context(), updateRequest)
+
+	if err != nil {
+		errMessage := dto.RPCError(err)
+		errMessage.WriteHeader(respWriter)
+		json.NewEncoder(respWriter).Encode(errMessage)
+		return
+	}
+
+	respWriter.Header().Set("ETag", etag(updatedProduct.Version))
+
+	res, err := toProductResponse(updatedProduct)
+
+	if err != nil {
+		errMessage := dto.Error{Status: http.StatusInternalServerError, Message: err.Error()}
+		errMessage.WriteHeader(respWriter)
+		json.NewEncoder(respWriter).Encode(errMessage)
+		return
+	}
 
 	respWriter.WriteHeader(http.StatusOK)
 	json.NewEncoder(respWriter).Encode(res)
@@ -102,16 +316,25 @@ func DeleteProduct(respWriter http.ResponseWriter, req *http.Request) {
 
 	if err != nil {
 		errMessage := dto.Error{Status: http.StatusBadRequest, Message: err.Error()}
-		respWriter.WriteHeader(errMessage.Status)
+		errMessage.WriteHeader(respWriter)
 		json.NewEncoder(respWriter).Encode(errMessage)
 		return
 	}
 
-	deletedProduct, err := productclient.ProductServiceClient.DeleteProduct(req.Context(), &proto.ProductIdRequest{Id: int32(productId)})
+	expectedVersion, err := parseIfMatch(req)
 
 	if err != nil {
-		errMessage := dto.Error{Status: http.StatusBadRequest, Message: strings.Replace(err.Error(), "rpc error: code = Unknown desc = ", "", 1)}
-		respWriter.WriteHeader(errMessage.Status)
+		errMessage := dto.Error{Status: http.StatusBadRequest, Message: err.Error()}
+		errMessage.WriteHeader(respWriter)
+		json.NewEncoder(respWriter).Encode(errMessage)
+		return
+	}
+
+	deletedProduct, err := productclient.ProductServiceClient.DeleteProduct(req.Context(), &proto.ProductIdRequest{Id: int32(productId), ExpectedVersion: expectedVersion})
+
+	if err != nil {
+		errMessage := dto.RPCError(err)
+		errMessage.WriteHeader(respWriter)
 		json.NewEncoder(respWriter).Encode(errMessage)
 		return
 	}
@@ -122,6 +345,102 @@ func DeleteProduct(respWriter http.ResponseWriter, req *http.Request) {
 	json.NewEncoder(respWriter).Encode(res)
 }
 
+func GetDeletedProducts(respWriter http.ResponseWriter, req *http.Request) {
+	respWriter.Header().Set("Content-Type", "application/json")
+
+	var res []dto.Product
+
+	products, err := productclient.ProductServiceClient.ListDeletedProducts(req.Context(), &empty.Empty{})
+
+	if err != nil {
+		errMessage := dto.RPCError(err)
+		errMessage.WriteHeader(respWriter)
+		json.NewEncoder(respWriter).Encode(errMessage)
+		return
+	}
+
+	for _, product := range products.Products {
+		converted, err := toProductResponse(product)
+
+		if err != nil {
+			errMessage := dto.Error{Status: http.StatusInternalServerError, Message: err.Error()}
+			errMessage.WriteHeader(respWriter)
+			json.NewEncoder(respWriter).Encode(errMessage)
+			return
+		}
+
+		res = append(res, converted)
+	}
+
+	respWriter.WriteHeader(http.StatusOK)
+	json.NewEncoder(respWriter).Encode(res)
+}
+
+func RestoreProduct(respWriter http.ResponseWriter, req *http.Request) {
+	respWriter.Header().Set("Content-Type", "application/json")
+
+	params := mux.Vars(req)
+	productId, err := strconv.Atoi(params["id"])
+
+	if err != nil {
+		errMessage := dto.Error{Status: http.StatusBadRequest, Message: err.Error()}
+		errMessage.WriteHeader(respWriter)
+		json.NewEncoder(respWriter).Encode(errMessage)
+		return
+	}
+
+	restoredProduct, err := productclient.ProductServiceClient.RestoreProduct(req.Context(), &proto.ProductIdRequest{Id: int32(productId)})
+
+	if err != nil {
+		errMessage := dto.RPCError(err)
+		errMessage.WriteHeader(respWriter)
+		json.NewEncoder(respWriter).Encode(errMessage)
+		return
+	}
+
+	respWriter.Header().Set("ETag", etag(restoredProduct.Version))
+
+	res, err := toProductResponse(restoredProduct)
+
+	if err != nil {
+		errMessage := dto.Error{Status: http.StatusInternalServerError, Message: err.Error()}
+		errMessage.WriteHeader(respWriter)
+		json.NewEncoder(respWriter).Encode(errMessage)
+		return
+	}
+
+	respWriter.WriteHeader(http.StatusOK)
+	json.NewEncoder(respWriter).Encode(res)
+}
+
+func PurgeProduct(respWriter http.ResponseWriter, req *http.Request) {
+	respWriter.Header().Set("Content-Type", "application/json")
+
+	params := mux.Vars(req)
+	productId, err := strconv.Atoi(params["id"])
+
+	if err != nil {
+		errMessage := dto.Error{Status: http.StatusBadRequest, Message: err.Error()}
+		errMessage.WriteHeader(respWriter)
+		json.NewEncoder(respWriter).Encode(errMessage)
+		return
+	}
+
+	purgedProduct, err := productclient.ProductServiceClient.PurgeProduct(req.Context(), &proto.ProductIdRequest{Id: int32(productId)})
+
+	if err != nil {
+		errMessage := dto.RPCError(err)
+		errMessage.WriteHeader(respWriter)
+		json.NewEncoder(respWriter).Encode(errMessage)
+		return
+	}
+
+	res := dto.DeletedProductResponse{Id: purgedProduct.Id}
+
+	respWriter.WriteHeader(http.StatusOK)
+	json.NewEncoder(respWriter).Encode(res)
+}
+
 func AddProducts(respWriter http.ResponseWriter, req *http.Request) {
 	respWriter.Header().Set("Content-Type", "application/json")
 
@@ -129,23 +448,47 @@ func AddProducts(respWriter http.ResponseWriter, req *http.Request) {
 
 	if err := json.NewDecoder(req.Body).Decode(&body); err != nil {
 		errMessage := dto.Error{Status: http.StatusBadRequest, Message: err.Error()}
-		respWriter.WriteHeader(errMessage.Status)
+		errMessage.WriteHeader(respWriter)
+		json.NewEncoder(respWriter).Encode(errMessage)
+		return
+	}
+
+	expectedVersion, err := parseIfMatch(req)
+
+	if err != nil {
+		errMessage := dto.Error{Status: http.StatusBadRequest, Message: err.Error()}
+		errMessage.WriteHeader(respWriter)
 		json.NewEncoder(respWriter).Encode(errMessage)
 		return
 	}
 
+	var header metadata.MD
+
 	updatedProduct, err := productclient.ProductServiceClient.AddProducts(req.Context(), &proto.UpdateProductQuantityRequest{
-		Id:       body.Id,
-		Quantity: body.Quantity})
+		Id:              body.Id,
+		SkuId:           body.SkuId,
+		WarehouseId:     body.WarehouseId,
+		Quantity:        body.Quantity,
+		ExpectedVersion: expectedVersion}, grpc.Header(&header))
 
 	if err != nil {
-		errMessage := dto.Error{Status: http.StatusBadRequest, Message: strings.Replace(err.Error(), "rpc error: code = Unknown desc = ", "", 1)}
-		respWriter.WriteHeader(errMessage.Status)
+		errMessage := dto.RPCError(err)
+		errMessage.WriteHeader(respWriter)
 		json.NewEncoder(respWriter).Encode(errMessage)
 		return
 	}
 
-	res := dto.Product{Id: updatedProduct.Id, Name: updatedProduct.Name, Description: updatedProduct.Description, Price: updatedProduct.Price, Quantity: updatedProduct.Quantity}
+	markReplayed(respWriter, header)
+	respWriter.Header().Set("ETag", etag(updatedProduct.Version))
+
+	res, err := toProductResponse(updatedProduct)
+
+	if err != nil {
+		errMessage := dto.Error{Status: http.StatusInternalServerError, Message: err.Error()}
+		errMessage.WriteHeader(respWriter)
+		json.NewEncoder(respWriter).Encode(errMessage)
+		return
+	}
 
 	respWriter.WriteHeader(http.StatusCreated)
 	json.NewEncoder(respWriter).Encode(res)
@@ -158,24 +501,204 @@ func RemoveProducts(respWriter http.ResponseWriter, req *http.Request) {
 
 	if err := json.NewDecoder(req.Body).Decode(&body); err != nil {
 		errMessage := dto.Error{Status: http.StatusBadRequest, Message: err.Error()}
-		respWriter.WriteHeader(errMessage.Status)
+		errMessage.WriteHeader(respWriter)
+		json.NewEncoder(respWriter).Encode(errMessage)
+		return
+	}
+
+	expectedVersion, err := parseIfMatch(req)
+
+	if err != nil {
+		errMessage := dto.Error{Status: http.StatusBadRequest, Message: err.Error()}
+		errMessage.WriteHeader(respWriter)
 		json.NewEncoder(respWriter).Encode(errMessage)
 		return
 	}
 
+	var header metadata.MD
+
 	updatedProduct, err := productclient.ProductServiceClient.RemoveProducts(req.Context(), &proto.UpdateProductQuantityRequest{
-		Id:       body.Id,
-		Quantity: body.Quantity})
+		Id:              body.Id,
+		SkuId:           body.SkuId,
+		WarehouseId:     body.WarehouseId,
+		Quantity:        body.Quantity,
+		ExpectedVersion: expectedVersion}, grpc.Header(&header))
 
 	if err != nil {
-		errMessage := dto.Error{Status: http.StatusBadRequest, Message: strings.Replace(err.Error(), "rpc error: code = Unknown desc = ", "", 1)}
-		respWriter.WriteHeader(errMessage.Status)
+		errMessage := dto.RPCError(err)
+		errMessage.WriteHeader(respWriter)
 		json.NewEncoder(respWriter).Encode(errMessage)
 		return
 	}
 
-	res := dto.Product{Id: updatedProduct.Id, Name: updatedProduct.Name, Description: updatedProduct.Description, Price: updatedProduct.Price, Quantity: updatedProduct.Quantity}
+	markReplayed(respWriter, header)
+	respWriter.Header().Set("ETag", etag(updatedProduct.Version))
+
+	res, err := toProductResponse(updatedProduct)
+
+	if err != nil {
+		errMessage := dto.Error{Status: http.StatusInternalServerError, Message: err.Error()}
+		errMessage.WriteHeader(respWriter)
+		json.NewEncoder(respWriter).Encode(errMessage)
+		return
+	}
 
 	respWriter.WriteHeader(http.StatusCreated)
 	json.NewEncoder(respWriter).Encode(res)
 }
+
+// RemoveOrderProducts takes the stock of a whole order in one call, so either
+// every line is reserved or none is. It backs the internal order flow route.
+func RemoveOrderProducts(respWriter http.ResponseWriter, req *http.Request) {
+	respWriter.Header().Set("Content-Type", "application/json")
+
+	var body dto.RemoveOrderProductsRequest
+
+	if err := json.NewDecoder(req.Body).Decode(&body); err != nil {
+		errMessage := dto.Error{Status: http.StatusBadRequest, Message: err.Error()}
+		errMessage.WriteHeader(respWriter)
+		json.NewEncoder(respWriter).Encode(errMessage)
+		return
+	}
+
+	if len(body.Products) == 0 {
+		errMessage := dto.Error{Status: http.StatusBadRequest, Message: "at least one product is required"}
+		errMessage.WriteHeader(respWriter)
+		json.NewEncoder(respWriter).Encode(errMessage)
+		return
+	}
+
+	var products []*proto.UpdateProduct
+
+	for _, product := range body.Products {
+		products = append(products, &proto.UpdateProduct{
+			Id:       int64(product.Id),
+			SkuId:    int64(product.SkuId),
+			Quantity: product.Quantity,
+		})
+	}
+
+	_, err := productclient.ProductServiceClient.UpdateProducts(req.Context(), &proto.UpdateProductRequest{
+		Products:           products,
+		AllocationStrategy: body.AllocationStrategy,
+	})
+
+	if err != nil {
+		errMessage := dto.RPCError(err)
+		errMessage.WriteHeader(respWriter)
+		json.NewEncoder(respWriter).Encode(errMessage)
+		return
+	}
+
+	respWriter.WriteHeader(http.StatusNoContent)
+}
+
+func TransferStock(respWriter http.ResponseWriter, req *http.Request) {
+	respWriter.Header().Set("Content-Type", "application/json")
+
+	params := mux.Vars(req)
+	productId, err := strconv.Atoi(params["id"])
+
+	if err != nil {
+		errMessage := dto.Error{Status: http.StatusBadRequest, Message: err.Error()}
+		errMessage.WriteHeader(respWriter)
+		json.NewEncoder(respWriter).Encode(errMessage)
+		return
+	}
+
+	var body dto.TransferStockRequest
+
+	if err := json.NewDecoder(req.Body).Decode(&body); err != nil {
+		errMessage := dto.Error{Status: http.StatusBadRequest, Message: err.Error()}
+		errMessage.WriteHeader(respWriter)
+		json.NewEncoder(respWriter).Encode(errMessage)
+		return
+	}
+
+	expectedVersion, err := parseIfMatch(req)
+
+	if err != nil {
+		errMessage := dto.Error{Status: http.StatusBadRequest, Message: err.Error()}
+		errMessage.WriteHeader(respWriter)
+		json.NewEncoder(respWriter).Encode(errMessage)
+		return
+	}
+
+	var header metadata.MD
+
+	updatedProduct, err := productclient.ProductServiceClient.TransferStock(req.Context(), &proto.TransferStockRequest{
+		ProductId:       int32(productId),
+		SkuId:           body.SkuId,
+		FromWarehouseId: body.FromWarehouseId,
+		ToWarehouseId:   body.ToWarehouseId,
+		Quantity:        body.Quantity,
+		ExpectedVersion: expectedVersion}, grpc.Header(&header))
+
+	if err != nil {
+		errMessage := dto.RPCError(err)
+		errMessage.WriteHeader(respWriter)
+		json.NewEncoder(respWriter).Encode(errMessage)
+		return
+	}
+
+	markReplayed(respWriter, header)
+	respWriter.Header().Set("ETag", etag(updatedProduct.Version))
+
+	res, err := toProductResponse(updatedProduct)
+
+	if err != nil {
+		errMessage := dto.Error{Status: http.StatusInternalServerError, Message: err.Error()}
+		errMessage.WriteHeader(respWriter)
+		json.NewEncoder(respWriter).Encode(errMessage)
+		return
+	}
+
+	respWriter.WriteHeader(http.StatusOK)
+	json.NewEncoder(respWriter).Encode(res)
+}
+
+func toProductResponse(product *proto.CreateProductResponse) (dto.Product, error) {
+	price, currency, err := dto.PriceFromMoney(product.Price)
+
+	if err != nil {
+		return dto.Product{}, err
+	}
+
+	var skus []dto.Sku
+	var stock []dto.WarehouseStock
+
+	for _, sku := range product.Skus {
+		converted, err := dto.SkuFromProto(sku)
+
+		if err != nil {
+			return dto.Product{}, err
+		}
+
+		skus = append(skus, converted)
+	}
+
+	for _, level := range product.Stock {
+		stock = append(stock, dto.WarehouseStock{WarehouseId: level.WarehouseId, SkuId: level.SkuId, Quantity: level.Quantity})
+	}
+
+	var deletedAt *time.Time
+
+	if product.DeletedAt != nil {
+		deleted := product.DeletedAt.AsTime()
+		deletedAt = &deleted
+	}
+
+	return dto.Product{
+		Id:               product.Id,
+		Name:             product.Name,
+		Description:      product.Description,
+		Price:            price,
+		Currency:         currency,
+		Quantity:         product.Quantity,
+		Version:          product.Version,
+		Skus:             skus,
+		Stock:            stock,
+		ReorderThreshold: product.ReorderThreshold,
+		DeletedAt:        deletedAt,
+	}, nil
+}
@@ -0,0 +1,110 @@
+package producthandler
+
+import (
+	"api-gateway/clients/productclient"
+	"api-gateway/dto"
+	proto "api-gateway/proto/product"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+)
+
+const (
+	heartbeatInterval = 15 * time.Second
+)
+
+func StreamInventory(respWriter http.ResponseWriter, req *http.Request) {
+	flusher, ok := respWriter.(http.Flusher)
+
+	if !ok {
+		respWriter.Header().Set("Content-Type", "application/json")
+		errMessage := dto.Error{Status: http.StatusInternalServerError, Message: "streaming is not supported"}
+		errMessage.WriteHeader(respWriter)
+		json.NewEncoder(respWriter).Encode(errMessage)
+		return
+	}
+
+	var productIds []int32
+
+	if ids := req.URL.Query().Get("ids"); len(ids) != 0 {
+		for _, id := range strings.Split(ids, ",") {
+			productId, err := strconv.Atoi(strings.TrimSpace(id))
+
+			if err != nil {
+				respWriter.Header().Set("Content-Type", "application/json")
+				errMessage := dto.Error{Status: http.StatusBadRequest, Message: "invalid product id " + id}
+				errMessage.WriteHeader(respWriter)
+				json.NewEncoder(respWriter).Encode(errMessage)
+				return
+			}
+
+			productIds = append(productIds, int32(productId))
+		}
+	}
+
+	stream, err := productclient.ProductServiceClient.WatchInventory(req.Context(), &proto.WatchInventoryRequest{ProductIds: productIds})
+
+	if err != nil {
+		respWriter.Header().Set("Content-Type", "application/json")
+		errMessage := dto.RPCError(err)
+		errMessage.WriteHeader(respWriter)
+		json.NewEncoder(respWriter).Encode(errMessage)
+		return
+	}
+
+	respWriter.Header().Set("Content-Type", "text/event-stream")
+	respWriter.Header().Set("Cache-Control", "no-cache")
+	respWriter.Header().Set("Connection", "keep-alive")
+	respWriter.WriteHeader(http.StatusOK)
+	flusher.Flush()
+
+	updates := make(chan *proto.InventoryUpdate)
+	failure := make(chan error, 1)
+
+	go func() {
+		for {
+			update, err := stream.Recv()
+
+			if err != nil {
+				failure <- err
+				return
+			}
+
+			select {
+			case updates <- update:
+			case <-req.Context().Done():
+				return
+			}
+		}
+	}()
+
+	heartbeat := time.NewTicker(heartbeatInterval)
+	defer heartbeat.Stop()
+
+	for {
+		select {
+		case <-req.Context().Done():
+			return
+		case <-heartbeat.C:
+			fmt.Fprint(respWriter, ": keep-alive\n\n")
+		case err := <-failure:
+			errMessage, _ := json.Marshal(dto.RPCError(err))
+			fmt.Fprintf(respWriter, "event: error\ndata: %s\n\n", errMessage)
+			flusher.Flush()
+			return
+		case update := <-updates:
+			data, _ := json.Marshal(dto.InventoryUpdate{
+				ProductId: update.ProductId,
+				Quantity:  update.Quantity,
+				Version:   update.Version,
+				ChangedAt: update.ChangedAt.AsTime(),
+			})
+			fmt.Fprintf(respWriter, "event: inventory\nid: %d-%d\ndata: %s\n\n", update.ProductId, update.Version, data)
+		}
+
+		flusher.Flush()
+	}
+}
@@ -0,0 +1,226 @@
+package promotionhandler
+
+import (
+	"api-gateway/clients/productclient"
+	"api-gateway/dto"
+	proto "api-gateway/proto/product"
+	"encoding/json"
+	"net/http"
+	"strconv"
+
+	"github.com/golang/protobuf/ptypes/empty"
+	"github.com/gorilla/mux"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+func GetAllPromotions(respWriter http.ResponseWriter, req *http.Request) {
+	respWriter.Header().Set("Content-Type", "application/json")
+
+	var res []dto.Promotion
+
+	promotions, err := productclient.ProductServiceClient.GetAllPromotions(req.Context(), &empty.Empty{})
+
+	if err != nil {
+		errMessage := dto.RPCError(err)
+		errMessage.WriteHeader(respWriter)
+		json.NewEncoder(respWriter).Encode(errMessage)
+		return
+	}
+
+	for _, promotion := range promotions.Promotions {
+		converted, err := toPromotionResponse(promotion)
+
+		if err != nil {
+			errMessage := dto.Error{Status: http.StatusInternalServerError, Message: err.Error()}
+			errMessage.WriteHeader(respWriter)
+			json.NewEncoder(respWriter).Encode(errMessage)
+			return
+		}
+
+		res = append(res, converted)
+	}
+
+	respWriter.WriteHeader(http.StatusOK)
+	json.NewEncoder(respWriter).Encode(res)
+}
+
+func CreatePromotion(respWriter http.ResponseWriter, req *http.Request) {
+	respWriter.Header().Set("Content-Type", "application/json")
+
+	var newPromotion dto.CreatePromotionRequest
+
+	if err := json.NewDecoder(req.Body).Decode(&newPromotion); err != nil {
+		errMessage := dto.Error{Status: http.StatusBadRequest, Message: err.Error()}
+		errMessage.WriteHeader(respWriter)
+		json.NewEncoder(respWriter).Encode(errMessage)
+		return
+	}
+
+	createRequest := &proto.CreatePromotionRequest{
+		Name:        newPromotion.Name,
+		Type:        newPromotion.Type,
+		ProductId:   newPromotion.ProductId,
+		PercentOff:  newPromotion.PercentOff,
+		BuyQuantity: newPromotion.BuyQuantity,
+		GetQuantity: newPromotion.GetQuantity,
+		CouponCode:  newPromotion.CouponCode,
+	}
+
+	if newPromotion.AmountOff != nil {
+		currency := newPromotion.Currency
+
+		if len(currency) == 0 && newPromotion.ProductId != 0 {
+			product, err := productclient.ProductServiceClient.GetProduct(req.Context(), &proto.ProductIdRequest{Id: newPromotion.ProductId})
+
+			if err != nil {
+				errMessage := dto.RPCError(err)
+				errMessage.WriteHeader(respWriter)
+				json.NewEncoder(respWriter).Encode(errMessage)
+				return
+			}
+
+			currency = product.Price.GetCurrencyCode()
+		}
+
+		amountOff, err := dto.PriceToMoney(*newPromotion.AmountOff, currency)
+
+		if err != nil {
+			errMessage := dto.Error{Status: http.StatusBadRequest, Message: err.Error()}
+			errMessage.WriteHeader(respWriter)
+			json.NewEncoder(respWriter).Encode(errMessage)
+			return
+		}
+
+		createRequest.AmountOff = amountOff
+	}
+
+	if newPromotion.StartsAt != nil {
+		createRequest.StartsAt = timestamppb.New(*newPromotion.StartsAt)
+	}
+
+	if newPromotion.EndsAt != nil {
+		createRequest.EndsAt = timestamppb.New(*newPromotion.EndsAt)
+	}
+
+	createdPromotion, err := productclient.ProductServiceClient.CreatePromotion(req.Context(), createRequest)
+
+	if err != nil {
+		errMessage := dto.RPCError(err)
+		errMessage.WriteHeader(respWriter)
+		json.NewEncoder(respWriter).Encode(errMessage)
+		return
+	}
+
+	res, err := toPromotionResponse(createdPromotion)
+
+	if err != nil {
+		errMessage := dto.Error{Status: http.StatusInternalServerError, Message: err.Error()}
+		errMessage.WriteHeader(respWriter)
+		json.NewEncoder(respWriter).Encode(errMessage)
+		return
+	}
+
+	respWriter.WriteHeader(http.StatusCreated)
+	json.NewEncoder(respWriter).Encode(res)
+}
+
+func DeletePromotion(respWriter http.ResponseWriter, req *http.Request) {
+	respWriter.Header().Set("Content-Type", "application/json")
+
+	params := mux.Vars(req)
+	promotionId, err := strconv.Atoi(params["id"])
+
+	if err != nil {
+		errMessage := dto.Error{Status: http.StatusBadRequest, Message: err.Error()}
+		errMessage.WriteHeader(respWriter)
+		json.NewEncoder(respWriter).Encode(errMessage)
+		return
+	}
+
+	deletedPromotion, err := productclient.ProductServiceClient.DeletePromotion(req.Context(), &proto.PromotionIdRequest{Id: int32(promotionId)})
+
+	if err != nil {
+		errMessage := dto.RPCError(err)
+		errMessage.WriteHeader(respWriter)
+		json.NewEncoder(respWriter).Encode(errMessage)
+		return
+	}
+
+	res := dto.DeletedPromotionResponse{Id: deletedPromotion.Id}
+
+	respWriter.WriteHeader(http.StatusOK)
+	json.NewEncoder(respWriter).Encode(res)
+}
+
+func PriceCart(respWriter http.ResponseWriter, req *http.Request) {
+	respWriter.Header().Set("Content-Type", "application/json")
+
+	var cart dto.PriceCartRequest
+
+	if err := json.NewDecoder(req.Body).Decode(&cart); err != nil {
+		errMessage := dto.Error{Status: http.StatusBadRequest, Message: err.Error()}
+		errMessage.WriteHeader(respWriter)
+		json.NewEncoder(respWriter).Encode(errMessage)
+		return
+	}
+
+	priced, err := productclient.ProductServiceClient.PriceCart(req.Context(), &proto.PriceCartRequest{
+		Cart:        dto.CartToProto(cart.Products),
+		CouponCodes: cart.CouponCodes,
+	})
+
+	if err != nil {
+		errMessage := dto.RPCError(err)
+		errMessage.WriteHeader(respWriter)
+		json.NewEncoder(respWriter).Encode(errMessage)
+		return
+	}
+
+	res, err := dto.PricedCartFromProto(priced)
+
+	if err != nil {
+		errMessage := dto.Error{Status: http.StatusInternalServerError, Message: err.Error()}
+		errMessage.WriteHeader(respWriter)
+		json.NewEncoder(respWriter).Encode(errMessage)
+		return
+	}
+
+	respWriter.WriteHeader(http.StatusOK)
+	json.NewEncoder(respWriter).Encode(res)
+}
+
+func toPromotionResponse(promotion *proto.Promotion) (dto.Promotion, error) {
+	res := dto.Promotion{
+		Id:          promotion.Id,
+		Name:        promotion.Name,
+		Type:        promotion.Type,
+		ProductId:   promotion.ProductId,
+		PercentOff:  promotion.PercentOff,
+		BuyQuantity: promotion.BuyQuantity,
+		GetQuantity: promotion.GetQuantity,
+		CouponCode:  promotion.CouponCode,
+	}
+
+	if promotion.AmountOff != nil {
+		amountOff, currency, err := dto.PriceFromMoney(promotion.AmountOff)
+
+		if err != nil {
+			return dto.Promotion{}, err
+		}
+
+		res.AmountOff = &amountOff
+		res.Currency = currency
+	}
+
+	if promotion.StartsAt != nil {
+		startsAt := promotion.StartsAt.AsTime()
+		res.StartsAt = &startsAt
+	}
+
+	if promotion.EndsAt != nil {
+		endsAt := promotion.EndsAt.AsTime()
+		res.EndsAt = &endsAt
+	}
+
+	return res, nil
+}
@@ -0,0 +1,230 @@
+package skuhandler
+
+import (
+	"api-gateway/clients/productclient"
+	"api-gateway/dto"
+	proto "api-gateway/proto/product"
+	"context"
+	"encoding/json"
+	"net/http"
+	"strconv"
+
+	"github.com/gorilla/mux"
+	"google.golang.org/protobuf/types/known/fieldmaskpb"
+)
+
+func CreateSku(respWriter http.ResponseWriter, req *http.Request) {
+	respWriter.Header().Set("Content-Type", "application/json")
+
+	params := mux.Vars(req)
+	productId, err := strconv.Atoi(params["id"])
+
+	if err != nil {
+		errMessage := dto.Error{Status: http.StatusBadRequest, Message: err.Error()}
+		errMessage.WriteHeader(respWriter)
+		json.NewEncoder(respWriter).Encode(errMessage)
+		return
+	}
+
+	var body dto.CreateSkuRequest
+
+	if err := json.NewDecoder(req.Body).Decode(&body); err != nil {
+		errMessage := dto.Error{Status: http.StatusBadRequest, Message: err.Error()}
+		errMessage.WriteHeader(respWriter)
+		json.NewEncoder(respWriter).Encode(errMessage)
+		return
+	}
+
+	createRequest := &proto.CreateSkuRequest{
+		ProductId:  int32(productId),
+		Code:       body.Code,
+		Attributes: body.Attributes,
+		Quantity:   body.Quantity}
+
+	if body.Price != nil {
+		createRequest.PriceOverride, err = priceOverride(req.Context(), int32(productId), *body.Price)
+
+		if err != nil {
+			errMessage := dto.RPCError(err)
+			errMessage.WriteHeader(respWriter)
+			json.NewEncoder(respWriter).Encode(errMessage)
+			return
+		}
+	}
+
+	createdSku, err := productclient.ProductServiceClient.CreateSku(req.Context(), createRequest)
+
+	if err != nil {
+		errMessage := dto.RPCError(err)
+		errMessage.WriteHeader(respWriter)
+		json.NewEncoder(respWriter).Encode(errMessage)
+		return
+	}
+
+	res, err := dto.SkuFromProto(createdSku)
+
+	if err != nil {
+		errMessage := dto.Error{Status: http.StatusInternalServerError, Message: err.Error()}
+		errMessage.WriteHeader(respWriter)
+		json.NewEncoder(respWriter).Encode(errMessage)
+		return
+	}
+
+	respWriter.WriteHeader(http.StatusCreated)
+	json.NewEncoder(respWriter).Encode(res)
+}
+
+func GetSku(respWriter http.ResponseWriter, req *http.Request) {
+	respWriter.Header().Set("Content-Type", "application/json")
+
+	params := mux.Vars(req)
+	skuId, err := strconv.Atoi(params["id"])
+
+	if err != nil {
+		errMessage := dto.Error{Status: http.StatusBadRequest, Message: err.Error()}
+		errMessage.WriteHeader(respWriter)
+		json.NewEncoder(respWriter).Encode(errMessage)
+		return
+	}
+
+	sku, err := productclient.ProductServiceClient.GetSku(req.Context(), &proto.SkuIdRequest{Id: int32(skuId)})
+
+	if err != nil {
+		errMessage := dto.RPCError(err)
+		errMessage.WriteHeader(respWriter)
+		json.NewEncoder(respWriter).Encode(errMessage)
+		return
+	}
+
+	res, err := dto.SkuFromProto(sku)
+
+	if err != nil {
+		errMessage := dto.Error{Status: http.StatusInternalServerError, Message: err.Error()}
+		errMessage.WriteHeader(respWriter)
+		json.NewEncoder(respWriter).Encode(errMessage)
+		return
+	}
+
+	respWriter.WriteHeader(http.StatusOK)
+	json.NewEncoder(respWriter).Encode(res)
+}
+
+func UpdateSku(respWriter http.ResponseWriter, req *http.Request) {
+	respWriter.Header().Set("Content-Type", "application/json")
+
+	params := mux.Vars(req)
+	skuId, err := strconv.Atoi(params["id"])
+
+	if err != nil {
+		errMessage := dto.Error{Status: http.StatusBadRequest, Message: err.Error()}
+		errMessage.WriteHeader(respWriter)
+		json.NewEncoder(respWriter).Encode(errMessage)
+		return
+	}
+
+	var body dto.UpdateSkuRequest
+
+	if err := json.NewDecoder(req.Body).Decode(&body); err != nil {
+		errMessage := dto.Error{Status: http.StatusBadRequest, Message: err.Error()}
+		errMessage.WriteHeader(respWriter)
+		json.NewEncoder(respWriter).Encode(errMessage)
+		return
+	}
+
+	if body.Price != nil && body.ClearPrice {
+		errMessage := dto.Error{Status: http.StatusBadRequest, Message: "price and clearPrice cannot be used together"}
+		errMessage.WriteHeader(respWriter)
+		json.NewEncoder(respWriter).Encode(errMessage)
+		return
+	}
+
+	updateRequest := &proto.UpdateSkuRequest{Id: int32(skuId), UpdateMask: &fieldmaskpb.FieldMask{}}
+
+	if body.Code != nil {
+		updateRequest.Code = *body.Code
+		updateRequest.UpdateMask.Paths = append(updateRequest.UpdateMask.Paths, "code")
+	}
+
+	if body.Attributes != nil {
+		updateRequest.Attributes = *body.Attributes
+		updateRequest.UpdateMask.Paths = append(updateRequest.UpdateMask.Paths, "attributes")
+	}
+
+	if body.Price != nil {
+		sku, err := productclient.ProductServiceClient.GetSku(req.Context(), &proto.SkuIdRequest{Id: int32(skuId)})
+
+		if err == nil {
+			updateRequest.PriceOverride, err = priceOverride(req.Context(), sku.ProductId, *body.Price)
+		}
+
+		if err != nil {
+			errMessage := dto.RPCError(err)
+			errMessage.WriteHeader(respWriter)
+			json.NewEncoder(respWriter).Encode(errMessage)
+			return
+		}
+	}
+
+	if body.Price != nil || body.ClearPrice {
+		updateRequest.UpdateMask.Paths = append(updateRequest.UpdateMask.Paths, "price_override")
+	}
+
+	updatedSku, err := productclient.ProductServiceClient.UpdateSku(req.Context(), updateRequest)
+
+	if err != nil {
+		errMessage := dto.RPCError(err)
+		errMessage.WriteHeader(respWriter)
+		json.NewEncoder(respWriter).Encode(errMessage)
+		return
+	}
+
+	res, err := dto.SkuFromProto(updatedSku)
+
+	if err != nil {
+		errMessage := dto.Error{Status: http.StatusInternalServerError, Message: err.Error()}
+		errMessage.WriteHeader(respWriter)
+		json.NewEncoder(respWriter).Encode(errMessage)
+		return
+	}
+
+	respWriter.WriteHeader(http.StatusOK)
+	json.NewEncoder(respWriter).Encode(res)
+}
+
+func DeleteSku(respWriter http.ResponseWriter, req *http.Request) {
+	respWriter.Header().Set("Content-Type", "application/json")
+
+	params := mux.Vars(req)
+	skuId, err := strconv.Atoi(params["id"])
+
+	if err != nil {
+		errMessage := dto.Error{Status: http.StatusBadRequest, Message: err.Error()}
+		errMessage.WriteHeader(respWriter)
+		json.NewEncoder(respWriter).Encode(errMessage)
+		return
+	}
+
+	deletedSku, err := productclient.ProductServiceClient.DeleteSku(req.Context(), &proto.SkuIdRequest{Id: int32(skuId)})
+
+	if err != nil {
+		errMessage := dto.RPCError(err)
+		errMessage.WriteHeader(respWriter)
+		json.NewEncoder(respWriter).Encode(errMessage)
+		return
+	}
+
+	res := dto.DeletedSkuResponse{Id: deletedSku.Id}
+
+	respWriter.WriteHeader(http.StatusOK)
+	json.NewEncoder(respWriter).Encode(res)
+}
+
+func priceOverride(ctx context.Context, productId int32, price json.Number) (*proto.Money, error) {
+	product, err := productclient.ProductServiceClient.GetProduct(ctx, &proto.ProductIdRequest{Id: productId})
+
+	if err != nil {
+		return nil, err
+	}
+
+	return dto.PriceToMoney(price, product.Price.GetCurrencyCode())
+}
@@ -0,0 +1,132 @@
+package warehousehandler
+
+import (
+	"api-gateway/clients/productclient"
+	"api-gateway/dto"
+	proto "api-gateway/proto/product"
+	"encoding/json"
+	"net/http"
+	"strconv"
+
+	"github.com/golang/protobuf/ptypes/empty"
+	"github.com/gorilla/mux"
+)
+
+func GetAllWarehouses(respWriter http.ResponseWriter, req *http.Request) {
+	respWriter.Header().Set("Content-Type", "application/json")
+
+	var res []dto.Warehouse
+
+	warehouses, err := productclient.ProductServiceClient.GetAllWarehouses(req.Context(), &empty.Empty{})
+
+	if err != nil {
+		errMessage := dto.RPCError(err)
+		errMessage.WriteHeader(respWriter)
+		json.NewEncoder(respWriter).Encode(errMessage)
+		return
+	}
+
+	for _, warehouse := range warehouses.Warehouses {
+		res = append(res, toWarehouseResponse(warehouse))
+	}
+
+	respWriter.WriteHeader(http.StatusOK)
+	json.NewEncoder(respWriter).Encode(res)
+}
+
+func CreateWarehouse(respWriter http.ResponseWriter, req *http.Request) {
+	respWriter.Header().Set("Content-Type", "application/json")
+
+	var newWarehouse dto.CreateWarehouseRequest
+
+	if err := json.NewDecoder(req.Body).Decode(&newWarehouse); err != nil {
+		errMessage := dto.Error{Status: http.StatusBadRequest, Message: err.Error()}
+		errMessage.WriteHeader(respWriter)
+		json.NewEncoder(respWriter).Encode(errMessage)
+		return
+	}
+
+	createdWarehouse, err := productclient.ProductServiceClient.CreateWarehouse(req.Context(), &proto.CreateWarehouseRequest{
+		Name:     newWarehouse.Name,
+		Location: &proto.Location{Latitude: newWarehouse.Latitude, Longitude: newWarehouse.Longitude},
+		Priority: newWarehouse.Priority})
+
+	if err != nil {
+		errMessage := dto.RPCError(err)
+		errMessage.WriteHeader(respWriter)
+		json.NewEncoder(respWriter).Encode(errMessage)
+		return
+	}
+
+	res := toWarehouseResponse(createdWarehouse)
+
+	respWriter.WriteHeader(http.StatusCreated)
+	json.NewEncoder(respWriter).Encode(res)
+}
+
+func GetWarehouse(respWriter http.ResponseWriter, req *http.Request) {
+	respWriter.Header().Set("Content-Type", "application/json")
+
+	params := mux.Vars(req)
+	warehouseId, err := strconv.Atoi(params["id"])
+
+	if err != nil {
+		errMessage := dto.Error{Status: http.StatusBadRequest, Message: err.Error()}
+		errMessage.WriteHeader(respWriter)
+		json.NewEncoder(respWriter).Encode(errMessage)
+		return
+	}
+
+	warehouse, err := productclient.ProductServiceClient.GetWarehouse(req.Context(), &proto.WarehouseIdRequest{Id: int32(warehouseId)})
+
+	if err != nil {
+		errMessage := dto.RPCError(err)
+		errMessage.WriteHeader(respWriter)
+		json.NewEncoder(respWriter).Encode(errMessage)
+		return
+	}
+
+	res := toWarehouseResponse(warehouse)
+
+	respWriter.WriteHeader(http.StatusOK)
+	json.NewEncoder(respWriter).Encode(res)
+}
+
+func DeleteWarehouse(respWriter http.ResponseWriter, req *http.Request) {
+	respWriter.Header().Set("Content-Type", "application/json")
+
+	params := mux.Vars(req)
+	warehouseId, err := strconv.Atoi(params["id"])
+
+	if err != nil {
+		errMessage := dto.Error{Status: http.StatusBadRequest, Message: err.Error()}
+		errMessage.WriteHeader(respWriter)
+		json.NewEncoder(respWriter).Encode(errMessage)
+		return
+	}
+
+	deletedWarehouse, err := productclient.ProductServiceClient.DeleteWarehouse(req.Context(), &proto.WarehouseIdRequest{Id: int32(warehouseId)})
+
+	if err != nil {
+		errMessage := dto.RPCError(err)
+		errMessage.WriteHeader(respWriter)
+		json.NewEncoder(respWriter).Encode(errMessage)
+		return
+	}
+
+	res := dto.DeletedWarehouseResponse{Id: deletedWarehouse.Id}
+
+	respWriter.WriteHeader(http.StatusOK)
+	json.NewEncoder(respWriter).Encode(res)
+}
+
+func toWarehouseResponse(warehouse *proto.Warehouse) dto.Warehouse {
+	return dto.Warehouse{
+		Id:        warehouse.Id,
+		Name:      warehouse.Name,
+		Latitude:  warehouse.Location.GetLatitude(),
+		Longitude: warehouse.Location.GetLongitude(),
+		Priority:  warehouse.Priority,
+		IsDefault: warehouse.IsDefault,
+	}
+}
@@ -0,0 +1,50 @@
+package logging
+
+import (
+	"api-gateway/requestid"
+	"context"
+	"fmt"
+	"io"
+	"log/slog"
+)
+
+// contextHandler adds the request id carried by the context to every record,
+// so handlers only need to log with the request context.
+type contextHandler struct {
+	slog.Handler
+}
+
+func (h contextHandler) Handle(ctx context.Context, record slog.Record) error {
+	if id := requestid.FromContext(ctx); len(id) != 0 {
+		record.AddAttrs(slog.String("request_id", id))
+	}
+
+	return h.Handler.Handle(ctx, record)
+}
+
+func (h contextHandler) WithAttrs(attrs []slog.Attr) slog.Handler {
+	return contextHandler{h.Handler.WithAttrs(attrs)}
+}
+
+func (h contextHandler) WithGroup(name string) slog.Handler {
+	return contextHandler{h.Handler.WithGroup(name)}
+}
+
+func NewLogger(writer io.Writer, level string, format string) (*slog.Logger, error) {
+	var parsed slog.Level
+
+	if err := parsed.UnmarshalText([]byte(level)); err != nil {
+		return nil, err
+	}
+
+	options := &slog.HandlerOptions{Level: parsed}
+
+	switch format {
+	case "json":
+		return slog.New(contextHandler{slog.NewJSONHandler(writer, options)}), nil
+	case "text":
+		return slog.New(contextHandler{slog.NewTextHandler(writer, options)}), nil
+	default:
+		return nil, fmt.Errorf("unknown log format %s", format)
+	}
+}
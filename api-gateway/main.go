@@ -2,16 +2,46 @@ package main
 
 import (
 	"api-gateway/clients"
+	"api-gateway/config"
+	"api-gateway/logging"
+	"api-gateway/middlewares"
+	"api-gateway/ratelimit"
 	"api-gateway/routes"
 	"log"
+	"log/slog"
 	"net/http"
+	"os"
 )
 
 func main() {
-	clients.InitClients()
+	cfg, err := config.Load(os.Getenv("CONFIG_FILE"))
 
-	router := routes.NewRouter()
+	if err != nil {
+		log.Fatal(err)
+	}
 
-	log.Println("Server started on port 8080")
-	log.Fatal(http.ListenAndServe(":8080", router))
+	logger, err := logging.NewLogger(os.Stderr, cfg.Log.Level, cfg.Log.Format)
+
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	slog.SetDefault(logger)
+
+	clients.InitClients(cfg.Upstreams)
+	middlewares.SetInternalToken(cfg.InternalToken)
+
+	if cfg.RateLimits.Enabled {
+		middlewares.SetRateLimiter(ratelimit.NewLimiter(ratelimit.NewMemoryStore(), cfg.RateLimits), cfg.RateLimits.TrustForwardedFor)
+	}
+
+	handler := middlewares.RequestIDMiddleware(middlewares.AccessLogMiddleware(routes.NewRouter(cfg.Features)))
+
+	slog.Info("server started", "address", cfg.ListenAddress)
+
+	if cfg.TLS.Enabled {
+		log.Fatal(http.ListenAndServeTLS(cfg.ListenAddress, cfg.TLS.CertFile, cfg.TLS.KeyFile, handler))
+	}
+
+	log.Fatal(http.ListenAndServe(cfg.ListenAddress, handler))
 }
@@ -0,0 +1,41 @@
+package metrics
+
+import (
+	"strconv"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+	"google.golang.org/grpc/codes"
+)
+
+var (
+	httpDuration = promauto.NewHistogramVec(prometheus.HistogramOpts{
+		Name:    "gateway_http_request_duration_seconds",
+		Help:    "Time taken to serve HTTP requests, by method, route template and status.",
+		Buckets: prometheus.DefBuckets,
+	}, []string{"method", "route", "status"})
+
+	clientDuration = promauto.NewHistogramVec(prometheus.HistogramOpts{
+		Name:    "gateway_grpc_client_handling_seconds",
+		Help:    "Time taken by upstream RPCs, by upstream, method and status code.",
+		Buckets: prometheus.DefBuckets,
+	}, []string{"upstream", "method", "code"})
+
+	ordersCreated = promauto.NewCounter(prometheus.CounterOpts{
+		Name: "gateway_orders_created_total",
+		Help: "Orders created through the gateway.",
+	})
+)
+
+func ObserveHTTPRequest(method string, route string, status int, duration time.Duration) {
+	httpDuration.WithLabelValues(method, route, strconv.Itoa(status)).Observe(duration.Seconds())
+}
+
+func ObserveClientRPC(upstream string, method string, code codes.Code, duration time.Duration) {
+	clientDuration.WithLabelValues(upstream, method, code.String()).Observe(duration.Seconds())
+}
+
+func RecordOrderCreated() {
+	ordersCreated.Inc()
+}
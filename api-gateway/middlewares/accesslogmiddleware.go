@@ -0,0 +1,103 @@
+package middlewares
+
+import (
+	"api-gateway/metrics"
+	"context"
+	"log/slog"
+	"net/http"
+	"time"
+
+	"github.com/gorilla/mux"
+)
+
+type accessLogKey struct{}
+
+type accessLog struct {
+	userId int64
+}
+
+type statusRecorder struct {
+	http.ResponseWriter
+	status int
+}
+
+func (r *statusRecorder) WriteHeader(status int) {
+	if r.status == 0 {
+		r.status = status
+	}
+
+	r.ResponseWriter.WriteHeader(status)
+}
+
+func (r *statusRecorder) Write(body []byte) (int, error) {
+	if r.status == 0 {
+		r.status = http.StatusOK
+	}
+
+	return r.ResponseWriter.Write(body)
+}
+
+func (r *statusRecorder) Flush() {
+	if flusher, ok := r.ResponseWriter.(http.Flusher); ok {
+		flusher.Flush()
+	}
+}
+
+func (r *statusRecorder) Unwrap() http.ResponseWriter {
+	return r.ResponseWriter
+}
+
+// AccessLogMiddleware logs one line per request and records its latency. The
+// route template is looked up on the router up front, the user id is filled
+// in by AuthMiddleware.
+func AccessLogMiddleware(router *mux.Router) http.Handler {
+	return http.HandlerFunc(func(respWriter http.ResponseWriter, req *http.Request) {
+		start := time.Now()
+		route := "unmatched"
+
+		var match mux.RouteMatch
+
+		if router.Match(req, &match) && match.Route != nil {
+			if template, err := match.Route.GetPathTemplate(); err == nil {
+				route = template
+			}
+		}
+
+		entry := &accessLog{}
+		recorder := &statusRecorder{ResponseWriter: respWriter}
+
+		router.ServeHTTP(recorder, req.WithContext(context.WithValue(req.Context(), accessLogKey{}, entry)))
+
+		if recorder.status == 0 {
+			recorder.status = http.StatusOK
+		}
+
+		attrs := []slog.Attr{
+			slog.String("method", req.Method),
+			slog.String("route", route),
+			slog.Int("status", recorder.status),
+			slog.Duration("latency", time.Since(start)),
+		}
+
+		if entry.userId != 0 {
+			attrs = append(attrs, slog.Int64("user_id", entry.userId))
+		}
+
+		level := slog.LevelInfo
+
+		if recorder.status >= http.StatusInternalServerError {
+			level = slog.LevelError
+		} else if recorder.status >= http.StatusBadRequest {
+			level = slog.LevelWarn
+		}
+
+		slog.LogAttrs(req.Context(), level, "http request", attrs...)
+		metrics.ObserveHTTPRequest(req.Method, route, recorder.status, time.Since(start))
+	})
+}
+
+func recordUserId(ctx context.Context, userId int64) {
+	if entry, ok := ctx.Value(accessLogKey{}).(*accessLog); ok {
+		entry.userId = userId
+	}
+}
@@ -13,7 +13,7 @@ func AdminMiddleware(next http.HandlerFunc) http.HandlerFunc {
 
 		if userType != proto.UserType_ADMIN.String() {
 			errMessage := dto.Error{Status: http.StatusForbidden, Message: "user does not have admin priviledges to access this resource"}
-			respWriter.WriteHeader(errMessage.Status)
+			errMessage.WriteHeader(respWriter)
 			json.NewEncoder(respWriter).Encode(errMessage)
 			return
 		}
@@ -20,11 +20,15 @@ const (
 
 func AuthMiddleware(next http.HandlerFunc) http.HandlerFunc {
 	return http.HandlerFunc(func(respWriter http.ResponseWriter, req *http.Request) {
+		if !authenticationAllowed(respWriter, req) {
+			return
+		}
+
 		header := req.Header.Get("Authorization")
 
 		if header == "" || !strings.HasPrefix(header, "Bearer ") {
 			errMessage := dto.Error{Status: http.StatusBadRequest, Message: "authentication token missing from the request"}
-			respWriter.WriteHeader(errMessage.Status)
+			errMessage.WriteHeader(respWriter)
 			json.NewEncoder(respWriter).Encode(errMessage)
 			return
 		}
@@ -36,8 +40,8 @@ func AuthMiddleware(next http.HandlerFunc) http.HandlerFunc {
 		})
 
 		if err != nil {
-			errMessage := dto.Error{Status: http.StatusBadRequest, Message: strings.Replace(err.Error(), "rpc error: code = Unknown desc = ", "", 1)}
-			respWriter.WriteHeader(errMessage.Status)
+			errMessage := dto.RPCError(err)
+			errMessage.WriteHeader(respWriter)
 			json.NewEncoder(respWriter).Encode(errMessage)
 			return
 		}
@@ -47,7 +51,8 @@ func AuthMiddleware(next http.HandlerFunc) http.HandlerFunc {
 		ctx = context.WithValue(ctx, USER_TYPE, user.UserType.String())
 
 		req = req.WithContext(ctx)
+		recordUserId(ctx, user.Id)
 
-		next.ServeHTTP(respWriter, req)
+		RateLimitMiddleware(next).ServeHTTP(respWriter, req)
 	})
 }
@@ -0,0 +1,44 @@
+package middlewares
+
+import (
+	"api-gateway/dto"
+	"encoding/json"
+	"net/http"
+	"strconv"
+
+	"google.golang.org/grpc/metadata"
+)
+
+const (
+	IdempotencyKeyHeader   = "Idempotency-Key"
+	IdempotencyKeyMetadata = "idempotency-key"
+	UserIdMetadata         = "user-id"
+)
+
+func IdempotencyMiddleware(next http.HandlerFunc) http.HandlerFunc {
+	return http.HandlerFunc(func(respWriter http.ResponseWriter, req *http.Request) {
+		key := req.Header.Get(IdempotencyKeyHeader)
+
+		if key == "" {
+			next.ServeHTTP(respWriter, req)
+			return
+		}
+
+		if len(key) > 255 {
+			errMessage := dto.Error{Status: http.StatusBadRequest, Message: "idempotency key cannot be longer than 255 characters"}
+			errMessage.WriteHeader(respWriter)
+			json.NewEncoder(respWriter).Encode(errMessage)
+			return
+		}
+
+		ctx := metadata.AppendToOutgoingContext(req.Context(), IdempotencyKeyMetadata, key)
+
+		// The product service scopes keys by user, so two users sending the
+		// same key never replay each other's responses.
+		if userId, ok := req.Context().Value(USER_ID).(int64); ok {
+			ctx = metadata.AppendToOutgoingContext(ctx, UserIdMetadata, strconv.FormatInt(userId, 10))
+		}
+
+		next.ServeHTTP(respWriter, req.WithContext(ctx))
+	})
+}
@@ -0,0 +1,35 @@
+package middlewares
+
+import (
+	"api-gateway/dto"
+	"crypto/subtle"
+	"encoding/json"
+	"net/http"
+)
+
+const (
+	InternalTokenHeader = "X-Internal-Token"
+)
+
+var (
+	internalToken string
+)
+
+func SetInternalToken(token string) {
+	internalToken = token
+}
+
+func InternalMiddleware(next http.HandlerFunc) http.HandlerFunc {
+	return http.HandlerFunc(func(respWriter http.ResponseWriter, req *http.Request) {
+		token := req.Header.Get(InternalTokenHeader)
+
+		if len(internalToken) == 0 || subtle.ConstantTimeCompare([]byte(token), []byte(internalToken)) != 1 {
+			errMessage := dto.Error{Status: http.StatusForbidden, Message: "this resource is only available to internal services"}
+			errMessage.WriteHeader(respWriter)
+			json.NewEncoder(respWriter).Encode(errMessage)
+			return
+		}
+
+		next.ServeHTTP(respWriter, req)
+	})
+}
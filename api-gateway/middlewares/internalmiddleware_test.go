@@ -0,0 +1,42 @@
+package middlewares
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func serveInternal(token string) int {
+	handler := InternalMiddleware(func(respWriter http.ResponseWriter, req *http.Request) {
+		respWriter.WriteHeader(http.StatusNoContent)
+	})
+
+	req := httptest.NewRequest(http.MethodPut, "/internal/products/remove-products", nil)
+
+	if len(token) != 0 {
+		req.Header.Set(InternalTokenHeader, token)
+	}
+
+	recorder := httptest.NewRecorder()
+	handler.ServeHTTP(recorder, req)
+
+	return recorder.Code
+}
+
+func TestInternalMiddlewareShouldOnlyAcceptTheConfiguredToken(t *testing.T) {
+	SetInternalToken("secret")
+	t.Cleanup(func() { SetInternalToken("") })
+
+	assert.Equal(t, http.StatusNoContent, serveInternal("secret"))
+	assert.Equal(t, http.StatusForbidden, serveInternal("secreT"))
+	assert.Equal(t, http.StatusForbidden, serveInternal(""))
+}
+
+func TestInternalMiddlewareShouldRejectEverythingWithoutAToken(t *testing.T) {
+	SetInternalToken("")
+
+	assert.Equal(t, http.StatusForbidden, serveInternal(""))
+	assert.Equal(t, http.StatusForbidden, serveInternal("anything"))
+}
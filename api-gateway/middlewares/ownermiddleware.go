@@ -0,0 +1,40 @@
+package middlewares
+
+import (
+	"api-gateway/dto"
+	proto "api-gateway/proto/auth"
+	"encoding/json"
+	"net/http"
+	"strconv"
+
+	"github.com/gorilla/mux"
+)
+
+func OwnerMiddleware(param string) func(http.HandlerFunc) http.HandlerFunc {
+	return func(next http.HandlerFunc) http.HandlerFunc {
+		return http.HandlerFunc(func(respWriter http.ResponseWriter, req *http.Request) {
+			if req.Context().Value(USER_TYPE).(string) == proto.UserType_ADMIN.String() {
+				next.ServeHTTP(respWriter, req)
+				return
+			}
+
+			ownerId, err := strconv.ParseInt(mux.Vars(req)[param], 10, 64)
+
+			if err != nil {
+				errMessage := dto.Error{Status: http.StatusBadRequest, Message: "invalid " + param}
+				errMessage.WriteHeader(respWriter)
+				json.NewEncoder(respWriter).Encode(errMessage)
+				return
+			}
+
+			if ownerId != req.Context().Value(USER_ID).(int64) {
+				errMessage := dto.Error{Status: http.StatusForbidden, Message: "user does not have access to this resource"}
+				errMessage.WriteHeader(respWriter)
+				json.NewEncoder(respWriter).Encode(errMessage)
+				return
+			}
+
+			next.ServeHTTP(respWriter, req)
+		})
+	}
+}
@@ -0,0 +1,111 @@
+package middlewares
+
+import (
+	"api-gateway/config"
+	"api-gateway/dto"
+	"api-gateway/ratelimit"
+	"encoding/json"
+	"fmt"
+	"log/slog"
+	"math"
+	"net"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/gorilla/mux"
+)
+
+var (
+	limiter           *ratelimit.Limiter
+	trustForwardedFor bool
+)
+
+func SetRateLimiter(rateLimiter *ratelimit.Limiter, forwardedFor bool) {
+	limiter = rateLimiter
+	trustForwardedFor = forwardedFor
+}
+
+// RateLimitMiddleware throttles authenticated requests per user and anonymous
+// requests per client IP. AuthMiddleware applies it once the user is known.
+func RateLimitMiddleware(next http.HandlerFunc) http.HandlerFunc {
+	return http.HandlerFunc(func(respWriter http.ResponseWriter, req *http.Request) {
+		if limiter == nil {
+			next.ServeHTTP(respWriter, req)
+			return
+		}
+
+		role := config.AnonymousRole
+		identity := "ip:" + clientIP(req)
+
+		if userId, ok := req.Context().Value(USER_ID).(int64); ok {
+			role = req.Context().Value(USER_TYPE).(string)
+			identity = fmt.Sprintf("user:%d", userId)
+		}
+
+		route := req.URL.Path
+
+		if current := mux.CurrentRoute(req); current != nil {
+			if template, err := current.GetPathTemplate(); err == nil {
+				route = template
+			}
+		}
+
+		res, err := limiter.Take(req.Context(), route, role, identity)
+
+		if !allowRequest(respWriter, req, res, err) {
+			return
+		}
+
+		next.ServeHTTP(respWriter, req)
+	})
+}
+
+// authenticationAllowed charges the client IP before AuthMiddleware calls the
+// auth service, so invalid tokens cannot be sent to it without limit.
+func authenticationAllowed(respWriter http.ResponseWriter, req *http.Request) bool {
+	if limiter == nil {
+		return true
+	}
+
+	res, err := limiter.TakeAuthentication(req.Context(), "ip:"+clientIP(req))
+
+	return allowRequest(respWriter, req, res, err)
+}
+
+func allowRequest(respWriter http.ResponseWriter, req *http.Request, res ratelimit.Result, err error) bool {
+	if err != nil {
+		slog.ErrorContext(req.Context(), "rate limit store failed, letting the request through", "error", err)
+		return true
+	}
+
+	respWriter.Header().Set("X-RateLimit-Limit", strconv.Itoa(res.Limit))
+	respWriter.Header().Set("X-RateLimit-Remaining", strconv.Itoa(res.Remaining))
+	respWriter.Header().Set("X-RateLimit-Reset", strconv.FormatInt(int64(math.Ceil(res.Reset.Seconds())), 10))
+
+	if !res.Allowed {
+		respWriter.Header().Set("Content-Type", "application/json")
+		errMessage := dto.Error{Status: http.StatusTooManyRequests, Message: "rate limit exceeded, try again later", RetryAfter: res.RetryAfter}
+		errMessage.WriteHeader(respWriter)
+		json.NewEncoder(respWriter).Encode(errMessage)
+		return false
+	}
+
+	return true
+}
+
+func clientIP(req *http.Request) string {
+	if trustForwardedFor {
+		if forwarded := req.Header.Get("X-Forwarded-For"); len(forwarded) != 0 {
+			return strings.TrimSpace(strings.Split(forwarded, ",")[0])
+		}
+	}
+
+	host, _, err := net.SplitHostPort(req.RemoteAddr)
+
+	if err != nil {
+		return req.RemoteAddr
+	}
+
+	return host
+}
@@ -0,0 +1,93 @@
+package middlewares
+
+import (
+	"api-gateway/config"
+	"api-gateway/ratelimit"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func useRateLimiter(t *testing.T, limits config.RateLimits, forwardedFor bool) {
+	SetRateLimiter(ratelimit.NewLimiter(ratelimit.NewMemoryStore(), limits), forwardedFor)
+	t.Cleanup(func() { SetRateLimiter(nil, false) })
+}
+
+func serve(handler http.HandlerFunc, forwardedFor string) *httptest.ResponseRecorder {
+	req := httptest.NewRequest(http.MethodGet, "/products", nil)
+
+	if len(forwardedFor) != 0 {
+		req.Header.Set("X-Forwarded-For", forwardedFor)
+	}
+
+	recorder := httptest.NewRecorder()
+	handler.ServeHTTP(recorder, req)
+
+	return recorder
+}
+
+func TestRateLimitMiddlewareShouldSetTheRateLimitHeaders(t *testing.T) {
+	useRateLimiter(t, config.RateLimits{
+		Authentication: config.Limit{Requests: 10, Per: config.Duration(time.Minute)},
+		Default:        config.Limit{Requests: 2, Per: config.Duration(time.Minute)},
+	}, false)
+	handler := RateLimitMiddleware(func(respWriter http.ResponseWriter, req *http.Request) {
+		respWriter.WriteHeader(http.StatusNoContent)
+	})
+
+	res1 := serve(handler, "")
+	serve(handler, "")
+	res3 := serve(handler, "")
+
+	assert.Equal(t, http.StatusNoContent, res1.Code)
+	assert.Equal(t, "2", res1.Header().Get("X-RateLimit-Limit"))
+	assert.Equal(t, "1", res1.Header().Get("X-RateLimit-Remaining"))
+	assert.Equal(t, "30", res1.Header().Get("X-RateLimit-Reset"))
+	assert.Empty(t, res1.Header().Get("Retry-After"))
+	assert.Equal(t, http.StatusTooManyRequests, res3.Code)
+	assert.Equal(t, "0", res3.Header().Get("X-RateLimit-Remaining"))
+	assert.Equal(t, "30", res3.Header().Get("Retry-After"))
+	assert.Equal(t, "application/json", res3.Header().Get("Content-Type"))
+}
+
+func TestRateLimitMiddlewareShouldKeyByForwardedForOnlyWhenTrusted(t *testing.T) {
+	limits := config.RateLimits{
+		Authentication: config.Limit{Requests: 10, Per: config.Duration(time.Minute)},
+		Default:        config.Limit{Requests: 1, Per: config.Duration(time.Minute)},
+	}
+	handler := RateLimitMiddleware(func(respWriter http.ResponseWriter, req *http.Request) {
+		respWriter.WriteHeader(http.StatusNoContent)
+	})
+
+	useRateLimiter(t, limits, false)
+	serve(handler, "203.0.113.1")
+	untrusted := serve(handler, "203.0.113.2")
+
+	useRateLimiter(t, limits, true)
+	serve(handler, "203.0.113.1")
+	trusted := serve(handler, "203.0.113.2, 10.0.0.1")
+
+	assert.Equal(t, http.StatusTooManyRequests, untrusted.Code)
+	assert.Equal(t, http.StatusNoContent, trusted.Code)
+}
+
+func TestAuthMiddlewareShouldLimitClientsBeforeAuthenticating(t *testing.T) {
+	useRateLimiter(t, config.RateLimits{
+		Authentication: config.Limit{Requests: 1, Per: config.Duration(time.Minute)},
+		Default:        config.Limit{Requests: 10, Per: config.Duration(time.Minute)},
+	}, false)
+	handler := AuthMiddleware(func(respWriter http.ResponseWriter, req *http.Request) {
+		respWriter.WriteHeader(http.StatusNoContent)
+	})
+
+	res1 := serve(handler, "")
+	res2 := serve(handler, "")
+
+	assert.Equal(t, http.StatusBadRequest, res1.Code)
+	assert.Equal(t, "1", res1.Header().Get("X-RateLimit-Limit"))
+	assert.Equal(t, http.StatusTooManyRequests, res2.Code)
+	assert.Equal(t, "60", res2.Header().Get("Retry-After"))
+}
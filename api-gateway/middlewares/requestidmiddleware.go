@@ -0,0 +1,20 @@
+package middlewares
+
+import (
+	"api-gateway/requestid"
+	"net/http"
+)
+
+func RequestIDMiddleware(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(respWriter http.ResponseWriter, req *http.Request) {
+		id := req.Header.Get(requestid.Header)
+
+		if !requestid.Valid(id) {
+			id = requestid.New()
+		}
+
+		respWriter.Header().Set(requestid.Header, id)
+
+		next.ServeHTTP(respWriter, req.WithContext(requestid.NewContext(req.Context(), id)))
+	})
+}
@@ -0,0 +1,29 @@
+package middlewares
+
+import (
+	"api-gateway/dto"
+	proto "api-gateway/proto/auth"
+	"encoding/json"
+	"net/http"
+)
+
+func RequireRoles(roles ...proto.UserType) func(http.HandlerFunc) http.HandlerFunc {
+	allowed := make(map[string]bool, len(roles))
+
+	for _, role := range roles {
+		allowed[role.String()] = true
+	}
+
+	return func(next http.HandlerFunc) http.HandlerFunc {
+		return AuthMiddleware(http.HandlerFunc(func(respWriter http.ResponseWriter, req *http.Request) {
+			if len(allowed) != 0 && !allowed[req.Context().Value(USER_TYPE).(string)] {
+				errMessage := dto.Error{Status: http.StatusForbidden, Message: "user does not have the role required to access this resource"}
+				errMessage.WriteHeader(respWriter)
+				json.NewEncoder(respWriter).Encode(errMessage)
+				return
+			}
+
+			next.ServeHTTP(respWriter, req)
+		}))
+	}
+}
@@ -0,0 +1,67 @@
+package ratelimit
+
+import (
+	"api-gateway/config"
+	"context"
+)
+
+type Limiter struct {
+	store          Store
+	rules          []config.RateLimitRule
+	fallback       config.Limit
+	authentication config.Limit
+}
+
+func NewLimiter(store Store, limits config.RateLimits) *Limiter {
+	return &Limiter{store: store, rules: limits.Rules, fallback: limits.Default, authentication: limits.Authentication}
+}
+
+// TakeAuthentication charges one request to the identity's authentication
+// bucket, which is checked before a token is sent to the auth service.
+func (l *Limiter) TakeAuthentication(ctx context.Context, identity string) (Result, error) {
+	return l.store.Take(ctx, "authentication|"+identity, l.authentication)
+}
+
+// Take charges one request to the bucket of the given identity. The most
+// specific rule wins: route and role, then route, then role, then the default.
+func (l *Limiter) Take(ctx context.Context, route string, role string, identity string) (Result, error) {
+	scope, limit := l.match(route, role)
+
+	return l.store.Take(ctx, scope+"|"+identity, limit)
+}
+
+func (l *Limiter) match(route string, role string) (string, config.Limit) {
+	var best *config.RateLimitRule
+	bestScore := 0
+
+	for idx := range l.rules {
+		rule := &l.rules[idx]
+		score := 0
+
+		if len(rule.Route) != 0 {
+			if rule.Route != route {
+				continue
+			}
+
+			score += 2
+		}
+
+		if len(rule.Role) != 0 {
+			if rule.Role != role {
+				continue
+			}
+
+			score++
+		}
+
+		if score > bestScore {
+			best, bestScore = rule, score
+		}
+	}
+
+	if best == nil {
+		return "default", l.fallback
+	}
+
+	return best.Route + "|" + best.Role, best.Limit
+}
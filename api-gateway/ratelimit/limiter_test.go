@@ -0,0 +1,75 @@
+package ratelimit
+
+import (
+	"api-gateway/config"
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type recordingStore struct {
+	keys   []string
+	limits []config.Limit
+}
+
+func (s *recordingStore) Take(ctx context.Context, key string, limit config.Limit) (Result, error) {
+	s.keys = append(s.keys, key)
+	s.limits = append(s.limits, limit)
+
+	return Result{Allowed: true, Limit: limit.Requests}, nil
+}
+
+func perMinute(requests int) config.Limit {
+	return config.Limit{Requests: requests, Per: config.Duration(time.Minute)}
+}
+
+func TestLimiterShouldPreferTheMostSpecificRule(t *testing.T) {
+	store := &recordingStore{}
+	limiter := NewLimiter(store, config.RateLimits{
+		Authentication: perMinute(1000),
+		Default:        perMinute(120),
+		Rules: []config.RateLimitRule{
+			{Role: "ADMIN", Limit: perMinute(600)},
+			{Route: "/orders", Limit: perMinute(20)},
+			{Route: "/orders", Role: "REGULAR", Limit: perMinute(10)},
+			{Route: "/login", Limit: perMinute(5)},
+		},
+	})
+
+	tests := []struct {
+		route string
+		role  string
+		key   string
+		limit config.Limit
+	}{
+		{"/orders", "REGULAR", "/orders|REGULAR|user:1", perMinute(10)},
+		{"/orders", "ADMIN", "/orders||user:1", perMinute(20)},
+		{"/products", "ADMIN", "|ADMIN|user:1", perMinute(600)},
+		{"/login", config.AnonymousRole, "/login||user:1", perMinute(5)},
+		{"/products", "REGULAR", "default|user:1", perMinute(120)},
+	}
+
+	for _, test := range tests {
+		_, err := limiter.Take(context.Background(), test.route, test.role, "user:1")
+
+		assert.NoError(t, err)
+	}
+
+	for idx, test := range tests {
+		assert.Equal(t, test.key, store.keys[idx], test.route+" "+test.role)
+		assert.Equal(t, test.limit, store.limits[idx], test.route+" "+test.role)
+	}
+}
+
+func TestLimiterShouldChargeAuthenticationToItsOwnBucket(t *testing.T) {
+	store := &recordingStore{}
+	limiter := NewLimiter(store, config.RateLimits{Authentication: perMinute(1000), Default: perMinute(120)})
+
+	_, err := limiter.TakeAuthentication(context.Background(), "ip:10.0.0.1")
+
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"authentication|ip:10.0.0.1"}, store.keys)
+	assert.Equal(t, []config.Limit{perMinute(1000)}, store.limits)
+}
@@ -0,0 +1,82 @@
+package ratelimit
+
+import (
+	"api-gateway/config"
+	"context"
+	"math"
+	"sync"
+	"time"
+)
+
+type bucket struct {
+	tokens  float64
+	updated time.Time
+	fullAt  time.Time
+}
+
+type MemoryStore struct {
+	now       func() time.Time
+	mutex     sync.Mutex
+	buckets   map[string]*bucket
+	lastSweep time.Time
+}
+
+func NewMemoryStore() *MemoryStore {
+	return &MemoryStore{now: time.Now, buckets: make(map[string]*bucket), lastSweep: time.Now()}
+}
+
+func (s *MemoryStore) Take(ctx context.Context, key string, limit config.Limit) (Result, error) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	now := s.now()
+	capacity := float64(limit.Requests)
+	rate := capacity / time.Duration(limit.Per).Seconds()
+
+	s.sweep(now)
+
+	current, ok := s.buckets[key]
+
+	if !ok {
+		current = &bucket{tokens: capacity, updated: now}
+		s.buckets[key] = current
+	}
+
+	current.tokens = math.Min(capacity, current.tokens+now.Sub(current.updated).Seconds()*rate)
+	current.updated = now
+
+	res := Result{Limit: limit.Requests}
+
+	if current.tokens >= 1 {
+		current.tokens--
+		res.Allowed = true
+	} else {
+		res.RetryAfter = seconds((1 - current.tokens) / rate)
+	}
+
+	res.Remaining = int(current.tokens)
+	res.Reset = seconds((capacity - current.tokens) / rate)
+	current.fullAt = now.Add(res.Reset)
+
+	return res, nil
+}
+
+// sweep drops buckets that have refilled completely, since a fresh bucket
+// behaves the same way. It runs at most once a minute.
+func (s *MemoryStore) sweep(now time.Time) {
+	if now.Sub(s.lastSweep) < time.Minute {
+		return
+	}
+
+	for key, current := range s.buckets {
+		if !now.Before(current.fullAt) {
+			delete(s.buckets, key)
+		}
+	}
+
+	s.lastSweep = now
+}
+
+func seconds(value float64) time.Duration {
+	return time.Duration(value * float64(time.Second))
+}
@@ -0,0 +1,87 @@
+package ratelimit
+
+import (
+	"api-gateway/config"
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func newTestStore() (*MemoryStore, *time.Time) {
+	now := time.Unix(1700000000, 0)
+	store := NewMemoryStore()
+	store.now = func() time.Time { return now }
+	store.lastSweep = now
+
+	return store, &now
+}
+
+func TestMemoryStoreShouldRejectOnceTheBucketIsEmpty(t *testing.T) {
+	store, _ := newTestStore()
+	limit := config.Limit{Requests: 2, Per: config.Duration(time.Second)}
+
+	res1, err1 := store.Take(context.Background(), "ip:1", limit)
+	res2, err2 := store.Take(context.Background(), "ip:1", limit)
+	res3, err3 := store.Take(context.Background(), "ip:1", limit)
+	res4, err4 := store.Take(context.Background(), "ip:2", limit)
+
+	assert.NoError(t, err1)
+	assert.NoError(t, err2)
+	assert.NoError(t, err3)
+	assert.NoError(t, err4)
+	assert.Equal(t, Result{Allowed: true, Limit: 2, Remaining: 1, Reset: 500 * time.Millisecond}, res1)
+	assert.Equal(t, Result{Allowed: true, Limit: 2, Remaining: 0, Reset: time.Second}, res2)
+	assert.Equal(t, Result{Allowed: false, Limit: 2, Remaining: 0, Reset: time.Second, RetryAfter: 500 * time.Millisecond}, res3)
+	assert.True(t, res4.Allowed)
+}
+
+func TestMemoryStoreShouldRefillTheBucketOverTime(t *testing.T) {
+	store, now := newTestStore()
+	limit := config.Limit{Requests: 4, Per: config.Duration(time.Minute)}
+
+	for i := 0; i < 4; i++ {
+		store.Take(context.Background(), "user:1", limit)
+	}
+
+	*now = now.Add(10 * time.Second)
+	res1, _ := store.Take(context.Background(), "user:1", limit)
+
+	*now = now.Add(5 * time.Second)
+	res2, _ := store.Take(context.Background(), "user:1", limit)
+
+	*now = now.Add(time.Hour)
+	res3, _ := store.Take(context.Background(), "user:1", limit)
+
+	assert.False(t, res1.Allowed)
+	assert.Equal(t, 5*time.Second, res1.RetryAfter)
+	assert.True(t, res2.Allowed)
+	assert.Equal(t, 0, res2.Remaining)
+	assert.Equal(t, time.Minute, res2.Reset)
+	assert.True(t, res3.Allowed)
+	assert.Equal(t, 3, res3.Remaining)
+}
+
+func TestMemoryStoreShouldSweepFullBucketsAtMostOnceAMinute(t *testing.T) {
+	store, now := newTestStore()
+	limit := config.Limit{Requests: 1, Per: config.Duration(time.Minute)}
+
+	store.Take(context.Background(), "ip:1", limit)
+
+	*now = now.Add(30 * time.Second)
+	store.Take(context.Background(), "ip:2", limit)
+
+	*now = now.Add(29 * time.Second)
+	store.Take(context.Background(), "ip:3", limit)
+
+	assert.Len(t, store.buckets, 3)
+
+	*now = now.Add(2 * time.Second)
+	store.Take(context.Background(), "ip:4", limit)
+
+	assert.Len(t, store.buckets, 3)
+	assert.NotContains(t, store.buckets, "ip:1")
+	assert.Contains(t, store.buckets, "ip:2")
+	assert.Equal(t, *now, store.lastSweep)
+}
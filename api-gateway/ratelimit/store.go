@@ -0,0 +1,19 @@
+package ratelimit
+
+import (
+	"api-gateway/config"
+	"context"
+	"time"
+)
+
+type Result struct {
+	Allowed    bool
+	Limit      int
+	Remaining  int
+	Reset      time.Duration
+	RetryAfter time.Duration
+}
+
+type Store interface {
+	Take(ctx context.Context, key string, limit config.Limit) (Result, error)
+}
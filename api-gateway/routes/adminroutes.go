@@ -0,0 +1,17 @@
+package routes
+
+import (
+	"api-gateway/handlers/adminhandler"
+	"api-gateway/handlers/authhandler"
+	"api-gateway/middlewares"
+	proto "api-gateway/proto/auth"
+
+	"github.com/gorilla/mux"
+)
+
+func RegisterAdminRoutes(router *mux.Router) {
+	admin := middlewares.RequireRoles(proto.UserType_ADMIN)
+
+	router.HandleFunc("/admin/breakers", admin(adminhandler.GetBreakers)).Methods("GET")
+	router.HandleFunc("/admin/users", admin(authhandler.CreateUser)).Methods("POST")
+}
@@ -2,11 +2,12 @@ package routes
 
 import (
 	"api-gateway/handlers/authhandler"
+	"api-gateway/middlewares"
 
 	"github.com/gorilla/mux"
 )
 
 func RegisterAuthRoutes(router *mux.Router) {
-	router.HandleFunc("/register", authhandler.RegisterUser).Methods("POST")
-	router.HandleFunc("/login", authhandler.LoginUser).Methods("POST")
+	router.HandleFunc("/register", middlewares.RateLimitMiddleware(authhandler.RegisterUser)).Methods("POST")
+	router.HandleFunc("/login", middlewares.RateLimitMiddleware(authhandler.LoginUser)).Methods("POST")
 }
@@ -0,0 +1,24 @@
+package routes
+
+import (
+	"api-gateway/handlers/categoryhandler"
+	"api-gateway/handlers/producthandler"
+	"api-gateway/middlewares"
+	proto "api-gateway/proto/auth"
+
+	"github.com/gorilla/mux"
+)
+
+func RegisterCategoryRoutes(router *mux.Router) {
+	authenticated := middlewares.RequireRoles()
+	admin := middlewares.RequireRoles(proto.UserType_ADMIN)
+
+	router.HandleFunc("/categories", authenticated(categoryhandler.GetAllCategories)).Methods("GET")
+	router.HandleFunc("/categories", admin(categoryhandler.CreateCategory)).Methods("POST")
+	router.HandleFunc("/categories/{id}", authenticated(categoryhandler.GetCategory)).Methods("GET")
+	router.HandleFunc("/categories/{id}", admin(categoryhandler.UpdateCategory)).Methods("PATCH")
+	router.HandleFunc("/categories/{id}", admin(categoryhandler.DeleteCategory)).Methods("DELETE")
+	router.HandleFunc("/categories/{id}/products", authenticated(producthandler.GetAllProductsInCategory)).Methods("GET")
+	router.HandleFunc("/categories/{id}/products/{productId}", admin(categoryhandler.AddProductToCategory)).Methods("PUT")
+	router.HandleFunc("/categories/{id}/products/{productId}", admin(categoryhandler.RemoveProductFromCategory)).Methods("DELETE")
+}
@@ -0,0 +1,11 @@
+package routes
+
+import (
+	"api-gateway/handlers/healthhandler"
+
+	"github.com/gorilla/mux"
+)
+
+func RegisterHealthRoutes(router *mux.Router) {
+	router.HandleFunc("/readyz", healthhandler.Readyz).Methods("GET")
+}
@@ -9,5 +9,6 @@ import (
 
 func RegisterOrderRoutes(router *mux.Router) {
 	router.HandleFunc("/orders", middlewares.AuthMiddleware(orderhandler.CreateOrder)).Methods("POST")
-	router.HandleFunc("/orders/user/{userId}", middlewares.AuthMiddleware(orderhandler.GetAllOrdersByUserId)).Methods("GET")
+	router.HandleFunc("/orders/me", middlewares.AuthMiddleware(orderhandler.GetMyOrders)).Methods("GET")
+	router.HandleFunc("/orders/user/{userId}", middlewares.AuthMiddleware(middlewares.OwnerMiddleware("userId")(orderhandler.GetAllOrdersByUserId))).Methods("GET")
 }
@@ -1,17 +1,47 @@
 package routes
 
 import (
+	"api-gateway/config"
 	"api-gateway/handlers/producthandler"
 	"api-gateway/middlewares"
+	proto "api-gateway/proto/auth"
 
 	"github.com/gorilla/mux"
 )
 
-func RegisterProductRoutes(router *mux.Router) {
-	router.HandleFunc("/products", middlewares.AuthMiddleware(producthandler.GetAllProducts)).Methods("GET")
-	router.HandleFunc("/products", middlewares.AuthMiddleware(middlewares.AdminMiddleware(producthandler.CreateProduct))).Methods("POST")
-	router.HandleFunc("/products/{id}", middlewares.AuthMiddleware(producthandler.GetProduct)).Methods("GET")
-	router.HandleFunc("/products/{id}", middlewares.AuthMiddleware(middlewares.AdminMiddleware(producthandler.DeleteProduct))).Methods("DELETE")
-	router.HandleFunc("/products/add-products", middlewares.AuthMiddleware(middlewares.AdminMiddleware(producthandler.AddProducts))).Methods("PUT")
-	router.HandleFunc("/products/remove-products", middlewares.AuthMiddleware(producthandler.RemoveProducts)).Methods("PUT")
+func RegisterProductRoutes(router *mux.Router, features config.Features) {
+	authenticated := middlewares.RequireRoles()
+	admin := middlewares.RequireRoles(proto.UserType_ADMIN)
+	inventory := middlewares.RequireRoles(proto.UserType_ADMIN, proto.UserType_INVENTORY)
+	internal := middlewares.InternalMiddleware
+
+	router.HandleFunc("/products", authenticated(producthandler.GetAllProducts)).Methods("GET")
+	router.HandleFunc("/products", admin(producthandler.CreateProduct)).Methods("POST")
+
+	if features.ImportExport {
+		router.HandleFunc("/products/import", admin(producthandler.ImportProducts)).Methods("POST")
+		router.HandleFunc("/products/export", admin(producthandler.ExportProducts)).Methods("GET")
+	}
+
+	router.HandleFunc("/products/deleted", admin(producthandler.GetDeletedProducts)).Methods("GET")
+
+	if features.InventoryStream {
+		router.HandleFunc("/products/stream", authenticated(producthandler.StreamInventory)).Methods("GET")
+	}
+
+	router.HandleFunc("/products/low-stock", admin(producthandler.GetLowStockProducts)).Methods("GET")
+	router.HandleFunc("/products/{id}", authenticated(producthandler.GetProduct)).Methods("GET")
+	router.HandleFunc("/products/{id}", admin(producthandler.UpdateProductDetails)).Methods("PATCH")
+	router.HandleFunc("/products/{id}", admin(producthandler.DeleteProduct)).Methods("DELETE")
+	router.HandleFunc("/products/add-products", admin(middlewares.IdempotencyMiddleware(producthandler.AddProducts))).Methods("PUT")
+	router.HandleFunc("/products/{id}/restore", admin(producthandler.RestoreProduct)).Methods("POST")
+	router.HandleFunc("/products/{id}/purge", admin(producthandler.PurgeProduct)).Methods("DELETE")
+	router.HandleFunc("/products/{id}/price-changes", admin(producthandler.SchedulePriceChange)).Methods("POST")
+	router.HandleFunc("/products/{id}/price-history", authenticated(producthandler.GetPriceHistory)).Methods("GET")
+	router.HandleFunc("/products/{id}/transfers", admin(middlewares.IdempotencyMiddleware(producthandler.TransferStock))).Methods("POST")
+	router.HandleFunc("/products/remove-products", inventory(middlewares.IdempotencyMiddleware(producthandler.RemoveProducts))).Methods("PUT")
+
+	if features.InternalStockPath {
+		router.HandleFunc("/internal/products/remove-products", internal(middlewares.IdempotencyMiddleware(producthandler.RemoveOrderProducts))).Methods("PUT")
+	}
 }
@@ -0,0 +1,19 @@
+package routes
+
+import (
+	"api-gateway/handlers/promotionhandler"
+	"api-gateway/middlewares"
+	proto "api-gateway/proto/auth"
+
+	"github.com/gorilla/mux"
+)
+
+func RegisterPromotionRoutes(router *mux.Router) {
+	authenticated := middlewares.RequireRoles()
+	admin := middlewares.RequireRoles(proto.UserType_ADMIN)
+
+	router.HandleFunc("/promotions", admin(promotionhandler.GetAllPromotions)).Methods("GET")
+	router.HandleFunc("/promotions", admin(promotionhandler.CreatePromotion)).Methods("POST")
+	router.HandleFunc("/promotions/{id}", admin(promotionhandler.DeletePromotion)).Methods("DELETE")
+	router.HandleFunc("/cart/price", authenticated(promotionhandler.PriceCart)).Methods("POST")
+}
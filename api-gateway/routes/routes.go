@@ -1,15 +1,24 @@
 package routes
 
 import (
+	"api-gateway/config"
+
 	"github.com/gorilla/mux"
 )
 
-func NewRouter() *mux.Router {
+func NewRouter(features config.Features) *mux.Router {
 	router := mux.NewRouter()
 
-	RegisterProductRoutes(router)
+	RegisterProductRoutes(router, features)
+	RegisterCategoryRoutes(router)
+	RegisterSkuRoutes(router)
+	RegisterWarehouseRoutes(router)
+	RegisterPromotionRoutes(router)
 	RegisterOrderRoutes(router)
 	RegisterAuthRoutes(router)
+	RegisterAdminRoutes(router)
+	RegisterHealthRoutes(router)
+	RegisterMetricsRoutes(router)
 
 	return router
 }
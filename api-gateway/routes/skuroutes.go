@@ -0,0 +1,19 @@
+package routes
+
+import (
+	"api-gateway/handlers/skuhandler"
+	"api-gateway/middlewares"
+	proto "api-gateway/proto/auth"
+
+	"github.com/gorilla/mux"
+)
+
+func RegisterSkuRoutes(router *mux.Router) {
+	authenticated := middlewares.RequireRoles()
+	admin := middlewares.RequireRoles(proto.UserType_ADMIN)
+
+	router.HandleFunc("/products/{id}/skus", admin(skuhandler.CreateSku)).Methods("POST")
+	router.HandleFunc("/skus/{id}", authenticated(skuhandler.GetSku)).Methods("GET")
+	router.HandleFunc("/skus/{id}", admin(skuhandler.UpdateSku)).Methods("PATCH")
+	router.HandleFunc("/skus/{id}", admin(skuhandler.DeleteSku)).Methods("DELETE")
+}
@@ -0,0 +1,18 @@
+package routes
+
+import (
+	"api-gateway/handlers/warehousehandler"
+	"api-gateway/middlewares"
+	proto "api-gateway/proto/auth"
+
+	"github.com/gorilla/mux"
+)
+
+func RegisterWarehouseRoutes(router *mux.Router) {
+	admin := middlewares.RequireRoles(proto.UserType_ADMIN)
+
+	router.HandleFunc("/warehouses", admin(warehousehandler.GetAllWarehouses)).Methods("GET")
+	router.HandleFunc("/warehouses", admin(warehousehandler.CreateWarehouse)).Methods("POST")
+	router.HandleFunc("/warehouses/{id}", admin(warehousehandler.GetWarehouse)).Methods("GET")
+	router.HandleFunc("/warehouses/{id}", admin(warehousehandler.DeleteWarehouse)).Methods("DELETE")
+}
@@ -0,0 +1,150 @@
+package config
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net"
+	"os"
+	"strconv"
+)
+
+var (
+	logLevels = map[string]bool{"debug": true, "info": true, "warn": true, "error": true}
+)
+
+type Database struct {
+	Host     string `json:"host"`
+	Port     int    `json:"port"`
+	User     string `json:"user"`
+	Password string `json:"password"`
+	Name     string `json:"name"`
+	SSLMode  string `json:"sslMode"`
+}
+
+func (database Database) DSN() string {
+	return fmt.Sprintf("host=%s port=%d user=%s password=%s dbname=%s sslmode=%s", database.Host, database.Port, database.User, database.Password, database.Name, database.SSLMode)
+}
+
+type Log struct {
+	Level  string `json:"level"`
+	Format string `json:"format"`
+}
+
+type Config struct {
+	MetricsAddress string   `json:"metricsAddress"`
+	ListenAddress  string   `json:"listenAddress"`
+	Database       Database `json:"database"`
+	Log            Log      `json:"log"`
+}
+
+func Default() Config {
+	return Config{
+		ListenAddress:  ":9003",
+		MetricsAddress: ":9103",
+		Database:       Database{Host: "localhost", Port: 5432, SSLMode: "disable"},
+		Log:            Log{Level: "info", Format: "text"},
+	}
+}
+
+func Load(path string) (*Config, error) {
+	cfg := Default()
+
+	if len(path) != 0 {
+		file, err := os.Open(path)
+
+		if err != nil {
+			return nil, err
+		}
+		defer file.Close()
+
+		decoder := json.NewDecoder(file)
+		decoder.DisallowUnknownFields()
+
+		if err := decoder.Decode(&cfg); err != nil {
+			return nil, fmt.Errorf("invalid config file %s: %w", path, err)
+		}
+	}
+
+	if err := cfg.applyEnv(); err != nil {
+		return nil, err
+	}
+
+	if err := cfg.Validate(); err != nil {
+		return nil, err
+	}
+
+	return &cfg, nil
+}
+
+func (cfg *Config) applyEnv() error {
+	setString(&cfg.ListenAddress, "LISTEN_ADDRESS")
+	setString(&cfg.MetricsAddress, "METRICS_ADDRESS")
+	setString(&cfg.Log.Level, "LOG_LEVEL")
+	setString(&cfg.Log.Format, "LOG_FORMAT")
+	setString(&cfg.Database.Host, "DB_HOST")
+	setString(&cfg.Database.User, "DB_USERNAME")
+	setString(&cfg.Database.Password, "DB_PASSWORD")
+	setString(&cfg.Database.Name, "DB_NAME")
+	setString(&cfg.Database.SSLMode, "DB_SSLMODE")
+
+	return setInt(&cfg.Database.Port, "DB_PORT")
+}
+
+func (cfg *Config) Validate() error {
+	if _, _, err := net.SplitHostPort(cfg.ListenAddress); err != nil {
+		return fmt.Errorf("invalid listen address %q: %w", cfg.ListenAddress, err)
+	}
+
+	if len(cfg.MetricsAddress) != 0 {
+		if _, _, err := net.SplitHostPort(cfg.MetricsAddress); err != nil {
+			return fmt.Errorf("invalid metrics address %q: %w", cfg.MetricsAddress, err)
+		}
+	}
+
+	if !logLevels[cfg.Log.Level] {
+		return fmt.Errorf("unknown log level %s", cfg.Log.Level)
+	}
+
+	if cfg.Log.Format != "text" && cfg.Log.Format != "json" {
+		return fmt.Errorf("unknown log format %s, expected text or json", cfg.Log.Format)
+	}
+
+	if len(cfg.Database.Host) == 0 {
+		return errors.New("database host cannot be empty")
+	}
+
+	if cfg.Database.Port <= 0 || cfg.Database.Port > 65535 {
+		return fmt.Errorf("invalid database port %d", cfg.Database.Port)
+	}
+
+	if len(cfg.Database.Name) == 0 {
+		return errors.New("database name cannot be empty")
+	}
+
+	return nil
+}
+
+func setString(field *string, name string) {
+	if value, ok := os.LookupEnv(name); ok {
+		*field = value
+	}
+}
+
+func setInt(field *int, name string) error {
+	value, ok := os.LookupEnv(name)
+
+	if !ok {
+		return nil
+	}
+
+	parsed, err := strconv.Atoi(value)
+
+	if err != nil {
+		return fmt.Errorf("invalid value %q for %s: %w", value, name, err)
+	}
+
+	*field = parsed
+
+	return nil
+}
@@ -0,0 +1,60 @@
+package config
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func writeConfig(t *testing.T, content string) string {
+	path := filepath.Join(t.TempDir(), "config.json")
+	assert.NoError(t, os.WriteFile(path, []byte(content), 0600))
+
+	return path
+}
+
+func TestShouldLoadMergeFileAndEnvironment(t *testing.T) {
+	path := writeConfig(t, `{"listenAddress": ":7003", "database": {"host": "db", "name": "auth"}}`)
+	t.Setenv("DB_PORT", "6543")
+	t.Setenv("DB_USERNAME", "auth")
+
+	cfg, err := Load(path)
+
+	assert.NoError(t, err)
+	assert.Equal(t, ":7003", cfg.ListenAddress)
+	assert.Equal(t, "db", cfg.Database.Host)
+	assert.Equal(t, 6543, cfg.Database.Port)
+	assert.Equal(t, "host=db port=6543 user=auth password= dbname=auth sslmode=disable", cfg.Database.DSN())
+}
+
+func TestShouldLoadThrowAnErrorIfConfigIsInvalid(t *testing.T) {
+	t.Setenv("DB_NAME", "auth")
+
+	_, err1 := Load(writeConfig(t, `{"listenAddres": ":7003"}`))
+
+	t.Setenv("DB_PORT", "not-a-port")
+	_, err2 := Load("")
+
+	t.Setenv("DB_PORT", "70000")
+	_, err3 := Load("")
+
+	assert.Error(t, err1)
+	assert.Error(t, err2)
+	assert.Equal(t, "invalid database port 70000", err3.Error())
+}
+
+func TestShouldLoadValidateTheLogSettings(t *testing.T) {
+	t.Setenv("DB_NAME", "test")
+	t.Setenv("LOG_FORMAT", "json")
+
+	cfg, err1 := Load("")
+
+	t.Setenv("LOG_LEVEL", "verbose")
+	_, err2 := Load("")
+
+	assert.NoError(t, err1)
+	assert.Equal(t, Log{Level: "info", Format: "json"}, cfg.Log)
+	assert.Equal(t, "unknown log level verbose", err2.Error())
+}
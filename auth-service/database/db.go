@@ -1,11 +1,10 @@
 package database
 
 import (
+	"auth-service/config"
 	"auth-service/models"
 	"errors"
-	"fmt"
-	"log"
-	"os"
+	"log/slog"
 
 	"gorm.io/driver/postgres"
 	"gorm.io/gorm"
@@ -15,11 +14,10 @@ var (
 	DB *gorm.DB
 )
 
-func Connect() error {
+func Connect(cfg config.Database) error {
 	var err error
 
-	dsn := fmt.Sprintf("host=localhost port=5432 user=%s password=%s dbname=%s sslmode=disable", os.Getenv("DB_USERNAME"), os.Getenv("DB_PASSWORD"), os.Getenv("DB_NAME"))
-	DB, err = gorm.Open(postgres.Open(dsn), &gorm.Config{})
+	DB, err = gorm.Open(postgres.Open(cfg.DSN()), &gorm.Config{})
 
 	if err != nil {
 		return errors.New("failed to connect to database")
@@ -27,7 +25,7 @@ func Connect() error {
 
 	initModels()
 
-	log.Printf("Database connected...")
+	slog.Info("database connected")
 
 	return nil
 }
@@ -41,12 +39,12 @@ func Close() error {
 
 	sqlDb.Close()
 
-	log.Printf("Database closed...")
+	slog.Info("database closed")
 
 	return nil
 }
 
 func initModels() {
-	log.Printf("Initializing models")
+	slog.Info("initializing models")
 	models.InitUserModel(DB)
 }
@@ -0,0 +1,79 @@
+package logging
+
+import (
+	"context"
+	"crypto/rand"
+	"fmt"
+	"io"
+	"log/slog"
+)
+
+const (
+	RequestIDHeader = "x-request-id"
+)
+
+type requestIDKey struct{}
+
+// contextHandler adds the request id carried by the context to every record,
+// so handlers only need to log with the request context.
+type contextHandler struct {
+	slog.Handler
+}
+
+func (h contextHandler) Handle(ctx context.Context, record slog.Record) error {
+	if id := RequestID(ctx); len(id) != 0 {
+		record.AddAttrs(slog.String("request_id", id))
+	}
+
+	return h.Handler.Handle(ctx, record)
+}
+
+func (h contextHandler) WithAttrs(attrs []slog.Attr) slog.Handler {
+	return contextHandler{h.Handler.WithAttrs(attrs)}
+}
+
+func (h contextHandler) WithGroup(name string) slog.Handler {
+	return contextHandler{h.Handler.WithGroup(name)}
+}
+
+func NewLogger(writer io.Writer, level string, format string) (*slog.Logger, error) {
+	var parsed slog.Level
+
+	if err := parsed.UnmarshalText([]byte(level)); err != nil {
+		return nil, err
+	}
+
+	options := &slog.HandlerOptions{Level: parsed}
+
+	switch format {
+	case "json":
+		return slog.New(contextHandler{slog.NewJSONHandler(writer, options)}), nil
+	case "text":
+		return slog.New(contextHandler{slog.NewTextHandler(writer, options)}), nil
+	default:
+		return nil, fmt.Errorf("unknown log format %s", format)
+	}
+}
+
+func NewRequestID() string {
+	var bytes [16]byte
+
+	if _, err := rand.Read(bytes[:]); err != nil {
+		panic(err)
+	}
+
+	bytes[6] = bytes[6]&0x0f | 0x40
+	bytes[8] = bytes[8]&0x3f | 0x80
+
+	return fmt.Sprintf("%x-%x-%x-%x-%x", bytes[0:4], bytes[4:6], bytes[6:8], bytes[8:10], bytes[10:16])
+}
+
+func WithRequestID(ctx context.Context, id string) context.Context {
+	return context.WithValue(ctx, requestIDKey{}, id)
+}
+
+func RequestID(ctx context.Context) string {
+	id, _ := ctx.Value(requestIDKey{}).(string)
+
+	return id
+}
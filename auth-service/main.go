@@ -1,14 +1,22 @@
 package main
 
 import (
+	"auth-service/config"
 	"auth-service/database"
+	"auth-service/logging"
+	"auth-service/metrics"
+	"auth-service/models"
 	proto "auth-service/proto/auth"
 	"auth-service/server"
 	"log"
+	"log/slog"
 	"net"
+	"os"
+	"time"
 
 	"github.com/joho/godotenv"
 	"google.golang.org/grpc"
+	"google.golang.org/grpc/keepalive"
 )
 
 func loadEnv() {
@@ -20,16 +28,41 @@ func loadEnv() {
 func main() {
 	loadEnv()
 
-	if err := database.Connect(); err != nil {
+	cfg, err := config.Load(os.Getenv("CONFIG_FILE"))
+
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	logger, err := logging.NewLogger(os.Stderr, cfg.Log.Level, cfg.Log.Format)
+
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	slog.SetDefault(logger)
+
+	if err := database.Connect(cfg.Database); err != nil {
 		log.Fatal(err)
 	}
 	defer database.Close()
 
-	grpcServer := grpc.NewServer()
-	proto.RegisterAuthServiceServer(grpcServer, &server.GRPCServer{})
+	grpcServer := grpc.NewServer(
+		grpc.ChainUnaryInterceptor(server.RequestIDInterceptor, server.LoggingInterceptor, server.MetricsInterceptor, server.StatusInterceptor),
+		grpc.KeepaliveEnforcementPolicy(keepalive.EnforcementPolicy{MinTime: 10 * time.Second, PermitWithoutStream: true}),
+	)
+	proto.RegisterAuthServiceServer(grpcServer, server.NewGRPCServer(models.NewGormUserRepository(database.DB)))
+
+	if len(cfg.MetricsAddress) != 0 {
+		go func() {
+			if err := metrics.Serve(cfg.MetricsAddress); err != nil {
+				log.Fatal(err)
+			}
+		}()
+	}
 
-	log.Printf("Server started at port 9003")
-	lis, err := net.Listen("tcp", ":9003")
+	slog.Info("server started", "address", cfg.ListenAddress)
+	lis, err := net.Listen("tcp", cfg.ListenAddress)
 
 	if err != nil {
 		log.Fatal(err)
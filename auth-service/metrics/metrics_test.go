@@ -0,0 +1,31 @@
+package metrics
+
+import (
+	"testing"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/grpc/codes"
+)
+
+func TestShouldObserveRPCCountCallsByMethodAndCode(t *testing.T) {
+	before := testutil.ToFloat64(rpcHandled.WithLabelValues("/AuthService/LoginUser", "Unauthenticated"))
+
+	ObserveRPC("/AuthService/LoginUser", codes.Unauthenticated, 20*time.Millisecond)
+
+	assert.Equal(t, before+1, testutil.ToFloat64(rpcHandled.WithLabelValues("/AuthService/LoginUser", "Unauthenticated")))
+	assert.Equal(t, 1, testutil.CollectAndCount(rpcDuration, "grpc_server_handling_seconds"))
+}
+
+func TestShouldRecordLoginSplitSuccessesAndFailures(t *testing.T) {
+	successes := testutil.ToFloat64(logins.WithLabelValues("success"))
+	failures := testutil.ToFloat64(logins.WithLabelValues("failure"))
+
+	RecordLogin(true)
+	RecordLogin(false)
+	RecordLogin(false)
+
+	assert.Equal(t, successes+1, testutil.ToFloat64(logins.WithLabelValues("success")))
+	assert.Equal(t, failures+2, testutil.ToFloat64(logins.WithLabelValues("failure")))
+}
@@ -0,0 +1,61 @@
+package models
+
+import (
+	"errors"
+	"sync"
+	"time"
+)
+
+type MemoryUserRepository struct {
+	mutex   sync.Mutex
+	nextID  int64
+	byEmail map[string]*User
+}
+
+func NewMemoryUserRepository() *MemoryUserRepository {
+	return &MemoryUserRepository{nextID: 1, byEmail: make(map[string]*User)}
+}
+
+func (repository *MemoryUserRepository) CreateUser(newUser *User) (*User, error) {
+	if newUser == nil {
+		return nil, ErrInvalidUser
+	}
+
+	repository.mutex.Lock()
+	defer repository.mutex.Unlock()
+
+	if _, ok := repository.byEmail[newUser.Email]; ok {
+		return nil, errors.New("error in creating a new user")
+	}
+
+	now := time.Now()
+
+	newUser.ID = repository.nextID
+	newUser.CreatedAt = now
+	newUser.UpdatedAt = now
+
+	stored := *newUser
+	repository.byEmail[newUser.Email] = &stored
+	repository.nextID++
+
+	return newUser, nil
+}
+
+func (repository *MemoryUserRepository) FindUserByEmail(email string) (*User, error) {
+	if len(email) == 0 {
+		return nil, ErrEmptyEmail
+	}
+
+	repository.mutex.Lock()
+	defer repository.mutex.Unlock()
+
+	user, ok := repository.byEmail[email]
+
+	if !ok {
+		return nil, nil
+	}
+
+	found := *user
+
+	return &found, nil
+}
@@ -0,0 +1,11 @@
+package models
+
+type UserRepository interface {
+	CreateUser(newUser *User) (*User, error)
+	FindUserByEmail(email string) (*User, error)
+}
+
+var (
+	_ UserRepository = (*GormUserRepository)(nil)
+	_ UserRepository = (*MemoryUserRepository)(nil)
+)
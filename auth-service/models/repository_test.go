@@ -0,0 +1,95 @@
+package models
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"gorm.io/driver/sqlite"
+	"gorm.io/gorm"
+)
+
+func newGormRepository(t *testing.T) UserRepository {
+	name := strings.NewReplacer("/", "_", " ", "_").Replace(t.Name())
+	db, err := gorm.Open(sqlite.Open(fmt.Sprintf("file:%s?mode=memory&cache=shared", name)), &gorm.Config{})
+	assert.NoError(t, err)
+
+	InitUserModel(db)
+
+	t.Cleanup(func() {
+		sql, _ := db.DB()
+		sql.Close()
+	})
+
+	return NewGormUserRepository(db)
+}
+
+func newMemoryRepository(t *testing.T) UserRepository {
+	return NewMemoryUserRepository()
+}
+
+func TestGormUserRepositoryConformance(t *testing.T) {
+	runUserRepositoryConformance(t, newGormRepository)
+}
+
+func TestMemoryUserRepositoryConformance(t *testing.T) {
+	runUserRepositoryConformance(t, newMemoryRepository)
+}
+
+func runUserRepositoryConformance(t *testing.T, newRepository func(t *testing.T) UserRepository) {
+	cases := map[string]func(t *testing.T, repository UserRepository){
+		"CreateAndFind":   conformCreateAndFind,
+		"UniqueEmails":    conformUniqueEmails,
+		"InvalidRequests": conformInvalidRequests,
+	}
+
+	for name, run := range cases {
+		run := run
+
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+			run(t, newRepository(t))
+		})
+	}
+}
+
+func conformCreateAndFind(t *testing.T, repository UserRepository) {
+	first, err1 := repository.CreateUser(&User{Name: "Ada", Email: "ada@example.com", Password: "hash", UserType: Admin})
+	second, err2 := repository.CreateUser(&User{Name: "Alan", Email: "alan@example.com", Password: "hash", UserType: Regular})
+	user, err3 := repository.FindUserByEmail("alan@example.com")
+	missing, err4 := repository.FindUserByEmail("grace@example.com")
+
+	assert.NoError(t, err1)
+	assert.NoError(t, err2)
+	assert.Equal(t, int64(1), first.ID)
+	assert.Equal(t, int64(2), second.ID)
+	assert.NoError(t, err3)
+	assert.Equal(t, second.ID, user.ID)
+	assert.Equal(t, "Alan", user.Name)
+	assert.Equal(t, Regular, user.UserType)
+	assert.NoError(t, err4)
+	assert.Nil(t, missing)
+}
+
+func conformUniqueEmails(t *testing.T, repository UserRepository) {
+	repository.CreateUser(&User{Name: "Ada", Email: "ada@example.com"})
+
+	user, err := repository.CreateUser(&User{Name: "Ada Lovelace", Email: "ada@example.com"})
+	existing, _ := repository.FindUserByEmail("ada@example.com")
+
+	assert.Error(t, err)
+	assert.Nil(t, user)
+	assert.True(t, strings.HasPrefix(err.Error(), "error in creating a new user"))
+	assert.Equal(t, "Ada", existing.Name)
+}
+
+func conformInvalidRequests(t *testing.T, repository UserRepository) {
+	user, err1 := repository.CreateUser(nil)
+	found, err2 := repository.FindUserByEmail("")
+
+	assert.Nil(t, user)
+	assert.Equal(t, "invalid user", err1.Error())
+	assert.Nil(t, found)
+	assert.Equal(t, "email is empty", err2.Error())
+}
@@ -2,19 +2,23 @@ package models
 
 import (
 	"errors"
+	"fmt"
+	"log/slog"
 
 	"gorm.io/gorm"
 )
 
+var (
+	ErrInvalidUser = errors.New("invalid user")
+	ErrEmptyEmail  = errors.New("email is empty")
+)
+
 type UserType int32
 
 const (
 	Admin UserType = iota
 	Regular
-)
-
-var (
-	db *gorm.DB
+	Inventory
 )
 
 type User struct {
@@ -26,31 +30,39 @@ type User struct {
 	UserType UserType `gorm:"column:user_type"`
 }
 
+type GormUserRepository struct {
+	db *gorm.DB
+}
+
+func NewGormUserRepository(dbInstance *gorm.DB) *GormUserRepository {
+	return &GormUserRepository{db: dbInstance}
+}
+
 func InitUserModel(dbInstance *gorm.DB) {
-	db = dbInstance
-	db.AutoMigrate(&User{})
+	dbInstance.AutoMigrate(&User{})
 }
 
-func CreateUser(newUser *User) (*User, error) {
+func (repository *GormUserRepository) CreateUser(newUser *User) (*User, error) {
 	if newUser == nil {
-		return nil, errors.New("invalid user")
+		return nil, ErrInvalidUser
 	}
 
-	if err := db.Create(newUser).Error; err != nil {
-		return nil, errors.New("error in creating a new user")
+	if err := repository.db.Create(newUser).Error; err != nil {
+		slog.Error("failed to create user", "operation", "create", "entity", "user", "error", err)
+		return nil, fmt.Errorf("error in creating a new user: %w", err)
 	}
 
 	return newUser, nil
 }
 
-func FindUserByEmail(email string) (*User, error) {
+func (repository *GormUserRepository) FindUserByEmail(email string) (*User, error) {
 	var user *User
 
 	if len(email) == 0 {
-		return nil, errors.New("email is empty")
+		return nil, ErrEmptyEmail
 	}
 
-	result := db.Where("email = ?", email).Find(&user)
+	result := repository.db.Where("email = ?", email).Find(&user)
 
 	if result.Error != nil {
 		return nil, result.Error
@@ -8,11 +8,14 @@ import (
 	"gorm.io/gorm"
 )
 
+var users *GormUserRepository
+
 func setupDatabase(t *testing.T) *gorm.DB {
 	db, err := gorm.Open(sqlite.Open("file:yourDbName?mode=memory&cache=shared"), &gorm.Config{})
 	assert.NoError(t, err)
 
 	InitUserModel(db)
+	users = NewGormUserRepository(db)
 
 	return db
 }
@@ -42,7 +45,7 @@ func TestShouldCreateUserWorkCorrectly(t *testing.T) {
 		UserType: Admin,
 	}
 
-	createdUser, err := CreateUser(newUser)
+	createdUser, err := users.CreateUser(newUser)
 
 	assert.NoError(t, err)
 	assert.NotNil(t, createdUser)
@@ -57,7 +60,7 @@ func TestShouldCreateUserThrowAnErrorIfUserIsNil(t *testing.T) {
 	db := setupDatabase(t)
 	defer teardownDatabase(db)
 
-	createdUser, err := CreateUser(nil)
+	createdUser, err := users.CreateUser(nil)
 
 	assert.Error(t, err)
 	assert.Nil(t, createdUser)
@@ -75,8 +78,8 @@ func TestShouldCreateUserThrowAnErrorIfFailedToCreateANewUser(t *testing.T) {
 		UserType: Admin,
 	}
 
-	createdUser1, err1 := CreateUser(newUser)
-	createdUser2, err2 := CreateUser(newUser)
+	createdUser1, err1 := users.CreateUser(newUser)
+	createdUser2, err2 := users.CreateUser(newUser)
 
 	assert.NoError(t, err1)
 	assert.NotNil(t, createdUser1)
@@ -86,7 +89,7 @@ func TestShouldCreateUserThrowAnErrorIfFailedToCreateANewUser(t *testing.T) {
 	assert.Equal(t, newUser.UserType, createdUser1.UserType)
 	assert.Error(t, err2)
 	assert.Nil(t, createdUser2)
-	assert.Equal(t, "error in creating a new user", err2.Error())
+	assert.Equal(t, "error in creating a new user: UNIQUE constraint failed: users.id", err2.Error())
 }
 
 func TestShouldFindUserByEmailWorkCorrectly(t *testing.T) {
@@ -100,9 +103,9 @@ func TestShouldFindUserByEmailWorkCorrectly(t *testing.T) {
 		UserType: Admin,
 	}
 
-	CreateUser(newUser)
+	users.CreateUser(newUser)
 
-	user, err := FindUserByEmail("test@example.com")
+	user, err := users.FindUserByEmail("test@example.com")
 
 	assert.NoError(t, err)
 	assert.NotNil(t, user)
@@ -117,7 +120,7 @@ func TestShouldFindUserByEmailThrowErrorIfEmailIsEmpty(t *testing.T) {
 	db := setupDatabase(t)
 	defer teardownDatabase(db)
 
-	user, err := FindUserByEmail("")
+	user, err := users.FindUserByEmail("")
 
 	assert.Error(t, err)
 	assert.Nil(t, user)
@@ -128,7 +131,7 @@ func TestShouldFindUserByEmailReturnEmptyIfUserDoesNotExist(t *testing.T) {
 	db := setupDatabase(t)
 	defer teardownDatabase(db)
 
-	user, err := FindUserByEmail("test@example.com")
+	user, err := users.FindUserByEmail("test@example.com")
 
 	assert.NoError(t, err)
 	assert.Nil(t, user)
@@ -0,0 +1,52 @@
+package server
+
+import (
+	"context"
+	"log/slog"
+	"time"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+var (
+	serverFailures = map[codes.Code]bool{
+		codes.Unknown:          true,
+		codes.Internal:         true,
+		codes.Unavailable:      true,
+		codes.DataLoss:         true,
+		codes.DeadlineExceeded: true,
+	}
+)
+
+func LoggingInterceptor(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+	start := time.Now()
+
+	response, err := handler(ctx, req)
+	logCall(ctx, info.FullMethod, start, err)
+
+	return response, err
+}
+
+func logCall(ctx context.Context, method string, start time.Time, err error) {
+	code := status.Code(err)
+	attrs := []slog.Attr{
+		slog.String("method", method),
+		slog.String("code", code.String()),
+		slog.Duration("duration", time.Since(start)),
+	}
+
+	level := slog.LevelInfo
+
+	if err != nil {
+		attrs = append(attrs, slog.String("error", status.Convert(err).Message()))
+		level = slog.LevelWarn
+
+		if serverFailures[code] {
+			level = slog.LevelError
+		}
+	}
+
+	slog.LogAttrs(ctx, level, "grpc request", attrs...)
+}
@@ -0,0 +1,19 @@
+package server
+
+import (
+	"auth-service/metrics"
+	"context"
+	"time"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/status"
+)
+
+func MetricsInterceptor(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+	start := time.Now()
+
+	response, err := handler(ctx, req)
+	metrics.ObserveRPC(info.FullMethod, status.Code(err), time.Since(start))
+
+	return response, err
+}
@@ -0,0 +1,31 @@
+package server
+
+import (
+	"auth-service/logging"
+	"context"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/metadata"
+)
+
+func RequestIDInterceptor(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+	return handler(withRequestID(ctx), req)
+}
+
+func withRequestID(ctx context.Context) context.Context {
+	id := ""
+
+	if md, ok := metadata.FromIncomingContext(ctx); ok {
+		if values := md.Get(logging.RequestIDHeader); len(values) != 0 {
+			id = values[0]
+		}
+	}
+
+	if len(id) == 0 || len(id) > 128 {
+		id = logging.NewRequestID()
+	}
+
+	grpc.SetHeader(ctx, metadata.Pairs(logging.RequestIDHeader, id))
+
+	return logging.WithRequestID(ctx, id)
+}
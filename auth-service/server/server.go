@@ -1,6 +1,7 @@
 package server
 
 import (
+	"auth-service/metrics"
 	"auth-service/models"
 	proto "auth-service/proto/auth"
 	"auth-service/services"
@@ -9,33 +10,31 @@ import (
 
 type GRPCServer struct {
 	proto.UnimplementedAuthServiceServer
+	auth *services.AuthService
+}
+
+func NewGRPCServer(users models.UserRepository) *GRPCServer {
+	return &GRPCServer{auth: services.NewAuthService(users)}
 }
 
 func (s *GRPCServer) RegisterUser(ctx context.Context, req *proto.RegisterUserRequest) (*proto.RegisterUserResponse, error) {
-	user, err := services.RegisterUser(req.Name, req.Email, req.Password, models.UserType(req.UserType))
+	user, err := s.auth.RegisterUser(req.Name, req.Email, req.Password, models.UserType(req.UserType))
 
 	if err != nil {
 		return nil, err
 	}
 
-	var userType proto.UserType
-
-	if user.UserType == models.Admin {
-		userType = proto.UserType_ADMIN
-	} else {
-		userType = proto.UserType_REGULAR
-	}
-
 	return &proto.RegisterUserResponse{
 		Id:       user.ID,
 		Name:     user.Name,
 		Email:    user.Email,
-		UserType: userType,
+		UserType: toProtoUserType(user.UserType),
 	}, nil
 }
 
 func (s *GRPCServer) LoginUser(ctx context.Context, req *proto.LoginUserRequest) (*proto.LoginUserResponse, error) {
-	token, err := services.LoginUser(req.Email, req.Password)
+	token, err := s.auth.LoginUser(req.Email, req.Password)
+	metrics.RecordLogin(err == nil)
 
 	if err != nil {
 		return nil, err
@@ -47,23 +46,26 @@ func (s *GRPCServer) LoginUser(ctx context.Context, req *proto.LoginUserRequest)
 }
 
 func (s *GRPCServer) AuthenticateUser(ctx context.Context, req *proto.AuthenticateUserRequest) (*proto.AuthenticateUserResponse, error) {
-	claims, err := services.AuthenticateUser(req.Token)
+	claims, err := s.auth.AuthenticateUser(req.Token)
 
 	if err != nil {
 		return nil, err
 	}
 
-	var userType proto.UserType
-
-	if claims.UserType == models.Admin {
-		userType = proto.UserType_ADMIN
-	} else {
-		userType = proto.UserType_REGULAR
-	}
-
 	return &proto.AuthenticateUserResponse{
 		Id:       claims.Id,
 		Email:    claims.Email,
-		UserType: userType,
+		UserType: toProtoUserType(claims.UserType),
 	}, nil
 }
+
+func toProtoUserType(userType models.UserType) proto.UserType {
+	switch userType {
+	case models.Admin:
+		return proto.UserType_ADMIN
+	case models.Inventory:
+		return proto.UserType_INVENTORY
+	}
+
+	return proto.UserType_REGULAR
+}
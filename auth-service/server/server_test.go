@@ -1,25 +1,35 @@
 package server
 
 import (
+	"auth-service/logging"
 	"auth-service/models"
 	proto "auth-service/proto/auth"
 	"auth-service/utils"
+	"bytes"
 	"context"
+	"encoding/json"
+	"errors"
+	"log/slog"
+	"strings"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
 	"gorm.io/driver/sqlite"
 	"gorm.io/gorm"
 )
 
-var server GRPCServer
+var server *GRPCServer
 
 func setupDatabase(t *testing.T) *gorm.DB {
 	db, err := gorm.Open(sqlite.Open("file:yourDbName?mode=memory&cache=shared"), &gorm.Config{})
 	assert.NoError(t, err)
 
-	server = GRPCServer{}
 	models.InitUserModel(db)
+	server = NewGRPCServer(models.NewGormUserRepository(db))
 
 	return db
 }
@@ -239,3 +249,135 @@ func TestShouldAuthenticateUserThrowErrorIfInvalidAuthentication(t *testing.T) {
 	assert.Nil(t, claims)
 	assert.Error(t, err)
 }
+
+func TestShouldStatusInterceptorMapServiceErrorsToStatusCodes(t *testing.T) {
+	db := setupDatabase(t)
+	defer teardownDatabase(db)
+
+	register := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return server.RegisterUser(ctx, req.(*proto.RegisterUserRequest))
+	}
+	login := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return server.LoginUser(ctx, req.(*proto.LoginUserRequest))
+	}
+	authenticate := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return server.AuthenticateUser(ctx, req.(*proto.AuthenticateUserRequest))
+	}
+
+	registerInfo := &grpc.UnaryServerInfo{FullMethod: proto.AuthService_RegisterUser_FullMethodName}
+	loginInfo := &grpc.UnaryServerInfo{FullMethod: proto.AuthService_LoginUser_FullMethodName}
+	authenticateInfo := &grpc.UnaryServerInfo{FullMethod: proto.AuthService_AuthenticateUser_FullMethodName}
+	newUser := &proto.RegisterUserRequest{Name: "Faiz Bachoo Shah", Email: "test@example.com", Password: "testPassword"}
+
+	StatusInterceptor(context.Background(), newUser, registerInfo, register)
+
+	_, err1 := StatusInterceptor(context.Background(), newUser, registerInfo, register)
+	_, err2 := StatusInterceptor(context.Background(), &proto.LoginUserRequest{Email: "other@example.com", Password: "testPassword"}, loginInfo, login)
+	_, err3 := StatusInterceptor(context.Background(), &proto.LoginUserRequest{Email: "test@example.com", Password: "wrongPassword"}, loginInfo, login)
+	_, err4 := StatusInterceptor(context.Background(), &proto.AuthenticateUserRequest{Token: "invalid_token"}, authenticateInfo, authenticate)
+
+	assert.Equal(t, codes.AlreadyExists, status.Code(err1))
+	assert.Equal(t, "user is already registered", status.Convert(err1).Message())
+	assert.Equal(t, codes.NotFound, status.Code(err2))
+	assert.Equal(t, codes.Unauthenticated, status.Code(err3))
+	assert.Equal(t, codes.Unauthenticated, status.Code(err4))
+}
+
+func TestShouldStatusInterceptorMapValidationErrorsToInvalidArgument(t *testing.T) {
+	db := setupDatabase(t)
+	defer teardownDatabase(db)
+
+	register := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return server.RegisterUser(ctx, req.(*proto.RegisterUserRequest))
+	}
+
+	registerInfo := &grpc.UnaryServerInfo{FullMethod: proto.AuthService_RegisterUser_FullMethodName}
+
+	_, err1 := StatusInterceptor(context.Background(), &proto.RegisterUserRequest{Name: "Faiz Bachoo Shah", Password: "testPassword"}, registerInfo, register)
+	_, err2 := StatusInterceptor(context.Background(), &proto.RegisterUserRequest{Name: "Faiz Bachoo Shah", Email: "test@example.com", Password: strings.Repeat("p", 73)}, registerInfo, register)
+
+	assert.Equal(t, codes.InvalidArgument, status.Code(err1))
+	assert.Equal(t, "email is empty", status.Convert(err1).Message())
+	assert.Equal(t, codes.InvalidArgument, status.Code(err2))
+	assert.Equal(t, "password cannot be longer than 72 bytes", status.Convert(err2).Message())
+}
+
+func TestShouldStatusInterceptorDefaultToInternal(t *testing.T) {
+	info := &grpc.UnaryServerInfo{FullMethod: proto.AuthService_RegisterUser_FullMethodName}
+
+	_, err := StatusInterceptor(context.Background(), nil, info, func(ctx context.Context, req interface{}) (interface{}, error) {
+		return nil, errors.New("connection reset by peer")
+	})
+
+	assert.Equal(t, codes.Internal, status.Code(err))
+	assert.Equal(t, "connection reset by peer", status.Convert(err).Message())
+}
+
+func TestShouldAuthenticateUserKeepTheInventoryRole(t *testing.T) {
+	db := setupDatabase(t)
+	defer teardownDatabase(db)
+
+	registeredUser, err1 := server.RegisterUser(context.Background(), &proto.RegisterUserRequest{
+		Name:     "Stock Keeper",
+		Email:    "stock@example.com",
+		Password: "testPassword",
+		UserType: proto.UserType_INVENTORY,
+	})
+	res, _ := server.LoginUser(context.Background(), &proto.LoginUserRequest{
+		Email:    "stock@example.com",
+		Password: "testPassword",
+	})
+	claims, err2 := server.AuthenticateUser(context.Background(), &proto.AuthenticateUserRequest{
+		Token: res.Token,
+	})
+
+	assert.NoError(t, err1)
+	assert.NoError(t, err2)
+	assert.Equal(t, proto.UserType_INVENTORY, registeredUser.UserType)
+	assert.Equal(t, proto.UserType_INVENTORY, claims.UserType)
+}
+
+func TestShouldRequestIDInterceptorPropagateTheIncomingID(t *testing.T) {
+	ctx := metadata.NewIncomingContext(context.Background(), metadata.Pairs(logging.RequestIDHeader, "req-42"))
+	var seen string
+
+	RequestIDInterceptor(ctx, nil, &grpc.UnaryServerInfo{}, func(ctx context.Context, _ interface{}) (interface{}, error) {
+		seen = logging.RequestID(ctx)
+		return nil, nil
+	})
+
+	assert.Equal(t, "req-42", seen)
+}
+
+func TestShouldRequestIDInterceptorGenerateAMissingID(t *testing.T) {
+	var seen string
+
+	RequestIDInterceptor(context.Background(), nil, &grpc.UnaryServerInfo{}, func(ctx context.Context, _ interface{}) (interface{}, error) {
+		seen = logging.RequestID(ctx)
+		return nil, nil
+	})
+
+	assert.Len(t, seen, 36)
+}
+
+func TestShouldLoggingInterceptorLogMethodCodeAndRequestID(t *testing.T) {
+	var output bytes.Buffer
+	logger, _ := logging.NewLogger(&output, "info", "json")
+	previous := slog.Default()
+	slog.SetDefault(logger)
+	defer slog.SetDefault(previous)
+
+	ctx := logging.WithRequestID(context.Background(), "req-45")
+	info := &grpc.UnaryServerInfo{FullMethod: proto.AuthService_LoginUser_FullMethodName}
+
+	LoggingInterceptor(ctx, nil, info, func(ctx context.Context, _ interface{}) (interface{}, error) {
+		return nil, status.Error(codes.Unauthenticated, "email/password is invalid")
+	})
+
+	var record map[string]interface{}
+	assert.NoError(t, json.Unmarshal(output.Bytes(), &record))
+	assert.Equal(t, "WARN", record["level"])
+	assert.Equal(t, proto.AuthService_LoginUser_FullMethodName, record["method"])
+	assert.Equal(t, "Unauthenticated", record["code"])
+	assert.Equal(t, "req-45", record["request_id"])
+}
@@ -0,0 +1,51 @@
+package server
+
+import (
+	"auth-service/models"
+	proto "auth-service/proto/auth"
+	"auth-service/services"
+	"auth-service/utils"
+	"context"
+	"database/sql"
+	"database/sql/driver"
+	"errors"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func StatusInterceptor(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+	response, err := handler(ctx, req)
+
+	if err == nil {
+		return response, nil
+	}
+
+	if _, ok := status.FromError(err); ok {
+		return nil, err
+	}
+
+	if info.FullMethod == proto.AuthService_AuthenticateUser_FullMethodName {
+		return nil, status.Error(codes.Unauthenticated, err.Error())
+	}
+
+	return nil, statusError(err)
+}
+
+func statusError(err error) error {
+	switch {
+	case errors.Is(err, services.ErrUserAlreadyRegistered):
+		return status.Error(codes.AlreadyExists, err.Error())
+	case errors.Is(err, services.ErrAccountNotFound):
+		return status.Error(codes.NotFound, err.Error())
+	case errors.Is(err, services.ErrInvalidCredentials):
+		return status.Error(codes.Unauthenticated, err.Error())
+	case errors.Is(err, models.ErrInvalidUser), errors.Is(err, models.ErrEmptyEmail), errors.Is(err, utils.ErrPasswordTooLong):
+		return status.Error(codes.InvalidArgument, err.Error())
+	case errors.Is(err, driver.ErrBadConn), errors.Is(err, sql.ErrConnDone):
+		return status.Error(codes.Unavailable, err.Error())
+	}
+
+	return status.Error(codes.Internal, err.Error())
+}
@@ -6,15 +6,29 @@ import (
 	"errors"
 )
 
-func RegisterUser(name string, email string, password string, userType models.UserType) (*models.User, error) {
-	user, err := models.FindUserByEmail(email)
+var (
+	ErrUserAlreadyRegistered = errors.New("user is already registered")
+	ErrAccountNotFound       = errors.New("user's account does not exist")
+	ErrInvalidCredentials    = errors.New("email/password is invalid")
+)
+
+type AuthService struct {
+	users models.UserRepository
+}
+
+func NewAuthService(users models.UserRepository) *AuthService {
+	return &AuthService{users: users}
+}
+
+func (service *AuthService) RegisterUser(name string, email string, password string, userType models.UserType) (*models.User, error) {
+	user, err := service.users.FindUserByEmail(email)
 
 	if err != nil {
 		return nil, err
 	}
 
 	if user != nil {
-		return nil, errors.New("user is already registered")
+		return nil, ErrUserAlreadyRegistered
 	}
 
 	hashedPassword, err := utils.GenerateHashFromPassword(password)
@@ -30,22 +44,22 @@ func RegisterUser(name string, email string, password string, userType models.Us
 		UserType: userType,
 	}
 
-	return models.CreateUser(&newUser)
+	return service.users.CreateUser(&newUser)
 }
 
-func LoginUser(email string, password string) (string, error) {
-	user, err := models.FindUserByEmail(email)
+func (service *AuthService) LoginUser(email string, password string) (string, error) {
+	user, err := service.users.FindUserByEmail(email)
 
 	if err != nil {
 		return "", err
 	}
 
 	if user == nil {
-		return "", errors.New("user's account does not exist")
+		return "", ErrAccountNotFound
 	}
 
 	if !utils.ValidatePassword(user.Password, password) {
-		return "", errors.New("email/password is invalid")
+		return "", ErrInvalidCredentials
 	}
 
 	jwtToken, err := utils.GenerateJwtToken(*user)
@@ -57,6 +71,6 @@ func LoginUser(email string, password string) (string, error) {
 	return jwtToken, nil
 }
 
-func AuthenticateUser(token string) (*utils.JwtClaims, error) {
+func (service *AuthService) AuthenticateUser(token string) (*utils.JwtClaims, error) {
 	return utils.ValidateJwtToken(token)
 }
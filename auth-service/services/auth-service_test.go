@@ -10,11 +10,14 @@ import (
 	"gorm.io/gorm"
 )
 
+var authService *AuthService
+
 func setupDatabase(t *testing.T) *gorm.DB {
 	db, err := gorm.Open(sqlite.Open("file:yourDbName?mode=memory&cache=shared"), &gorm.Config{})
 	assert.NoError(t, err)
 
 	models.InitUserModel(db)
+	authService = NewAuthService(models.NewGormUserRepository(db))
 
 	return db
 }
@@ -44,7 +47,7 @@ func TestShouldRegisterUserWorkCorrectly(t *testing.T) {
 		UserType: models.Admin,
 	}
 
-	registeredUser, err := RegisterUser(newUser.Name, newUser.Email, newUser.Password, newUser.UserType)
+	registeredUser, err := authService.RegisterUser(newUser.Name, newUser.Email, newUser.Password, newUser.UserType)
 
 	assert.NoError(t, err)
 	assert.NotNil(t, registeredUser)
@@ -67,8 +70,8 @@ func TestShouldRegisterUserThrowAnErrorIfUserIsAlreadyRegistered(t *testing.T) {
 		UserType: models.Admin,
 	}
 
-	registeredUser1, err1 := RegisterUser(newUser.Name, newUser.Email, newUser.Password, newUser.UserType)
-	registeredUser2, err2 := RegisterUser(newUser.Name, newUser.Email, newUser.Password, newUser.UserType)
+	registeredUser1, err1 := authService.RegisterUser(newUser.Name, newUser.Email, newUser.Password, newUser.UserType)
+	registeredUser2, err2 := authService.RegisterUser(newUser.Name, newUser.Email, newUser.Password, newUser.UserType)
 
 	assert.NoError(t, err1)
 	assert.NotNil(t, registeredUser1)
@@ -92,9 +95,9 @@ func TestShouldLoginUserWorkCorrectly(t *testing.T) {
 		UserType: models.Admin,
 	}
 
-	registeredUser, err1 := RegisterUser(newUser.Name, newUser.Email, newUser.Password, newUser.UserType)
+	registeredUser, err1 := authService.RegisterUser(newUser.Name, newUser.Email, newUser.Password, newUser.UserType)
 
-	token, err2 := LoginUser(newUser.Email, newUser.Password)
+	token, err2 := authService.LoginUser(newUser.Email, newUser.Password)
 	claims, err3 := utils.ValidateJwtToken(token)
 
 	assert.NoError(t, err1)
@@ -112,7 +115,7 @@ func TestLoginUserThrowAnErrorUserDoesNotExist(t *testing.T) {
 	db := setupDatabase(t)
 	defer teardownDatabase(db)
 
-	token, err := LoginUser("test@example.com", "testPassword")
+	token, err := authService.LoginUser("test@example.com", "testPassword")
 
 	assert.Error(t, err)
 	assert.Empty(t, token)
@@ -130,9 +133,9 @@ func TestLoginUserThrowAnErrorIfPasswordIsIncorrect(t *testing.T) {
 		UserType: models.Admin,
 	}
 
-	RegisterUser(newUser.Name, newUser.Email, newUser.Password, newUser.UserType)
+	authService.RegisterUser(newUser.Name, newUser.Email, newUser.Password, newUser.UserType)
 
-	token, err := LoginUser(newUser.Email, "wrongPassword")
+	token, err := authService.LoginUser(newUser.Email, "wrongPassword")
 
 	assert.Empty(t, token)
 	assert.Error(t, err)
@@ -150,10 +153,10 @@ func TestShouldAuthenticateUserWorkCorrectly(t *testing.T) {
 		UserType: models.Admin,
 	}
 
-	registeredUser, err1 := RegisterUser(newUser.Name, newUser.Email, newUser.Password, newUser.UserType)
+	registeredUser, err1 := authService.RegisterUser(newUser.Name, newUser.Email, newUser.Password, newUser.UserType)
 
-	token, err2 := LoginUser(newUser.Email, newUser.Password)
-	claims, err3 := AuthenticateUser(token)
+	token, err2 := authService.LoginUser(newUser.Email, newUser.Password)
+	claims, err3 := authService.AuthenticateUser(token)
 
 	assert.NoError(t, err1)
 	assert.NotNil(t, registeredUser)
@@ -177,10 +180,10 @@ func TestShouldAuthenticateUserThrowErrorIfInvalidAuthentication(t *testing.T) {
 		UserType: models.Admin,
 	}
 
-	RegisterUser(newUser.Name, newUser.Email, newUser.Password, newUser.UserType)
-	LoginUser(newUser.Email, newUser.Password)
+	authService.RegisterUser(newUser.Name, newUser.Email, newUser.Password, newUser.UserType)
+	authService.LoginUser(newUser.Email, newUser.Password)
 
-	claims, err := AuthenticateUser("invalid_token")
+	claims, err := authService.AuthenticateUser("invalid_token")
 
 	assert.Nil(t, claims)
 	assert.Error(t, err)
@@ -6,9 +6,17 @@ import (
 	"golang.org/x/crypto/bcrypt"
 )
 
+var (
+	ErrPasswordTooLong = errors.New("password cannot be longer than 72 bytes")
+)
+
 func GenerateHashFromPassword(password string) (string, error) {
 	bytes, err := bcrypt.GenerateFromPassword([]byte(password), 11)
 
+	if errors.Is(err, bcrypt.ErrPasswordTooLong) {
+		return "", ErrPasswordTooLong
+	}
+
 	if err != nil {
 		return "", errors.New("error in generating hash of password")
 	}
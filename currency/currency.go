@@ -0,0 +1,60 @@
+package currency
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+)
+
+const (
+	DefaultCurrency = "USD"
+)
+
+var (
+	ErrUnsupported = errors.New("unsupported currency code")
+)
+
+var (
+	exponents = map[string]int32{
+		"AUD": 2,
+		"BHD": 3,
+		"CAD": 2,
+		"CHF": 2,
+		"CNY": 2,
+		"EUR": 2,
+		"GBP": 2,
+		"INR": 2,
+		"JPY": 0,
+		"KRW": 0,
+		"KWD": 3,
+		"SGD": 2,
+		"USD": 2,
+	}
+)
+
+// Normalize upper-cases a currency code, falling back to DefaultCurrency
+// when it is empty, and rejects codes that are not supported.
+func Normalize(code string) (string, error) {
+	if len(code) == 0 {
+		return DefaultCurrency, nil
+	}
+
+	code = strings.ToUpper(code)
+
+	if _, ok := exponents[code]; !ok {
+		return "", fmt.Errorf("%w %s", ErrUnsupported, code)
+	}
+
+	return code, nil
+}
+
+// Exponent returns the number of minor unit digits for a supported currency.
+func Exponent(code string) (int32, error) {
+	exponent, ok := exponents[code]
+
+	if !ok {
+		return 0, fmt.Errorf("%w %s", ErrUnsupported, code)
+	}
+
+	return exponent, nil
+}
@@ -0,0 +1,47 @@
+package currency
+
+import (
+	"testing"
+)
+
+func TestNormalizeShouldDefaultToUSD(t *testing.T) {
+	code, err := Normalize("")
+
+	if err != nil || code != DefaultCurrency {
+		t.Fatalf("expected %s, got %q (%v)", DefaultCurrency, code, err)
+	}
+}
+
+func TestNormalizeShouldUppercaseTheCode(t *testing.T) {
+	code, err := Normalize("eur")
+
+	if err != nil || code != "EUR" {
+		t.Fatalf("expected EUR, got %q (%v)", code, err)
+	}
+}
+
+func TestNormalizeShouldThrowAnErrorIfCodeIsUnsupported(t *testing.T) {
+	code, err := Normalize("XYZ")
+
+	if err == nil || err.Error() != "unsupported currency code XYZ" || code != "" {
+		t.Fatalf("expected an unsupported currency error, got %q (%v)", code, err)
+	}
+}
+
+func TestExponentShouldReturnTheMinorUnitDigits(t *testing.T) {
+	cases := map[string]int32{"USD": 2, "JPY": 0, "KWD": 3}
+
+	for code, expected := range cases {
+		exponent, err := Exponent(code)
+
+		if err != nil || exponent != expected {
+			t.Errorf("%s: expected %d, got %d (%v)", code, expected, exponent, err)
+		}
+	}
+}
+
+func TestExponentShouldThrowAnErrorIfCodeIsUnsupported(t *testing.T) {
+	if _, err := Exponent("XYZ"); err == nil {
+		t.Fatal("expected an error for an unsupported currency")
+	}
+}
@@ -1,37 +1,52 @@
 package com.example.orderservicegrpcserver.client;
 
 import com.example.proto.order.Product;
-import com.example.proto.product.ProductServiceGrpc;
-import com.example.proto.product.UpdateProduct;
-import com.example.proto.product.UpdateProductRequest;
-import com.example.proto.product.UpdateProductResponse;
-import net.devh.boot.grpc.client.inject.GrpcClient;
+import org.springframework.beans.factory.annotation.Value;
+import org.springframework.http.HttpEntity;
+import org.springframework.http.HttpHeaders;
+import org.springframework.http.HttpMethod;
+import org.springframework.http.MediaType;
 import org.springframework.stereotype.Service;
+import org.springframework.web.client.RestClientException;
+import org.springframework.web.client.RestTemplate;
 
 import java.util.List;
+import java.util.Map;
 
 @Service
 public class ProductClientService {
 
-    @GrpcClient("product")
-    private ProductServiceGrpc.ProductServiceBlockingStub productServiceStub;
+    private static final String INTERNAL_TOKEN_HEADER = "X-Internal-Token";
 
-    public void updateProducts(List<Product> products) {
-        List<UpdateProduct> updateProducts = products.stream()
-                .map((product -> UpdateProduct.newBuilder()
-                        .setId(product.getId())
-                        .setQuantity(product.getQuantity())
-                        .build()))
-                .toList();
+    private final RestTemplate restTemplate = new RestTemplate();
+
+    @Value("${gateway.address}")
+    private String gatewayAddress;
 
-        UpdateProductRequest request = UpdateProductRequest.newBuilder()
-                .addAllProducts(updateProducts)
-                .build();
+    @Value("${gateway.internal-token}")
+    private String internalToken;
 
-        UpdateProductResponse response = productServiceStub.updateProducts(request);
+    // Stock for the whole order is taken through the gateway's internal stock
+    // path, which reserves every line in a single product service call.
+    public void updateProducts(List<Product> products) {
+        List<Map<String, Object>> lines = products.stream()
+                .map(product -> Map.<String, Object>of(
+                        "id", product.getId(),
+                        "quantity", product.getQuantity()))
+                .toList();
 
-        if (response.getResponseCase() == UpdateProductResponse.ResponseCase.ERRORRESPONSE) {
-            throw new IllegalStateException("Failed to update product");
+        HttpHeaders headers = new HttpHeaders();
+        headers.setContentType(MediaType.APPLICATION_JSON);
+        headers.set(INTERNAL_TOKEN_HEADER, internalToken);
+
+        try {
+            restTemplate.exchange(
+                    gatewayAddress + "/internal/products/remove-products",
+                    HttpMethod.PUT,
+                    new HttpEntity<>(Map.of("products", lines), headers),
+                    Void.class);
+        } catch (RestClientException e) {
+            throw new IllegalStateException("Failed to update product", e);
         }
     }
 
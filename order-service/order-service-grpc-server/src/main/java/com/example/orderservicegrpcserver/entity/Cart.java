@@ -33,6 +33,9 @@ public class Cart {
     private List<CartProduct> cartProducts;
 
     @Column(nullable = false)
-    private Double totalPrice;
+    private Long totalPriceMinor;
+
+    @Column(nullable = false)
+    private String currency;
 
 }
@@ -28,7 +28,15 @@ import lombok.NoArgsConstructor;
                 column = @Column(nullable = false)
         ),
         @AttributeOverride(
-                name = "price",
+                name = "unitPriceMinor",
+                column = @Column(nullable = false)
+        ),
+        @AttributeOverride(
+                name = "discountMinor",
+                column = @Column(nullable = false)
+        ),
+        @AttributeOverride(
+                name = "currency",
                 column = @Column(nullable = false)
         ),
         @AttributeOverride(
@@ -40,6 +48,8 @@ public class CartProduct {
     private Long productId;
     private String name;
     private String description;
-    private Double price;
+    private Long unitPriceMinor;
+    private Long discountMinor;
+    private String currency;
     private int quantity;
 }
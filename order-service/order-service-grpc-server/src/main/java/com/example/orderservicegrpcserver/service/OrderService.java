@@ -6,6 +6,7 @@ import com.example.orderservicegrpcserver.entity.CartProduct;
 import com.example.orderservicegrpcserver.entity.Order;
 import com.example.orderservicegrpcserver.repository.OrderRepository;
 import com.example.proto.order.CreateOrderResponse;
+import com.example.proto.order.Money;
 import com.example.proto.order.Product;
 import org.springframework.beans.factory.annotation.Autowired;
 import org.springframework.stereotype.Service;
@@ -16,6 +17,8 @@ import java.util.stream.Collectors;
 @Service
 public class OrderService {
 
+    private static final String DEFAULT_CURRENCY = "USD";
+
     @Autowired
     private OrderRepository orderRepository;
 
@@ -28,18 +31,27 @@ public class OrderService {
                         .productId(product.getId())
                         .name(product.getName())
                         .description(product.getDescription())
-                        .price(product.getPrice())
+                        .unitPriceMinor(product.getUnitPrice().getMinorUnits())
+                        .discountMinor(product.getDiscount().getMinorUnits())
+                        .currency(product.getUnitPrice().getCurrencyCode())
                         .quantity(product.getQuantity())
                         .build())
                 .toList();
 
-        Double totalPrice = products.stream()
-                .mapToDouble((product -> product.getPrice() * product.getQuantity()))
+        Long totalPriceMinor = products.stream()
+                .mapToLong((product -> product.getUnitPrice().getMinorUnits() * product.getQuantity()
+                        - product.getDiscount().getMinorUnits()))
                 .sum();
 
+        String currency = products.stream()
+                .map(product -> product.getUnitPrice().getCurrencyCode())
+                .findFirst()
+                .orElse(DEFAULT_CURRENCY);
+
         Cart cart = Cart.builder()
                 .cartProducts(cartProducts)
-                .totalPrice(totalPrice)
+                .totalPriceMinor(totalPriceMinor)
+                .currency(currency)
                 .build();
 
         Order order = Order.builder()
@@ -63,7 +75,14 @@ public class OrderService {
                         .setId(cartProduct.getProductId())
                         .setName(cartProduct.getName())
                         .setDescription(cartProduct.getDescription())
-                        .setPrice(cartProduct.getPrice())
+                        .setUnitPrice(Money.newBuilder()
+                                .setMinorUnits(cartProduct.getUnitPriceMinor())
+                                .setCurrencyCode(cartProduct.getCurrency())
+                                .build())
+                        .setDiscount(Money.newBuilder()
+                                .setMinorUnits(cartProduct.getDiscountMinor())
+                                .setCurrencyCode(cartProduct.getCurrency())
+                                .build())
                         .setQuantity(cartProduct.getQuantity())
                         .build())
                 .toList();
@@ -0,0 +1,233 @@
+package config
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net"
+	"os"
+	"strconv"
+	"time"
+)
+
+var (
+	logLevels = map[string]bool{"debug": true, "info": true, "warn": true, "error": true}
+)
+
+type Duration time.Duration
+
+func (d *Duration) UnmarshalJSON(data []byte) error {
+	var value string
+
+	if err := json.Unmarshal(data, &value); err != nil {
+		return fmt.Errorf("duration must be a string such as \"5s\": %w", err)
+	}
+
+	parsed, err := time.ParseDuration(value)
+
+	if err != nil {
+		return err
+	}
+
+	*d = Duration(parsed)
+
+	return nil
+}
+
+type Database struct {
+	Host     string `json:"host"`
+	Port     int    `json:"port"`
+	User     string `json:"user"`
+	Password string `json:"password"`
+	Name     string `json:"name"`
+	SSLMode  string `json:"sslMode"`
+}
+
+func (database Database) DSN() string {
+	return fmt.Sprintf("host=%s port=%d user=%s password=%s dbname=%s sslmode=%s", database.Host, database.Port, database.User, database.Password, database.Name, database.SSLMode)
+}
+
+type Log struct {
+	Level  string `json:"level"`
+	Format string `json:"format"`
+}
+
+type Config struct {
+	MetricsAddress         string   `json:"metricsAddress"`
+	ListenAddress          string   `json:"listenAddress"`
+	Database               Database `json:"database"`
+	AllocationStrategy     string   `json:"allocationStrategy"`
+	LowStockWebhookURL     string   `json:"lowStockWebhookUrl"`
+	IdempotencyRetention   Duration `json:"idempotencyRetention"`
+	IdempotencyLease       Duration `json:"idempotencyLease"`
+	PriceSchedulerInterval Duration `json:"priceSchedulerInterval"`
+	OutboxEventsFile       string   `json:"outboxEventsFile"`
+	OutboxRelayInterval    Duration `json:"outboxRelayInterval"`
+	Log                    Log      `json:"log"`
+}
+
+func Default() Config {
+	return Config{
+		ListenAddress:          ":9001",
+		MetricsAddress:         ":9101",
+		Database:               Database{Host: "localhost", Port: 5432, SSLMode: "disable"},
+		IdempotencyRetention:   Duration(24 * time.Hour),
+		IdempotencyLease:       Duration(30 * time.Second),
+		PriceSchedulerInterval: Duration(time.Minute),
+		OutboxRelayInterval:    Duration(5 * time.Second),
+		Log:                    Log{Level: "info", Format: "text"},
+	}
+}
+
+func Load(path string) (*Config, error) {
+	cfg := Default()
+
+	if len(path) != 0 {
+		file, err := os.Open(path)
+
+		if err != nil {
+			return nil, err
+		}
+		defer file.Close()
+
+		decoder := json.NewDecoder(file)
+		decoder.DisallowUnknownFields()
+
+		if err := decoder.Decode(&cfg); err != nil {
+			return nil, fmt.Errorf("invalid config file %s: %w", path, err)
+		}
+	}
+
+	if err := cfg.applyEnv(); err != nil {
+		return nil, err
+	}
+
+	if err := cfg.Validate(); err != nil {
+		return nil, err
+	}
+
+	return &cfg, nil
+}
+
+func (cfg *Config) applyEnv() error {
+	setString(&cfg.ListenAddress, "LISTEN_ADDRESS")
+	setString(&cfg.MetricsAddress, "METRICS_ADDRESS")
+	setString(&cfg.Log.Level, "LOG_LEVEL")
+	setString(&cfg.Log.Format, "LOG_FORMAT")
+	setString(&cfg.Database.Host, "DB_HOST")
+	setString(&cfg.Database.User, "DB_USERNAME")
+	setString(&cfg.Database.Password, "DB_PASSWORD")
+	setString(&cfg.Database.Name, "DB_NAME")
+	setString(&cfg.Database.SSLMode, "DB_SSLMODE")
+	setString(&cfg.AllocationStrategy, "ALLOCATION_STRATEGY")
+	setString(&cfg.LowStockWebhookURL, "LOW_STOCK_WEBHOOK_URL")
+	setString(&cfg.OutboxEventsFile, "OUTBOX_EVENTS_FILE")
+
+	if err := setInt(&cfg.Database.Port, "DB_PORT"); err != nil {
+		return err
+	}
+
+	durations := map[string]*Duration{
+		"IDEMPOTENCY_RETENTION":    &cfg.IdempotencyRetention,
+		"IDEMPOTENCY_LEASE":        &cfg.IdempotencyLease,
+		"PRICE_SCHEDULER_INTERVAL": &cfg.PriceSchedulerInterval,
+		"OUTBOX_RELAY_INTERVAL":    &cfg.OutboxRelayInterval,
+	}
+
+	for name, duration := range durations {
+		if err := setDuration(duration, name); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (cfg *Config) Validate() error {
+	if _, _, err := net.SplitHostPort(cfg.ListenAddress); err != nil {
+		return fmt.Errorf("invalid listen address %q: %w", cfg.ListenAddress, err)
+	}
+
+	if len(cfg.MetricsAddress) != 0 {
+		if _, _, err := net.SplitHostPort(cfg.MetricsAddress); err != nil {
+			return fmt.Errorf("invalid metrics address %q: %w", cfg.MetricsAddress, err)
+		}
+	}
+
+	if !logLevels[cfg.Log.Level] {
+		return fmt.Errorf("unknown log level %s", cfg.Log.Level)
+	}
+
+	if cfg.Log.Format != "text" && cfg.Log.Format != "json" {
+		return fmt.Errorf("unknown log format %s, expected text or json", cfg.Log.Format)
+	}
+
+	if len(cfg.Database.Host) == 0 {
+		return errors.New("database host cannot be empty")
+	}
+
+	if cfg.Database.Port <= 0 || cfg.Database.Port > 65535 {
+		return fmt.Errorf("invalid database port %d", cfg.Database.Port)
+	}
+
+	if len(cfg.Database.Name) == 0 {
+		return errors.New("database name cannot be empty")
+	}
+
+	durations := map[string]Duration{
+		"idempotency retention":    cfg.IdempotencyRetention,
+		"idempotency lease":        cfg.IdempotencyLease,
+		"price scheduler interval": cfg.PriceSchedulerInterval,
+		"outbox relay interval":    cfg.OutboxRelayInterval,
+	}
+
+	for name, duration := range durations {
+		if duration <= 0 {
+			return fmt.Errorf("%s must be greater than 0", name)
+		}
+	}
+
+	return nil
+}
+
+func setString(field *string, name string) {
+	if value, ok := os.LookupEnv(name); ok {
+		*field = value
+	}
+}
+
+func setInt(field *int, name string) error {
+	value, ok := os.LookupEnv(name)
+
+	if !ok {
+		return nil
+	}
+
+	parsed, err := strconv.Atoi(value)
+
+	if err != nil {
+		return fmt.Errorf("invalid value %q for %s: %w", value, name, err)
+	}
+
+	*field = parsed
+
+	return nil
+}
+
+func setDuration(field *Duration, name string) error {
+	value, ok := os.LookupEnv(name)
+
+	if !ok {
+		return nil
+	}
+
+	parsed, err := time.ParseDuration(value)
+
+	if err != nil {
+		return fmt.Errorf("invalid value %q for %s: %w", value, name, err)
+	}
+
+	*field = Duration(parsed)
+
+	return nil
+}
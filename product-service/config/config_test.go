@@ -0,0 +1,67 @@
+package config
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func writeConfig(t *testing.T, content string) string {
+	path := filepath.Join(t.TempDir(), "config.json")
+	assert.NoError(t, os.WriteFile(path, []byte(content), 0600))
+
+	return path
+}
+
+func TestShouldLoadMergeFileAndEnvironment(t *testing.T) {
+	path := writeConfig(t, `{"listenAddress": ":7001", "database": {"host": "db", "name": "products"}, "outboxRelayInterval": "10s"}`)
+	t.Setenv("DB_PORT", "6543")
+	t.Setenv("PRICE_SCHEDULER_INTERVAL", "30s")
+
+	cfg, err := Load(path)
+
+	assert.NoError(t, err)
+	assert.Equal(t, ":7001", cfg.ListenAddress)
+	assert.Equal(t, 6543, cfg.Database.Port)
+	assert.Equal(t, Duration(10*time.Second), cfg.OutboxRelayInterval)
+	assert.Equal(t, Duration(30*time.Second), cfg.PriceSchedulerInterval)
+	assert.Equal(t, Duration(24*time.Hour), cfg.IdempotencyRetention)
+	assert.Equal(t, Duration(30*time.Second), cfg.IdempotencyLease)
+	assert.Equal(t, "host=db port=6543 user= password= dbname=products sslmode=disable", cfg.Database.DSN())
+}
+
+func TestShouldLoadThrowAnErrorIfConfigIsInvalid(t *testing.T) {
+	_, err1 := Load("")
+
+	t.Setenv("DB_NAME", "products")
+	_, err2 := Load(writeConfig(t, `{"outboxRelayInterval": 5}`))
+
+	t.Setenv("OUTBOX_RELAY_INTERVAL", "0s")
+	_, err3 := Load("")
+
+	t.Setenv("OUTBOX_RELAY_INTERVAL", "5s")
+	t.Setenv("LISTEN_ADDRESS", "9001")
+	_, err4 := Load("")
+
+	assert.Equal(t, "database name cannot be empty", err1.Error())
+	assert.Error(t, err2)
+	assert.Equal(t, "outbox relay interval must be greater than 0", err3.Error())
+	assert.Error(t, err4)
+}
+
+func TestShouldLoadValidateTheLogSettings(t *testing.T) {
+	t.Setenv("DB_NAME", "test")
+	t.Setenv("LOG_FORMAT", "json")
+
+	cfg, err1 := Load("")
+
+	t.Setenv("LOG_LEVEL", "verbose")
+	_, err2 := Load("")
+
+	assert.NoError(t, err1)
+	assert.Equal(t, Log{Level: "info", Format: "json"}, cfg.Log)
+	assert.Equal(t, "unknown log level verbose", err2.Error())
+}
@@ -3,37 +3,32 @@ package database
 import (
 	"errors"
 	"fmt"
-	"log"
-	"os"
+	"log/slog"
+	"product-service/config"
 	"product-service/models"
 
 	"gorm.io/driver/postgres"
 	"gorm.io/gorm"
 )
 
-var (
-	DB *gorm.DB
-)
-
-func Connect() error {
-	var err error
-
-	dsn := fmt.Sprintf("host=localhost port=5432 user=%s password=%s dbname=%s sslmode=disable", os.Getenv("DB_USERNAME"), os.Getenv("DB_PASSWORD"), os.Getenv("DB_NAME"))
-	DB, err = gorm.Open(postgres.Open(dsn), &gorm.Config{})
+func Connect(cfg config.Database) (*gorm.DB, error) {
+	db, err := gorm.Open(postgres.Open(cfg.DSN()), &gorm.Config{})
 
 	if err != nil {
-		return errors.New("failed to connect to database")
+		return nil, errors.New("failed to connect to database")
 	}
 
-	initModels()
+	if err := initModels(db); err != nil {
+		return nil, fmt.Errorf("failed to initialize models: %w", err)
+	}
 
-	log.Printf("Database connected...")
+	slog.Info("database connected")
 
-	return nil
+	return db, nil
 }
 
-func Close() error {
-	sqlDb, err := DB.DB()
+func Close(db *gorm.DB) error {
+	sqlDb, err := db.DB()
 
 	if err != nil {
 		return errors.New("failed to close the database connection")
@@ -41,12 +36,45 @@ func Close() error {
 
 	sqlDb.Close()
 
-	log.Printf("Database closed...")
+	slog.Info("database closed")
 
 	return nil
 }
 
-func initModels() {
-	log.Printf("Initializing models")
-	models.InitProductModel(DB)
+func initModels(db *gorm.DB) error {
+	slog.Info("initializing models")
+
+	if err := models.InitProductModel(db); err != nil {
+		return err
+	}
+
+	if err := models.InitCategoryModel(db); err != nil {
+		return err
+	}
+
+	if err := models.InitSkuModel(db); err != nil {
+		return err
+	}
+
+	if err := models.InitWarehouseModel(db); err != nil {
+		return err
+	}
+
+	if err := models.InitPriceHistoryModel(db); err != nil {
+		return err
+	}
+
+	if err := models.InitPromotionModel(db); err != nil {
+		return err
+	}
+
+	if err := models.InitIdempotencyModel(db); err != nil {
+		return err
+	}
+
+	if err := models.InitOutboxModel(db); err != nil {
+		return err
+	}
+
+	return nil
 }
@@ -0,0 +1,52 @@
+package events
+
+import (
+	"errors"
+	"sync"
+)
+
+type Handler func(event Event) error
+
+// Bus is the publisher the outbox relay hands events to. The configured sink
+// and any in-process consumers subscribe to it.
+type Bus struct {
+	mutex    sync.RWMutex
+	handlers map[Type][]Handler
+	all      []Handler
+}
+
+func NewBus() *Bus {
+	return &Bus{handlers: make(map[Type][]Handler)}
+}
+
+// Subscribe registers a handler for the given event types, or for every
+// event when no type is given.
+func (bus *Bus) Subscribe(handler Handler, types ...Type) {
+	bus.mutex.Lock()
+	defer bus.mutex.Unlock()
+
+	if len(types) == 0 {
+		bus.all = append(bus.all, handler)
+		return
+	}
+
+	for _, eventType := range types {
+		bus.handlers[eventType] = append(bus.handlers[eventType], handler)
+	}
+}
+
+func (bus *Bus) Publish(event Event) error {
+	bus.mutex.RLock()
+	handlers := append(append([]Handler{}, bus.handlers[event.Type]...), bus.all...)
+	bus.mutex.RUnlock()
+
+	var errs []error
+
+	for _, handler := range handlers {
+		if err := handler(event); err != nil {
+			errs = append(errs, err)
+		}
+	}
+
+	return errors.Join(errs...)
+}
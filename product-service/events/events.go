@@ -0,0 +1,68 @@
+package events
+
+import (
+	"encoding/json"
+	"errors"
+	"log/slog"
+	"time"
+)
+
+type Type string
+
+const (
+	ProductCreated Type = "ProductCreated"
+	StockChanged   Type = "StockChanged"
+	ProductDeleted Type = "ProductDeleted"
+)
+
+type Event struct {
+	ID          int64           `json:"id"`
+	Type        Type            `json:"type"`
+	AggregateID int64           `json:"aggregateId"`
+	Payload     json.RawMessage `json:"payload"`
+	OccurredAt  time.Time       `json:"occurredAt"`
+}
+
+type ProductCreatedPayload struct {
+	ProductID  int64  `json:"productId"`
+	Name       string `json:"name"`
+	PriceMinor int64  `json:"priceMinor"`
+	Currency   string `json:"currency"`
+	Quantity   int32  `json:"quantity"`
+}
+
+type StockChangedPayload struct {
+	ProductID        int64 `json:"productId"`
+	PreviousQuantity int32 `json:"previousQuantity"`
+	Quantity         int32 `json:"quantity"`
+	Version          int64 `json:"version"`
+}
+
+type ProductDeletedPayload struct {
+	ProductID int64 `json:"productId"`
+}
+
+type Publisher interface {
+	Publish(event Event) error
+}
+
+type LogPublisher struct{}
+
+func (LogPublisher) Publish(event Event) error {
+	slog.Info("event published", "type", event.Type, "event_id", event.ID, "product_id", event.AggregateID, "payload", event.Payload)
+	return nil
+}
+
+type MultiPublisher []Publisher
+
+func (publishers MultiPublisher) Publish(event Event) error {
+	var errs []error
+
+	for _, publisher := range publishers {
+		if err := publisher.Publish(event); err != nil {
+			errs = append(errs, err)
+		}
+	}
+
+	return errors.Join(errs...)
+}
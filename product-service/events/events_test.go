@@ -0,0 +1,66 @@
+package events
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestBusShouldDeliverEventsToSubscribers(t *testing.T) {
+	bus := NewBus()
+
+	var created, everything []Event
+
+	bus.Subscribe(func(event Event) error { created = append(created, event); return nil }, ProductCreated)
+	bus.Subscribe(func(event Event) error { everything = append(everything, event); return nil })
+
+	bus.Publish(Event{ID: 1, Type: ProductCreated})
+	bus.Publish(Event{ID: 2, Type: StockChanged})
+
+	assert.Equal(t, 1, len(created))
+	assert.Equal(t, 2, len(everything))
+}
+
+func TestBusShouldReturnHandlerErrors(t *testing.T) {
+	bus := NewBus()
+
+	bus.Subscribe(func(event Event) error { return errors.New("handler failed") })
+
+	assert.Error(t, bus.Publish(Event{ID: 1, Type: ProductDeleted}))
+}
+
+func TestWriterSinkShouldWriteOneEventPerLine(t *testing.T) {
+	var buffer bytes.Buffer
+
+	sink := NewWriterSink(&buffer)
+	sink.Publish(Event{ID: 1, Type: ProductCreated, AggregateID: 7, Payload: json.RawMessage(`{"productId":7}`)})
+	sink.Publish(Event{ID: 2, Type: ProductDeleted, AggregateID: 7, Payload: json.RawMessage(`{"productId":7}`)})
+
+	lines := bytes.Split(bytes.TrimSpace(buffer.Bytes()), []byte("\n"))
+
+	var event Event
+
+	assert.Equal(t, 2, len(lines))
+	assert.NoError(t, json.Unmarshal(lines[1], &event))
+	assert.Equal(t, ProductDeleted, event.Type)
+	assert.Equal(t, int64(7), event.AggregateID)
+}
+
+func TestFileSinkShouldAppendToTheFile(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "events.log")
+
+	sink, err := NewFileSink(path)
+	assert.NoError(t, err)
+
+	sink.Publish(Event{ID: 1, Type: StockChanged})
+	sink.Close()
+
+	contents, _ := os.ReadFile(path)
+
+	assert.Contains(t, string(contents), `"type":"StockChanged"`)
+}
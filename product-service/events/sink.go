@@ -0,0 +1,46 @@
+package events
+
+import (
+	"encoding/json"
+	"io"
+	"os"
+	"sync"
+)
+
+type WriterSink struct {
+	mutex   sync.Mutex
+	encoder *json.Encoder
+	closer  io.Closer
+}
+
+func NewWriterSink(writer io.Writer) *WriterSink {
+	return &WriterSink{encoder: json.NewEncoder(writer)}
+}
+
+func NewFileSink(path string) (*WriterSink, error) {
+	file, err := os.OpenFile(path, os.O_CREATE|os.O_APPEND|os.O_WRONLY, 0644)
+
+	if err != nil {
+		return nil, err
+	}
+
+	sink := NewWriterSink(file)
+	sink.closer = file
+
+	return sink, nil
+}
+
+func (sink *WriterSink) Publish(event Event) error {
+	sink.mutex.Lock()
+	defer sink.mutex.Unlock()
+
+	return sink.encoder.Encode(event)
+}
+
+func (sink *WriterSink) Close() error {
+	if sink.closer == nil {
+		return nil
+	}
+
+	return sink.closer.Close()
+}
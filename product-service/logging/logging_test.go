@@ -0,0 +1,50 @@
+package logging
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestShouldNewRequestIDGenerateDistinctUUIDs(t *testing.T) {
+	first, second := NewRequestID(), NewRequestID()
+
+	assert.Len(t, first, 36)
+	assert.NotEqual(t, first, second)
+}
+
+func TestShouldNewLoggerAttachTheRequestID(t *testing.T) {
+	var output bytes.Buffer
+	logger, err := NewLogger(&output, "info", "json")
+	assert.NoError(t, err)
+
+	logger.InfoContext(WithRequestID(context.Background(), "abc-123"), "hello", "name", "world")
+
+	var record map[string]interface{}
+	assert.NoError(t, json.Unmarshal(output.Bytes(), &record))
+	assert.Equal(t, "hello", record["msg"])
+	assert.Equal(t, "world", record["name"])
+	assert.Equal(t, "abc-123", record["request_id"])
+}
+
+func TestShouldNewLoggerRespectTheLevel(t *testing.T) {
+	var output bytes.Buffer
+	logger, _ := NewLogger(&output, "warn", "text")
+
+	logger.Info("hidden")
+	logger.Warn("shown")
+
+	assert.NotContains(t, output.String(), "hidden")
+	assert.Contains(t, output.String(), "msg=shown")
+}
+
+func TestShouldNewLoggerThrowAnErrorIfSettingsAreInvalid(t *testing.T) {
+	_, err1 := NewLogger(&bytes.Buffer{}, "verbose", "text")
+	_, err2 := NewLogger(&bytes.Buffer{}, "info", "xml")
+
+	assert.Error(t, err1)
+	assert.Error(t, err2)
+}
@@ -1,14 +1,27 @@
 package main
 
 import (
+	"context"
 	"log"
+	"log/slog"
 	"net"
+	"os"
+	"product-service/config"
 	"product-service/database"
+	"product-service/events"
+	"product-service/logging"
+	"product-service/metrics"
+	"product-service/models"
+	"product-service/notifier"
 	proto "product-service/proto/product"
 	"product-service/server"
+	"product-service/services"
+	"time"
 
 	"github.com/joho/godotenv"
+	"github.com/prometheus/client_golang/prometheus"
 	"google.golang.org/grpc"
+	"google.golang.org/grpc/keepalive"
 )
 
 func loadEnv() {
@@ -20,16 +33,97 @@ func loadEnv() {
 func main() {
 	loadEnv()
 
-	if err := database.Connect(); err != nil {
+	cfg, err := config.Load(os.Getenv("CONFIG_FILE"))
+
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	logger, err := logging.NewLogger(os.Stderr, cfg.Log.Level, cfg.Log.Format)
+
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	slog.SetDefault(logger)
+
+	strategy, err := models.ParseAllocationStrategy(cfg.AllocationStrategy, models.AllocationPriority)
+
+	if err != nil {
 		log.Fatal(err)
 	}
-	defer database.Close()
 
-	grpcServer := grpc.NewServer()
-	proto.RegisterProductServiceServer(grpcServer, &server.GRPCServer{})
+	var stockNotifier notifier.Notifier = notifier.LogNotifier{}
+
+	if len(cfg.LowStockWebhookURL) != 0 {
+		stockNotifier = notifier.MultiNotifier{notifier.LogNotifier{}, notifier.NewWebhookNotifier(cfg.LowStockWebhookURL)}
+	}
+
+	db, err := database.Connect(cfg.Database)
+
+	if err != nil {
+		log.Fatal(err)
+	}
+	defer database.Close(db)
+
+	idempotency, err := services.NewIdempotencyService(models.NewGormIdempotencyRepository(db), time.Duration(cfg.IdempotencyRetention), time.Duration(cfg.IdempotencyLease))
+
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	products := models.NewGormProductRepository(db, strategy)
+	inventory := services.NewInventory(stockNotifier)
+	productService := services.NewProductService(products, models.NewGormPriceRepository(db), inventory)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	productService.StartPriceScheduler(ctx, time.Duration(cfg.PriceSchedulerInterval))
+
+	var sink events.Publisher = events.LogPublisher{}
+
+	if len(cfg.OutboxEventsFile) != 0 {
+		fileSink, err := events.NewFileSink(cfg.OutboxEventsFile)
+
+		if err != nil {
+			log.Fatal(err)
+		}
+		defer fileSink.Close()
+
+		sink = fileSink
+	}
+
+	bus := events.NewBus()
+	bus.Subscribe(sink.Publish)
+
+	services.NewOutboxRelay(models.NewGormOutboxRepository(db), bus).Start(ctx, time.Duration(cfg.OutboxRelayInterval), 100)
+
+	grpcServer := grpc.NewServer(
+		grpc.ChainUnaryInterceptor(server.RequestIDInterceptor, server.LoggingInterceptor, server.MetricsInterceptor, server.StatusInterceptor, server.NewIdempotencyInterceptor(idempotency)),
+		grpc.ChainStreamInterceptor(server.StreamRequestIDInterceptor, server.StreamLoggingInterceptor, server.StreamMetricsInterceptor, server.StreamStatusInterceptor),
+		grpc.KeepaliveEnforcementPolicy(keepalive.EnforcementPolicy{MinTime: 10 * time.Second, PermitWithoutStream: true}),
+	)
+	prometheus.MustRegister(metrics.NewStockCollector(products))
+
+	proto.RegisterProductServiceServer(grpcServer, server.NewGRPCServer(
+		productService,
+		services.NewSkuService(models.NewGormSkuRepository(db), products, inventory),
+		services.NewPromotionService(models.NewGormPromotionRepository(db), products),
+		services.NewCategoryService(models.NewGormCategoryRepository(db), products),
+		services.NewWarehouseService(models.NewGormWarehouseRepository(db), inventory),
+	))
+
+	if len(cfg.MetricsAddress) != 0 {
+		go func() {
+			if err := metrics.Serve(cfg.MetricsAddress); err != nil {
+				log.Fatal(err)
+			}
+		}()
+	}
 
-	log.Printf("Server started at port 9001")
-	lis, err := net.Listen("tcp", ":9001")
+	slog.Info("server started", "address", cfg.ListenAddress)
+	lis, err := net.Listen("tcp", cfg.ListenAddress)
 
 	if err != nil {
 		log.Fatal(err)
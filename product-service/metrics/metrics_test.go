@@ -0,0 +1,20 @@
+package metrics
+
+import (
+	"testing"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/grpc/codes"
+)
+
+func TestObserveRPCShouldCountCallsByMethodAndCode(t *testing.T) {
+	before := testutil.ToFloat64(rpcHandled.WithLabelValues("/product_service.ProductService/GetProduct", "NotFound"))
+
+	ObserveRPC("/product_service.ProductService/GetProduct", codes.NotFound, 20*time.Millisecond)
+	ObserveRPC("/product_service.ProductService/GetProduct", codes.OK, 10*time.Millisecond)
+
+	assert.Equal(t, before+1, testutil.ToFloat64(rpcHandled.WithLabelValues("/product_service.ProductService/GetProduct", "NotFound")))
+	assert.Equal(t, 1, testutil.CollectAndCount(rpcDuration, "grpc_server_handling_seconds"))
+}
@@ -0,0 +1,50 @@
+package metrics
+
+import (
+	"log/slog"
+	"product-service/models"
+	"strconv"
+
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+var (
+	stockQuantityDesc = prometheus.NewDesc("product_stock_quantity", "Units in stock across all warehouses, by product.", []string{"product_id", "product"}, nil)
+	lowStockDesc      = prometheus.NewDesc("product_low_stock_products", "Products at or below their reorder threshold.", nil, nil)
+)
+
+// stockCollector reads stock levels from the repository at scrape time, so
+// the gauges never drift from the database.
+type stockCollector struct {
+	products models.ProductRepository
+}
+
+func NewStockCollector(products models.ProductRepository) prometheus.Collector {
+	return &stockCollector{products: products}
+}
+
+func (c *stockCollector) Describe(descs chan<- *prometheus.Desc) {
+	descs <- stockQuantityDesc
+	descs <- lowStockDesc
+}
+
+func (c *stockCollector) Collect(metrics chan<- prometheus.Metric) {
+	products, err := c.products.GetAllProducts()
+
+	if err != nil {
+		slog.Error("failed to collect stock levels", "error", err)
+		return
+	}
+
+	lowStock := 0
+
+	for _, product := range products {
+		metrics <- prometheus.MustNewConstMetric(stockQuantityDesc, prometheus.GaugeValue, float64(product.Quantity), strconv.FormatInt(product.ID, 10), product.Name)
+
+		if product.ReorderThreshold > 0 && product.Quantity <= product.ReorderThreshold {
+			lowStock++
+		}
+	}
+
+	metrics <- prometheus.MustNewConstMetric(lowStockDesc, prometheus.GaugeValue, float64(lowStock))
+}
@@ -0,0 +1,28 @@
+package metrics
+
+import (
+	"product-service/models"
+	"strings"
+	"testing"
+
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestStockCollectorShouldReportQuantitiesAndLowStock(t *testing.T) {
+	products := models.NewMemoryProductRepository()
+	products.CreateProduct(&models.Product{Name: "Keyboard", Quantity: 3, ReorderThreshold: 5})
+	products.CreateProduct(&models.Product{Name: "Mouse", Quantity: 20, ReorderThreshold: 5})
+
+	expected := `
+# HELP product_low_stock_products Products at or below their reorder threshold.
+# TYPE product_low_stock_products gauge
+product_low_stock_products 1
+# HELP product_stock_quantity Units in stock across all warehouses, by product.
+# TYPE product_stock_quantity gauge
+product_stock_quantity{product="Keyboard",product_id="1"} 3
+product_stock_quantity{product="Mouse",product_id="2"} 20
+`
+
+	assert.NoError(t, testutil.CollectAndCompare(NewStockCollector(products), strings.NewReader(expected)))
+}
@@ -0,0 +1,196 @@
+package models
+
+import (
+	"fmt"
+	"log/slog"
+
+	"gorm.io/gorm"
+)
+
+type Category struct {
+	gorm.Model
+	ID          int64  `gorm:"primarykey;AUTO_INCREMENT"`
+	Name        string `gorm:"column:name"`
+	Description string `gorm:"column:description"`
+	ParentID    *int64 `gorm:"column:parent_id;index"`
+}
+
+type ProductCategory struct {
+	ProductID  int64 `gorm:"column:product_id;primaryKey"`
+	CategoryID int64 `gorm:"column:category_id;primaryKey;index"`
+}
+
+type GormCategoryRepository struct {
+	db *gorm.DB
+}
+
+func NewGormCategoryRepository(dbInstance *gorm.DB) *GormCategoryRepository {
+	return &GormCategoryRepository{db: dbInstance}
+}
+
+func InitCategoryModel(db *gorm.DB) error {
+	return db.AutoMigrate(&Category{}, &ProductCategory{})
+}
+
+func (repository *GormCategoryRepository) CreateCategory(newCategory *Category) (*Category, error) {
+	if newCategory == nil {
+		return nil, InvalidArgumentError("invalid category")
+	}
+
+	if err := repository.db.Create(newCategory).Error; err != nil {
+		slog.Error("failed to create category", "operation", "create", "entity", "category", "error", err)
+		return nil, fmt.Errorf("error in creating a new category: %w", err)
+	}
+
+	return newCategory, nil
+}
+
+func (repository *GormCategoryRepository) GetAllCategories() ([]Category, error) {
+	var categories []Category
+
+	if err := repository.db.Find(&categories).Error; err != nil {
+		return nil, err
+	}
+
+	return categories, nil
+}
+
+func (repository *GormCategoryRepository) GetCategory(id int32) (*Category, error) {
+	var category *Category
+
+	if err := repository.db.First(&category, id).Error; err != nil {
+		return nil, err
+	}
+
+	return category, nil
+}
+
+func (repository *GormCategoryRepository) FindCategoryByName(name string, parentID *int64) (*Category, error) {
+	var category *Category
+
+	query := repository.db.Where("name = ?", name)
+
+	if parentID == nil {
+		query = query.Where("parent_id IS NULL")
+	} else {
+		query = query.Where("parent_id = ?", *parentID)
+	}
+
+	result := query.Find(&category)
+
+	if result.Error != nil {
+		return nil, result.Error
+	}
+
+	if result.RowsAffected == 0 {
+		return nil, nil
+	}
+
+	return category, nil
+}
+
+func (repository *GormCategoryRepository) UpdateCategory(id int32, fields map[string]interface{}) (*Category, error) {
+	var category *Category
+
+	if len(fields) == 0 {
+		return nil, InvalidArgumentError("no category details to update")
+	}
+
+	if err := repository.db.First(&category, id).Error; err != nil {
+		return nil, err
+	}
+
+	if err := repository.db.Model(&category).Updates(fields).Error; err != nil {
+		slog.Error("failed to update category", "operation", "update", "entity", "category", "category_id", id, "error", err)
+		return nil, fmt.Errorf("failed to update category: %w", err)
+	}
+
+	return category, nil
+}
+
+func (repository *GormCategoryRepository) DeleteCategory(id int32) error {
+	var children int64
+
+	if err := repository.db.Model(&Category{}).Where("parent_id = ?", id).Count(&children).Error; err != nil {
+		return err
+	}
+
+	if children > 0 {
+		return FailedPreconditionError("category has sub-categories")
+	}
+
+	return repository.db.Transaction(func(tx *gorm.DB) error {
+		if tx.Delete(&Category{}, id).RowsAffected < 1 {
+			return NotFoundError("category with id %d does not exist", id)
+		}
+
+		return tx.Where("category_id = ?", id).Delete(&ProductCategory{}).Error
+	})
+}
+
+func (repository *GormCategoryRepository) GetCategoryDescendantIds(id int32) ([]int64, error) {
+	if _, err := repository.GetCategory(id); err != nil {
+		return nil, err
+	}
+
+	ids := []int64{int64(id)}
+	seen := map[int64]bool{int64(id): true}
+	frontier := []int64{int64(id)}
+
+	for len(frontier) > 0 {
+		var children []int64
+
+		if err := repository.db.Model(&Category{}).Where("parent_id IN ?", frontier).Pluck("id", &children).Error; err != nil {
+			return nil, err
+		}
+
+		frontier = nil
+
+		for _, child := range children {
+			if !seen[child] {
+				seen[child] = true
+				ids = append(ids, child)
+				frontier = append(frontier, child)
+			}
+		}
+	}
+
+	return ids, nil
+}
+
+func (repository *GormCategoryRepository) AddProductToCategory(productID int32, categoryID int32) error {
+	if _, err := findProduct(repository.db, productID); err != nil {
+		return err
+	}
+
+	if _, err := repository.GetCategory(categoryID); err != nil {
+		return err
+	}
+
+	link := ProductCategory{ProductID: int64(productID), CategoryID: int64(categoryID)}
+
+	if err := repository.db.Where(&link).FirstOrCreate(&link).Error; err != nil {
+		slog.Error("failed to add product to category", "operation", "create", "entity", "product_category", "product_id", productID, "category_id", categoryID, "error", err)
+		return fmt.Errorf("failed to add product to category: %w", err)
+	}
+
+	return nil
+}
+
+func (repository *GormCategoryRepository) RemoveProductFromCategory(productID int32, categoryID int32) error {
+	if repository.db.Where("product_id = ? AND category_id = ?", productID, categoryID).Delete(&ProductCategory{}).RowsAffected < 1 {
+		return NotFoundError("product with id %d is not in category with id %d", productID, categoryID)
+	}
+
+	return nil
+}
+
+func (repository *GormCategoryRepository) GetCategoryProductIds(categoryIds []int64) ([]int64, error) {
+	var productIds []int64
+
+	if err := repository.db.Model(&ProductCategory{}).Distinct().Where("category_id IN ?", categoryIds).Pluck("product_id", &productIds).Error; err != nil {
+		return nil, err
+	}
+
+	return productIds, nil
+}
@@ -0,0 +1,179 @@
+package models
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"gorm.io/gorm"
+)
+
+func TestShouldCreateCategoryWorkCorrectly(t *testing.T) {
+	db := setupDatabase(t)
+	defer teardownDatabase(db)
+	categories := NewGormCategoryRepository(db)
+
+	newCategory := &Category{
+		Name:        "Clothing",
+		Description: "All kinds of clothing",
+	}
+
+	createdCategory, err := categories.CreateCategory(newCategory)
+
+	assert.NoError(t, err)
+	assert.NotNil(t, createdCategory)
+	assert.Equal(t, newCategory.Name, createdCategory.Name)
+	assert.Equal(t, newCategory.Description, createdCategory.Description)
+	assert.Nil(t, createdCategory.ParentID)
+}
+
+func TestShouldCreateCategoryThrowAnErrorIfTryingToPassAnInvalidCategory(t *testing.T) {
+	db := setupDatabase(t)
+	defer teardownDatabase(db)
+	categories := NewGormCategoryRepository(db)
+
+	createdCategory, err := categories.CreateCategory(nil)
+
+	assert.Error(t, err)
+	assert.Nil(t, createdCategory)
+	assert.Equal(t, "invalid category", err.Error())
+}
+
+func TestFindCategoryByNameShouldMatchOnlyWithinTheSameParent(t *testing.T) {
+	db := setupDatabase(t)
+	defer teardownDatabase(db)
+	categories := NewGormCategoryRepository(db)
+
+	parent, _ := categories.CreateCategory(&Category{Name: "Men"})
+	categories.CreateCategory(&Category{Name: "Shoes", ParentID: &parent.ID})
+
+	child, err1 := categories.FindCategoryByName("Shoes", &parent.ID)
+	root, err2 := categories.FindCategoryByName("Shoes", nil)
+
+	assert.NoError(t, err1)
+	assert.NoError(t, err2)
+	assert.NotNil(t, child)
+	assert.Nil(t, root)
+}
+
+func TestGetCategoryDescendantIdsShouldWorkCorrectly(t *testing.T) {
+	db := setupDatabase(t)
+	defer teardownDatabase(db)
+	categories := NewGormCategoryRepository(db)
+
+	root, _ := categories.CreateCategory(&Category{Name: "Clothing"})
+	men, _ := categories.CreateCategory(&Category{Name: "Men", ParentID: &root.ID})
+	shoes, _ := categories.CreateCategory(&Category{Name: "Shoes", ParentID: &men.ID})
+	categories.CreateCategory(&Category{Name: "Electronics"})
+
+	ids, err := categories.GetCategoryDescendantIds(int32(root.ID))
+
+	assert.NoError(t, err)
+	assert.ElementsMatch(t, []int64{root.ID, men.ID, shoes.ID}, ids)
+}
+
+func TestGetCategoryDescendantIdsShouldThrowAnErrorIfCategoryDoesNotExist(t *testing.T) {
+	db := setupDatabase(t)
+	defer teardownDatabase(db)
+	categories := NewGormCategoryRepository(db)
+
+	ids, err := categories.GetCategoryDescendantIds(1001)
+
+	assert.Error(t, err)
+	assert.Nil(t, ids)
+	assert.Equal(t, gorm.ErrRecordNotFound, err)
+}
+
+func TestShouldDeleteCategoryThrowAnErrorIfItHasSubCategories(t *testing.T) {
+	db := setupDatabase(t)
+	defer teardownDatabase(db)
+	categories := NewGormCategoryRepository(db)
+
+	root, _ := categories.CreateCategory(&Category{Name: "Clothing"})
+	categories.CreateCategory(&Category{Name: "Men", ParentID: &root.ID})
+
+	err := categories.DeleteCategory(int32(root.ID))
+
+	assert.Error(t, err)
+	assert.Equal(t, "category has sub-categories", err.Error())
+}
+
+func TestShouldDeleteCategoryWorkCorrectly(t *testing.T) {
+	db := setupDatabase(t)
+	defer teardownDatabase(db)
+	categories := NewGormCategoryRepository(db)
+
+	products.CreateProduct(&Product{Name: "Test Product", PriceMinor: 999, Quantity: 10})
+	category, _ := categories.CreateCategory(&Category{Name: "Clothing"})
+	categories.AddProductToCategory(1, int32(category.ID))
+
+	err1 := categories.DeleteCategory(int32(category.ID))
+	deletedCategory, err2 := categories.GetCategory(int32(category.ID))
+
+	var links int64
+	db.Model(&ProductCategory{}).Count(&links)
+
+	assert.NoError(t, err1)
+	assert.Error(t, err2)
+	assert.Nil(t, deletedCategory)
+	assert.Equal(t, int64(0), links)
+}
+
+func TestShouldDeleteCategoryThrowAnErrorIfCategoryDoesNotExist(t *testing.T) {
+	db := setupDatabase(t)
+	defer teardownDatabase(db)
+	categories := NewGormCategoryRepository(db)
+
+	err := categories.DeleteCategory(1001)
+
+	assert.Error(t, err)
+	assert.Equal(t, "category with id 1001 does not exist", err.Error())
+}
+
+func TestGetCategoryProductIdsShouldWorkCorrectly(t *testing.T) {
+	db := setupDatabase(t)
+	defer teardownDatabase(db)
+	categories := NewGormCategoryRepository(db)
+
+	products.CreateProduct(&Product{Name: "Test Product 1", PriceMinor: 999, Quantity: 10})
+	products.CreateProduct(&Product{Name: "Test Product 2", PriceMinor: 999, Quantity: 10})
+	category, _ := categories.CreateCategory(&Category{Name: "Clothing"})
+
+	err1 := categories.AddProductToCategory(1, int32(category.ID))
+	err2 := categories.AddProductToCategory(1, int32(category.ID))
+	productIds, err3 := categories.GetCategoryProductIds([]int64{category.ID})
+
+	assert.NoError(t, err1)
+	assert.NoError(t, err2)
+	assert.NoError(t, err3)
+	assert.Equal(t, []int64{1}, productIds)
+}
+
+func TestAddProductToCategoryShouldThrowAnErrorIfProductDoesNotExist(t *testing.T) {
+	db := setupDatabase(t)
+	defer teardownDatabase(db)
+	categories := NewGormCategoryRepository(db)
+
+	category, _ := categories.CreateCategory(&Category{Name: "Clothing"})
+
+	err := categories.AddProductToCategory(1001, int32(category.ID))
+
+	assert.Error(t, err)
+	assert.Equal(t, gorm.ErrRecordNotFound, err)
+}
+
+func TestRemoveProductFromCategoryShouldWorkCorrectly(t *testing.T) {
+	db := setupDatabase(t)
+	defer teardownDatabase(db)
+	categories := NewGormCategoryRepository(db)
+
+	products.CreateProduct(&Product{Name: "Test Product", PriceMinor: 999, Quantity: 10})
+	category, _ := categories.CreateCategory(&Category{Name: "Clothing"})
+	categories.AddProductToCategory(1, int32(category.ID))
+
+	err1 := categories.RemoveProductFromCategory(1, int32(category.ID))
+	err2 := categories.RemoveProductFromCategory(1, int32(category.ID))
+
+	assert.NoError(t, err1)
+	assert.Error(t, err2)
+	assert.Equal(t, "product with id 1 is not in category with id 1", err2.Error())
+}
@@ -0,0 +1,56 @@
+package models
+
+import (
+	"errors"
+	"fmt"
+
+	"gorm.io/gorm"
+)
+
+var (
+	ErrNotFound           = errors.New("not found")
+	ErrAlreadyExists      = errors.New("already exists")
+	ErrFailedPrecondition = errors.New("failed precondition")
+	ErrInvalidArgument    = errors.New("invalid argument")
+)
+
+type kindError struct {
+	kind    error
+	message string
+}
+
+func (err *kindError) Error() string {
+	return err.message
+}
+
+func (err *kindError) Unwrap() error {
+	return err.kind
+}
+
+func NotFoundError(format string, args ...interface{}) error {
+	return &kindError{kind: ErrNotFound, message: fmt.Sprintf(format, args...)}
+}
+
+func AlreadyExistsError(format string, args ...interface{}) error {
+	return &kindError{kind: ErrAlreadyExists, message: fmt.Sprintf(format, args...)}
+}
+
+func FailedPreconditionError(format string, args ...interface{}) error {
+	return &kindError{kind: ErrFailedPrecondition, message: fmt.Sprintf(format, args...)}
+}
+
+func InvalidArgumentError(format string, args ...interface{}) error {
+	return &kindError{kind: ErrInvalidArgument, message: fmt.Sprintf(format, args...)}
+}
+
+// isUniqueViolation reports whether err breaks a unique index, whether or not
+// db was opened with error translation.
+func isUniqueViolation(db *gorm.DB, err error) bool {
+	if errors.Is(err, gorm.ErrDuplicatedKey) {
+		return true
+	}
+
+	translator, ok := db.Dialector.(gorm.ErrorTranslator)
+
+	return ok && errors.Is(translator.Translate(err), gorm.ErrDuplicatedKey)
+}
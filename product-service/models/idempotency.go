@@ -0,0 +1,99 @@
+package models
+
+import (
+	"time"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type IdempotencyRecord struct {
+	Caller       string    `gorm:"column:caller;primaryKey;size:255"`
+	Key          string    `gorm:"column:idempotency_key;primaryKey;size:255"`
+	Method       string    `gorm:"column:method;not null"`
+	Fingerprint  string    `gorm:"column:fingerprint;not null"`
+	ResponseType string    `gorm:"column:response_type"`
+	Response     []byte    `gorm:"column:response"`
+	LockedUntil  time.Time `gorm:"column:locked_until;not null"`
+	CreatedAt    time.Time `gorm:"column:created_at;not null;index"`
+}
+
+type GormIdempotencyRepository struct {
+	db *gorm.DB
+}
+
+func NewGormIdempotencyRepository(dbInstance *gorm.DB) *GormIdempotencyRepository {
+	return &GormIdempotencyRepository{db: dbInstance}
+}
+
+func InitIdempotencyModel(db *gorm.DB) error {
+	// Keys used to be global. Records only live for the retention window, so
+	// a table without the caller column is recreated instead of migrated.
+	if db.Migrator().HasTable(&IdempotencyRecord{}) && !db.Migrator().HasColumn(&IdempotencyRecord{}, "Caller") {
+		if err := db.Migrator().DropTable(&IdempotencyRecord{}); err != nil {
+			return err
+		}
+	}
+
+	return db.AutoMigrate(&IdempotencyRecord{})
+}
+
+// ReserveIdempotencyKey claims the key for the caller until the lease runs
+// out. A reservation that was never completed or released, because the
+// process died mid-request, can be claimed again once its lease expired.
+func (repository *GormIdempotencyRepository) ReserveIdempotencyKey(caller string, key string, method string, fingerprint string, lease time.Duration) (*IdempotencyRecord, bool, error) {
+	now := time.Now()
+	record := IdempotencyRecord{Caller: caller, Key: key, Method: method, Fingerprint: fingerprint, LockedUntil: now.Add(lease), CreatedAt: now}
+
+	result := repository.db.Clauses(clause.OnConflict{DoNothing: true}).Create(&record)
+
+	if result.Error != nil {
+		return nil, false, result.Error
+	}
+
+	if result.RowsAffected == 1 {
+		return &record, true, nil
+	}
+
+	result = repository.db.Model(&IdempotencyRecord{}).
+		Where("caller = ? AND idempotency_key = ? AND response IS NULL AND locked_until < ?", caller, key, now).
+		Updates(map[string]interface{}{
+			"method":       method,
+			"fingerprint":  fingerprint,
+			"locked_until": record.LockedUntil,
+			"created_at":   now,
+		})
+
+	if result.Error != nil {
+		return nil, false, result.Error
+	}
+
+	if result.RowsAffected == 1 {
+		return &record, true, nil
+	}
+
+	var existing IdempotencyRecord
+
+	if err := repository.db.Where("caller = ? AND idempotency_key = ?", caller, key).First(&existing).Error; err != nil {
+		return nil, false, err
+	}
+
+	return &existing, false, nil
+}
+
+func (repository *GormIdempotencyRepository) CompleteIdempotencyKey(caller string, key string, responseType string, response []byte) error {
+	return repository.db.Model(&IdempotencyRecord{}).Where("caller = ? AND idempotency_key = ?", caller, key).Updates(map[string]interface{}{
+		"response_type": responseType,
+		"response":      response,
+	}).Error
+}
+
+func (repository *GormIdempotencyRepository) ReleaseIdempotencyKey(caller string, key string) error {
+	return repository.db.Where("caller = ? AND idempotency_key = ? AND response IS NULL", caller, key).Delete(&IdempotencyRecord{}).Error
+}
+
+func (repository *GormIdempotencyRepository) PurgeIdempotencyKeys(before time.Time) (int64, error) {
+	result := repository.db.Where("created_at < ?", before).Delete(&IdempotencyRecord{})
+
+	return result.RowsAffected, result.Error
+}
@@ -0,0 +1,410 @@
+package models
+
+import (
+	"errors"
+	"sort"
+	"sync"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+var (
+	ErrStockLocationUnsupported = FailedPreconditionError("the in-memory repository does not track skus or warehouses")
+)
+
+// MemoryProductRepository keeps products in memory. Only the product rows are
+// covered: it has no SKUs or warehouses, so stock is tracked per product and a
+// stock change naming a SKU or warehouse fails with
+// ErrStockLocationUnsupported, and it records neither outbox events nor price
+// history.
+type MemoryProductRepository struct {
+	mutex    sync.Mutex
+	nextID   int64
+	products map[int64]*Product
+}
+
+func NewMemoryProductRepository() *MemoryProductRepository {
+	return &MemoryProductRepository{nextID: 1, products: make(map[int64]*Product)}
+}
+
+func (repository *MemoryProductRepository) CreateProduct(newProduct *Product) (*Product, error) {
+	if newProduct == nil {
+		return nil, InvalidArgumentError("invalid product")
+	}
+
+	repository.mutex.Lock()
+	defer repository.mutex.Unlock()
+
+	if repository.activeByName(newProduct.Name) != nil {
+		return nil, errors.New("error in creating a new product")
+	}
+
+	now := time.Now()
+
+	newProduct.ID = repository.nextID
+	newProduct.Version = 1
+	newProduct.CreatedAt = now
+	newProduct.UpdatedAt = now
+	repository.nextID++
+
+	stored := *newProduct
+	repository.products[stored.ID] = &stored
+
+	return newProduct, nil
+}
+
+func (repository *MemoryProductRepository) GetAllProducts() ([]Product, error) {
+	repository.mutex.Lock()
+	defer repository.mutex.Unlock()
+
+	return repository.list(func(product *Product) bool { return !product.DeletedAt.Valid }), nil
+}
+
+func (repository *MemoryProductRepository) GetProduct(id int32) (*Product, error) {
+	repository.mutex.Lock()
+	defer repository.mutex.Unlock()
+
+	product, ok := repository.products[int64(id)]
+
+	if !ok || product.DeletedAt.Valid {
+		return nil, gorm.ErrRecordNotFound
+	}
+
+	copied := *product
+
+	return &copied, nil
+}
+
+func (repository *MemoryProductRepository) GetProductsByIds(ids []int64) ([]Product, error) {
+	repository.mutex.Lock()
+	defer repository.mutex.Unlock()
+
+	wanted := make(map[int64]bool, len(ids))
+
+	for _, id := range ids {
+		wanted[id] = true
+	}
+
+	return repository.list(func(product *Product) bool { return !product.DeletedAt.Valid && wanted[product.ID] }), nil
+}
+
+func (repository *MemoryProductRepository) GetLowStockProducts() ([]Product, error) {
+	repository.mutex.Lock()
+	defer repository.mutex.Unlock()
+
+	return repository.list(func(product *Product) bool {
+		return !product.DeletedAt.Valid && product.ReorderThreshold > 0 && product.Quantity <= product.ReorderThreshold
+	}), nil
+}
+
+func (repository *MemoryProductRepository) FindProductByName(name string) (*Product, error) {
+	repository.mutex.Lock()
+	defer repository.mutex.Unlock()
+
+	product := repository.activeByName(name)
+
+	if product == nil {
+		return nil, nil
+	}
+
+	copied := *product
+
+	return &copied, nil
+}
+
+func (repository *MemoryProductRepository) GetDeletedProducts() ([]Product, error) {
+	repository.mutex.Lock()
+	defer repository.mutex.Unlock()
+
+	return repository.list(func(product *Product) bool { return product.DeletedAt.Valid }), nil
+}
+
+func (repository *MemoryProductRepository) RestoreProduct(id int32) (*Product, error) {
+	repository.mutex.Lock()
+
+	product, ok := repository.products[int64(id)]
+
+	if !ok || !product.DeletedAt.Valid {
+		repository.mutex.Unlock()
+		return nil, NotFoundError("deleted product with id %d does not exist", id)
+	}
+
+	if repository.activeByName(product.Name) != nil {
+		repository.mutex.Unlock()
+		return nil, AlreadyExistsError("product with the same name already exists")
+	}
+
+	product.DeletedAt = gorm.DeletedAt{}
+	product.Version++
+	repository.mutex.Unlock()
+
+	return repository.GetProduct(id)
+}
+
+func (repository *MemoryProductRepository) PurgeProduct(id int32) error {
+	repository.mutex.Lock()
+	defer repository.mutex.Unlock()
+
+	product, ok := repository.products[int64(id)]
+
+	if !ok {
+		return NotFoundError("product with id %d does not exist", id)
+	}
+
+	if !product.DeletedAt.Valid {
+		return FailedPreconditionError("only deleted products can be purged")
+	}
+
+	delete(repository.products, int64(id))
+
+	return nil
+}
+
+func (repository *MemoryProductRepository) UpdateProductDetails(id int32, fields map[string]interface{}, expectedVersion int64) (*Product, error) {
+	if len(fields) == 0 {
+		return nil, InvalidArgumentError("no product details to update")
+	}
+
+	repository.mutex.Lock()
+
+	product, err := repository.active(int64(id), expectedVersion)
+
+	if err != nil {
+		repository.mutex.Unlock()
+		return nil, err
+	}
+
+	updated := *product
+
+	if err := repository.applyFields(&updated, fields); err != nil {
+		repository.mutex.Unlock()
+		return nil, err
+	}
+
+	updated.Version++
+	updated.UpdatedAt = time.Now()
+	*product = updated
+	repository.mutex.Unlock()
+
+	return repository.GetProduct(id)
+}
+
+func (repository *MemoryProductRepository) UpdateProductDetailsAndStock(id int32, fields map[string]interface{}, quantityDelta int32) (*Product, error) {
+	if len(fields) == 0 && quantityDelta == 0 {
+		return nil, InvalidArgumentError("no product details to update")
+	}
+
+	repository.mutex.Lock()
+
+	product, err := repository.active(int64(id), 0)
+
+	if err != nil {
+		repository.mutex.Unlock()
+		return nil, err
+	}
+
+	updated := *product
+
+	if err := repository.applyFields(&updated, fields); err != nil {
+		repository.mutex.Unlock()
+		return nil, err
+	}
+
+	if updated.Quantity+quantityDelta < 0 {
+		repository.mutex.Unlock()
+		return nil, FailedPreconditionError("too many products to be removed")
+	}
+
+	updated.Quantity += quantityDelta
+	updated.Version++
+	updated.UpdatedAt = time.Now()
+	*product = updated
+	repository.mutex.Unlock()
+
+	return repository.GetProduct(id)
+}
+
+func (repository *MemoryProductRepository) DeleteProduct(id int32, expectedVersion int64) error {
+	repository.mutex.Lock()
+	defer repository.mutex.Unlock()
+
+	product, err := repository.active(int64(id), expectedVersion)
+
+	if err != nil {
+		if errors.Is(err, ErrVersionMismatch) {
+			return err
+		}
+
+		return NotFoundError("product with id %d does not exist", id)
+	}
+
+	product.DeletedAt = gorm.DeletedAt{Time: time.Now(), Valid: true}
+
+	return nil
+}
+
+func (repository *MemoryProductRepository) AddProducts(id int32, skuID int32, warehouseID int32, quantity int32, expectedVersion int64) (*Product, error) {
+	if quantity <= 0 {
+		return nil, InvalidArgumentError("quantity added cannot be less than 0")
+	}
+
+	if err := repository.changeQuantity(int64(id), skuID, warehouseID, quantity, expectedVersion); err != nil {
+		return nil, err
+	}
+
+	return repository.GetProduct(id)
+}
+
+func (repository *MemoryProductRepository) RemoveProducts(id int32, skuID int32, warehouseID int32, quantity int32, expectedVersion int64) (*Product, error) {
+	if quantity <= 0 {
+		return nil, InvalidArgumentError("quantity removed cannot be less than 0")
+	}
+
+	if err := repository.changeQuantity(int64(id), skuID, warehouseID, -quantity, expectedVersion); err != nil {
+		if errors.Is(err, ErrInsufficientStock) {
+			return nil, FailedPreconditionError("too many products to be removed")
+		}
+
+		return nil, err
+	}
+
+	return repository.GetProduct(id)
+}
+
+func (repository *MemoryProductRepository) UpdateProducts(ids []int64, skuIds []int64, quantities []int32, strategy string, destination *Location) error {
+	if len(ids) == 0 {
+		return InvalidArgumentError("empty id set passed")
+	}
+
+	if len(ids) != len(quantities) || (skuIds != nil && len(skuIds) != len(ids)) {
+		return InvalidArgumentError("items no.s are mismatched")
+	}
+
+	if _, err := ParseAllocationStrategy(strategy, AllocationPriority); err != nil {
+		return err
+	}
+
+	repository.mutex.Lock()
+	defer repository.mutex.Unlock()
+
+	remaining := make(map[int64]int32)
+
+	for idx, id := range ids {
+		product, err := repository.active(id, 0)
+
+		if err != nil {
+			return InvalidArgumentError("some ids are invalid")
+		}
+
+		if skuIds != nil && skuIds[idx] != 0 {
+			return ErrStockLocationUnsupported
+		}
+
+		if _, ok := remaining[id]; !ok {
+			remaining[id] = product.Quantity
+		}
+
+		if remaining[id] < quantities[idx] {
+			return FailedPreconditionError("trying to order more items than there is in the inventory")
+		}
+
+		remaining[id] -= quantities[idx]
+	}
+
+	for idx, id := range ids {
+		product := repository.products[id]
+		product.Quantity -= quantities[idx]
+		product.Version++
+	}
+
+	return nil
+}
+
+func (repository *MemoryProductRepository) changeQuantity(id int64, skuID int32, warehouseID int32, delta int32, expectedVersion int64) error {
+	repository.mutex.Lock()
+	defer repository.mutex.Unlock()
+
+	if skuID != 0 || warehouseID != 0 {
+		return ErrStockLocationUnsupported
+	}
+
+	product, err := repository.active(id, expectedVersion)
+
+	if err != nil {
+		return err
+	}
+
+	if product.Quantity+delta < 0 {
+		return ErrInsufficientStock
+	}
+
+	product.Quantity += delta
+	product.Version++
+	product.UpdatedAt = time.Now()
+
+	return nil
+}
+
+func (repository *MemoryProductRepository) applyFields(product *Product, fields map[string]interface{}) error {
+	for field, value := range fields {
+		switch field {
+		case "name":
+			product.Name = value.(string)
+		case "description":
+			product.Description = value.(string)
+		case "price_minor":
+			product.PriceMinor = value.(int64)
+		case "currency":
+			product.Currency = value.(string)
+		case "reorder_threshold":
+			product.ReorderThreshold = value.(int32)
+		default:
+			return errors.New("failed to update product details")
+		}
+	}
+
+	if existing := repository.activeByName(product.Name); existing != nil && existing.ID != product.ID {
+		return AlreadyExistsError("product with the same name already exists")
+	}
+
+	return nil
+}
+
+func (repository *MemoryProductRepository) active(id int64, expectedVersion int64) (*Product, error) {
+	product, ok := repository.products[id]
+
+	if !ok || product.DeletedAt.Valid {
+		return nil, gorm.ErrRecordNotFound
+	}
+
+	if expectedVersion != 0 && product.Version != expectedVersion {
+		return nil, ErrVersionMismatch
+	}
+
+	return product, nil
+}
+
+func (repository *MemoryProductRepository) activeByName(name string) *Product {
+	for _, product := range repository.products {
+		if product.Name == name && !product.DeletedAt.Valid {
+			return product
+		}
+	}
+
+	return nil
+}
+
+func (repository *MemoryProductRepository) list(include func(product *Product) bool) []Product {
+	var products []Product
+
+	for _, product := range repository.products {
+		if include(product) {
+			products = append(products, *product)
+		}
+	}
+
+	sort.Slice(products, func(i, j int) bool { return products[i].ID < products[j].ID })
+
+	return products
+}
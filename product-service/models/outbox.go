@@ -0,0 +1,142 @@
+package models
+
+import (
+	"encoding/json"
+	"product-service/events"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+type OutboxEvent struct {
+	ID           int64      `gorm:"primarykey;AUTO_INCREMENT"`
+	EventType    string     `gorm:"column:event_type;not null"`
+	AggregateID  int64      `gorm:"column:aggregate_id;not null;index"`
+	Payload      string     `gorm:"column:payload;type:text;not null"`
+	CreatedAt    time.Time  `gorm:"column:created_at;not null"`
+	PublishedAt  *time.Time `gorm:"column:published_at;index"`
+	Attempts     int32      `gorm:"column:attempts;not null;default:0"`
+	LastError    string     `gorm:"column:last_error"`
+	ClaimedBy    string     `gorm:"column:claimed_by"`
+	ClaimedUntil *time.Time `gorm:"column:claimed_until"`
+	ParkedAt     *time.Time `gorm:"column:parked_at;index"`
+}
+
+type GormOutboxRepository struct {
+	db *gorm.DB
+}
+
+func NewGormOutboxRepository(dbInstance *gorm.DB) *GormOutboxRepository {
+	return &GormOutboxRepository{db: dbInstance}
+}
+
+func InitOutboxModel(db *gorm.DB) error {
+	return db.AutoMigrate(&OutboxEvent{})
+}
+
+// ClaimPendingEvents leases up to limit unpublished, unparked events to the
+// claimant. The claim is a conditional update rather than SELECT ... FOR
+// UPDATE SKIP LOCKED so it also works on SQLite; a row claimed by another
+// relay is left alone until its lease runs out.
+func (repository *GormOutboxRepository) ClaimPendingEvents(claimant string, limit int, lease time.Duration) ([]OutboxEvent, error) {
+	now := time.Now()
+	claimable := repository.db.Model(&OutboxEvent{}).
+		Select("id").
+		Where("published_at IS NULL AND parked_at IS NULL AND (claimed_until IS NULL OR claimed_until < ?)", now).
+		Order("id").
+		Limit(limit)
+
+	err := repository.db.Model(&OutboxEvent{}).
+		Where("id IN (?) AND (claimed_until IS NULL OR claimed_until < ?)", claimable, now).
+		Updates(map[string]interface{}{
+			"claimed_by":    claimant,
+			"claimed_until": now.Add(lease),
+		}).Error
+
+	if err != nil {
+		return nil, err
+	}
+
+	var claimed []OutboxEvent
+
+	if err := repository.db.Where("claimed_by = ? AND published_at IS NULL AND parked_at IS NULL", claimant).Order("id").Find(&claimed).Error; err != nil {
+		return nil, err
+	}
+
+	return claimed, nil
+}
+
+// ReleaseClaimedEvents hands the claimant's unpublished events back so the
+// next relay does not have to wait for their lease to run out.
+func (repository *GormOutboxRepository) ReleaseClaimedEvents(claimant string) error {
+	return repository.db.Model(&OutboxEvent{}).Where("claimed_by = ? AND published_at IS NULL", claimant).Updates(map[string]interface{}{
+		"claimed_by":    "",
+		"claimed_until": nil,
+	}).Error
+}
+
+func (repository *GormOutboxRepository) GetParkedEvents() ([]OutboxEvent, error) {
+	var parked []OutboxEvent
+
+	if err := repository.db.Where("parked_at IS NOT NULL").Order("id").Find(&parked).Error; err != nil {
+		return nil, err
+	}
+
+	return parked, nil
+}
+
+func (repository *GormOutboxRepository) MarkEventPublished(id int64, at time.Time) error {
+	return repository.db.Model(&OutboxEvent{}).Where("id = ?", id).Updates(map[string]interface{}{
+		"published_at": at,
+		"attempts":     gorm.Expr("attempts + 1"),
+		"last_error":   "",
+	}).Error
+}
+
+// MarkEventFailed records a failed publish and parks the event once it has
+// been attempted maxAttempts times, so it stops blocking the events after it.
+// It reports whether the event was parked.
+func (repository *GormOutboxRepository) MarkEventFailed(id int64, cause error, maxAttempts int32) (bool, error) {
+	err := repository.db.Model(&OutboxEvent{}).Where("id = ?", id).Updates(map[string]interface{}{
+		"attempts":   gorm.Expr("attempts + 1"),
+		"last_error": cause.Error(),
+		"parked_at":  gorm.Expr("CASE WHEN attempts + 1 >= ? THEN ? END", maxAttempts, time.Now()),
+	}).Error
+
+	if err != nil {
+		return false, err
+	}
+
+	var outboxEvent OutboxEvent
+
+	if err := repository.db.First(&outboxEvent, id).Error; err != nil {
+		return false, err
+	}
+
+	return outboxEvent.ParkedAt != nil, nil
+}
+
+func (outboxEvent *OutboxEvent) ToEvent() events.Event {
+	return events.Event{
+		ID:          outboxEvent.ID,
+		Type:        events.Type(outboxEvent.EventType),
+		AggregateID: outboxEvent.AggregateID,
+		Payload:     json.RawMessage(outboxEvent.Payload),
+		OccurredAt:  outboxEvent.CreatedAt,
+	}
+}
+
+func enqueueEvent(tx *gorm.DB, eventType events.Type, aggregateID int64, payload interface{}) error {
+	bytes, err := json.Marshal(payload)
+
+	if err != nil {
+		return err
+	}
+
+	return tx.Create(&OutboxEvent{
+		EventType:   string(eventType),
+		AggregateID: aggregateID,
+		Payload:     string(bytes),
+		CreatedAt:   time.Now(),
+	}).Error
+}
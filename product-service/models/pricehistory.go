@@ -0,0 +1,154 @@
+package models
+
+import (
+	"errors"
+	"fmt"
+	"log/slog"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+var (
+	errProductDeleted = errors.New("product has been deleted")
+)
+
+type PriceChange struct {
+	gorm.Model
+	ID          int64      `gorm:"primarykey;AUTO_INCREMENT"`
+	ProductID   int64      `gorm:"column:product_id;not null;index"`
+	PriceMinor  int64      `gorm:"column:price_minor;not null"`
+	Currency    string     `gorm:"column:currency;size:3;not null"`
+	EffectiveAt time.Time  `gorm:"column:effective_at;not null;index"`
+	AppliedAt   *time.Time `gorm:"column:applied_at;index"`
+}
+
+type GormPriceRepository struct {
+	db *gorm.DB
+}
+
+func NewGormPriceRepository(dbInstance *gorm.DB) *GormPriceRepository {
+	return &GormPriceRepository{db: dbInstance}
+}
+
+func InitPriceHistoryModel(db *gorm.DB) error {
+	if err := db.AutoMigrate(&PriceChange{}); err != nil {
+		return err
+	}
+
+	if err := seedPriceHistory(db); err != nil {
+		return fmt.Errorf("failed to seed the price history: %w", err)
+	}
+
+	return nil
+}
+
+func seedPriceHistory(db *gorm.DB) error {
+	return db.Exec("INSERT INTO price_changes (created_at, updated_at, product_id, price_minor, currency, effective_at, applied_at) SELECT created_at, created_at, id, price_minor, currency, created_at, created_at FROM products WHERE NOT EXISTS (SELECT 1 FROM price_changes WHERE price_changes.product_id = products.id)").Error
+}
+
+func (repository *GormPriceRepository) SchedulePriceChange(productID int32, priceMinor int64, currency string, effectiveAt time.Time) (*PriceChange, error) {
+	if _, err := findProduct(repository.db, productID); err != nil {
+		return nil, err
+	}
+
+	change := PriceChange{
+		ProductID:   int64(productID),
+		PriceMinor:  priceMinor,
+		Currency:    currency,
+		EffectiveAt: effectiveAt,
+	}
+
+	if err := repository.db.Create(&change).Error; err != nil {
+		slog.Error("failed to schedule price change", "operation", "create", "entity", "price_change", "product_id", productID, "error", err)
+		return nil, fmt.Errorf("error in scheduling the price change: %w", err)
+	}
+
+	return &change, nil
+}
+
+func (repository *GormPriceRepository) GetPriceHistory(productID int32) ([]PriceChange, error) {
+	var changes []PriceChange
+
+	if err := repository.db.Where("product_id = ?", productID).Order("effective_at, id").Find(&changes).Error; err != nil {
+		return nil, err
+	}
+
+	return changes, nil
+}
+
+func (repository *GormPriceRepository) GetEffectivePrice(productID int32, at time.Time) (*PriceChange, error) {
+	var change *PriceChange
+
+	result := repository.db.Where("product_id = ? AND effective_at <= ?", productID, at).Order("effective_at DESC, id DESC").Limit(1).Find(&change)
+
+	if result.Error != nil {
+		return nil, result.Error
+	}
+
+	if result.RowsAffected == 0 {
+		return nil, NotFoundError("no price recorded for product with id %d at %s", productID, at.UTC().Format(time.RFC3339))
+	}
+
+	return change, nil
+}
+
+// ApplyDuePriceChanges applies pending price changes for active products.
+// Changes for deleted products stay pending until the product is restored.
+func (repository *GormPriceRepository) ApplyDuePriceChanges(now time.Time) (int, error) {
+	var due []PriceChange
+
+	activeProducts := repository.db.Model(&Product{}).Select("id")
+
+	if err := repository.db.Where("applied_at IS NULL AND effective_at <= ? AND product_id IN (?)", now, activeProducts).Order("effective_at, id").Find(&due).Error; err != nil {
+		return 0, err
+	}
+
+	applied := 0
+
+	for _, change := range due {
+		err := repository.db.Transaction(func(tx *gorm.DB) error {
+			result := tx.Model(&PriceChange{}).Where("id = ? AND applied_at IS NULL", change.ID).Update("applied_at", now)
+
+			if result.Error != nil || result.RowsAffected == 0 {
+				return result.Error
+			}
+
+			result = tx.Model(&Product{}).Where("id = ?", change.ProductID).Updates(map[string]interface{}{
+				"price_minor": change.PriceMinor,
+				"currency":    change.Currency,
+				"version":     gorm.Expr("version + 1"),
+			})
+
+			if result.Error != nil {
+				return result.Error
+			}
+
+			if result.RowsAffected == 0 {
+				return errProductDeleted
+			}
+
+			applied++
+
+			return nil
+		})
+
+		if err != nil && !errors.Is(err, errProductDeleted) {
+			return 0, err
+		}
+	}
+
+	return applied, nil
+}
+
+func recordPrice(tx *gorm.DB, product *Product) error {
+	now := time.Now()
+
+	return tx.Create(&PriceChange{
+		ProductID:   product.ID,
+		PriceMinor:  product.PriceMinor,
+		Currency:    product.Currency,
+		EffectiveAt: now,
+		AppliedAt:   &now,
+	}).Error
+}
@@ -0,0 +1,116 @@
+package models
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestShouldRecordPriceHistoryOnCreateAndUpdate(t *testing.T) {
+	db := setupDatabase(t)
+	defer teardownDatabase(db)
+	prices := NewGormPriceRepository(db)
+
+	products.CreateProduct(&Product{Name: "Sneakers", PriceMinor: 4999, Currency: "USD"})
+	products.UpdateProductDetails(1, map[string]interface{}{"price_minor": int64(5999)}, 0)
+	products.UpdateProductDetails(1, map[string]interface{}{"description": "Running shoes"}, 0)
+
+	changes, err := prices.GetPriceHistory(1)
+
+	assert.NoError(t, err)
+	assert.Equal(t, 2, len(changes))
+	assert.Equal(t, int64(4999), changes[0].PriceMinor)
+	assert.Equal(t, int64(5999), changes[1].PriceMinor)
+	assert.NotNil(t, changes[1].AppliedAt)
+}
+
+func TestShouldApplyDuePriceChangesWorkCorrectly(t *testing.T) {
+	db := setupDatabase(t)
+	defer teardownDatabase(db)
+	prices := NewGormPriceRepository(db)
+
+	products.CreateProduct(&Product{Name: "Sneakers", PriceMinor: 4999, Currency: "USD"})
+
+	now := time.Now()
+	prices.SchedulePriceChange(1, 3999, "USD", now.Add(time.Hour))
+	prices.SchedulePriceChange(1, 2999, "USD", now.Add(2*time.Hour))
+
+	applied, err := prices.ApplyDuePriceChanges(now.Add(90 * time.Minute))
+	product, _ := products.GetProduct(1)
+
+	assert.NoError(t, err)
+	assert.Equal(t, 1, applied)
+	assert.Equal(t, int64(3999), product.PriceMinor)
+	assert.Equal(t, int64(2), product.Version)
+
+	applied, err = prices.ApplyDuePriceChanges(now.Add(90 * time.Minute))
+
+	assert.NoError(t, err)
+	assert.Equal(t, 0, applied)
+}
+
+func TestApplyDuePriceChangesShouldSkipDeletedProducts(t *testing.T) {
+	db := setupDatabase(t)
+	defer teardownDatabase(db)
+	prices := NewGormPriceRepository(db)
+
+	products.CreateProduct(&Product{Name: "Sneakers", PriceMinor: 4999, Currency: "USD"})
+
+	now := time.Now()
+	prices.SchedulePriceChange(1, 3999, "USD", now.Add(time.Hour))
+	products.DeleteProduct(1, 0)
+
+	applied, err := prices.ApplyDuePriceChanges(now.Add(2 * time.Hour))
+	deleted, _ := products.GetDeletedProducts()
+	changes, _ := prices.GetPriceHistory(1)
+
+	assert.NoError(t, err)
+	assert.Equal(t, 0, applied)
+	assert.Equal(t, int64(4999), deleted[0].PriceMinor)
+	assert.Equal(t, int64(1), deleted[0].Version)
+	assert.Nil(t, changes[1].AppliedAt)
+
+	products.RestoreProduct(1)
+
+	applied, err = prices.ApplyDuePriceChanges(now.Add(2 * time.Hour))
+	product, _ := products.GetProduct(1)
+
+	assert.NoError(t, err)
+	assert.Equal(t, 1, applied)
+	assert.Equal(t, int64(3999), product.PriceMinor)
+}
+
+func TestShouldGetEffectivePriceAtTimestamp(t *testing.T) {
+	db := setupDatabase(t)
+	defer teardownDatabase(db)
+	prices := NewGormPriceRepository(db)
+
+	products.CreateProduct(&Product{Name: "Sneakers", PriceMinor: 4999, Currency: "USD"})
+
+	now := time.Now()
+	prices.SchedulePriceChange(1, 3999, "USD", now.Add(time.Hour))
+
+	current, err1 := prices.GetEffectivePrice(1, now.Add(time.Minute))
+	future, err2 := prices.GetEffectivePrice(1, now.Add(2*time.Hour))
+	past, err3 := prices.GetEffectivePrice(1, now.Add(-time.Hour))
+
+	assert.NoError(t, err1)
+	assert.NoError(t, err2)
+	assert.Error(t, err3)
+	assert.Nil(t, past)
+	assert.Equal(t, int64(4999), current.PriceMinor)
+	assert.Equal(t, int64(3999), future.PriceMinor)
+}
+
+func TestInitPriceHistoryModelShouldThrowAnErrorIfSeedingFails(t *testing.T) {
+	db := setupDatabase(t)
+	defer teardownDatabase(db)
+
+	assert.NoError(t, db.Migrator().DropTable(&Product{}))
+
+	err := InitPriceHistoryModel(db)
+
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "failed to seed the price history")
+}
@@ -3,148 +3,556 @@ package models
 import (
 	"errors"
 	"fmt"
+	"log/slog"
+	"product-service/events"
 
 	"gorm.io/gorm"
 )
 
 var (
-	db *gorm.DB
+	ErrVersionMismatch = errors.New("product has been modified by another request")
 )
 
 type Product struct {
 	gorm.Model
-	ID          int64   `gorm:"primarykey;AUTO_INCREMENT"`
-	Name        string  `gorm:"column:name;unique"`
-	Description string  `gorm:"column:description"`
-	Price       float64 `gorm:"column:price"`
-	Quantity    int32   `gorm:"column:quantity"`
+	ID               int64        `gorm:"primarykey;AUTO_INCREMENT"`
+	Name             string       `gorm:"column:name;uniqueIndex:idx_products_name_active,where:deleted_at IS NULL"`
+	Description      string       `gorm:"column:description"`
+	PriceMinor       int64        `gorm:"column:price_minor;not null;default:0"`
+	Currency         string       `gorm:"column:currency;size:3;not null;default:USD"`
+	Quantity         int32        `gorm:"column:quantity"`
+	Version          int64        `gorm:"column:version;not null;default:1"`
+	ReorderThreshold int32        `gorm:"column:reorder_threshold;not null;default:0"`
+	Skus             []Sku        `gorm:"foreignKey:ProductID"`
+	StockLevels      []StockLevel `gorm:"foreignKey:ProductID"`
 }
 
-func InitProductModel(dbInstance *gorm.DB) {
-	db = dbInstance
-	db.AutoMigrate(&Product{})
+type GormProductRepository struct {
+	db       *gorm.DB
+	strategy AllocationStrategy
 }
 
-func CreateProduct(newProduct *Product) (*Product, error) {
+// NewGormProductRepository allocates stock with strategy whenever a request
+// does not name one.
+func NewGormProductRepository(dbInstance *gorm.DB, strategy AllocationStrategy) *GormProductRepository {
+	return &GormProductRepository{db: dbInstance, strategy: strategy}
+}
+
+func InitProductModel(db *gorm.DB) error {
+	if err := dropLegacyConstraint(db, &Product{}, "products_name_key"); err != nil {
+		return fmt.Errorf("failed to drop the legacy product name constraint: %w", err)
+	}
+
+	if err := db.AutoMigrate(&Product{}); err != nil {
+		return err
+	}
+
+	if err := migrateLegacyPrices(db); err != nil {
+		return fmt.Errorf("failed to migrate legacy product prices: %w", err)
+	}
+
+	return nil
+}
+
+func dropLegacyConstraint(db *gorm.DB, model interface{}, constraint string) error {
+	if !db.Migrator().HasTable(model) || !db.Migrator().HasConstraint(model, constraint) {
+		return nil
+	}
+
+	return db.Migrator().DropConstraint(model, constraint)
+}
+
+func dropLegacyIndex(db *gorm.DB, model interface{}, index string) error {
+	if !db.Migrator().HasTable(model) || !db.Migrator().HasIndex(model, index) {
+		return nil
+	}
+
+	return db.Migrator().DropIndex(model, index)
+}
+
+func migrateLegacyPrices(db *gorm.DB) error {
+	if !db.Migrator().HasColumn(&Product{}, "price") {
+		return nil
+	}
+
+	return db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Exec("UPDATE products SET price_minor = CAST(ROUND(price * 100) AS BIGINT), currency = 'USD' WHERE price IS NOT NULL").Error; err != nil {
+			return err
+		}
+
+		return tx.Migrator().DropColumn(&Product{}, "price")
+	})
+}
+
+func (repository *GormProductRepository) CreateProduct(newProduct *Product) (*Product, error) {
 	if newProduct == nil {
-		return nil, errors.New("invalid product")
+		return nil, InvalidArgumentError("invalid product")
 	}
 
-	if err := db.Create(newProduct).Error; err != nil {
-		return nil, errors.New("error in creating a new product")
+	newProduct.Version = 1
+
+	err := repository.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Create(newProduct).Error; err != nil {
+			slog.Error("failed to create product", "operation", "create", "entity", "product", "error", err)
+			return fmt.Errorf("error in creating a new product: %w", err)
+		}
+
+		if err := recordPrice(tx, newProduct); err != nil {
+			return err
+		}
+
+		if err := enqueueEvent(tx, events.ProductCreated, newProduct.ID, events.ProductCreatedPayload{
+			ProductID:  newProduct.ID,
+			Name:       newProduct.Name,
+			PriceMinor: newProduct.PriceMinor,
+			Currency:   newProduct.Currency,
+			Quantity:   newProduct.Quantity,
+		}); err != nil {
+			return err
+		}
+
+		if newProduct.Quantity <= 0 {
+			return nil
+		}
+
+		warehouseID, err := defaultWarehouseID(tx)
+
+		if err != nil {
+			return err
+		}
+
+		return changeStock(tx, warehouseID, newProduct.ID, 0, newProduct.Quantity)
+	})
+
+	if err != nil {
+		return nil, err
 	}
 
 	return newProduct, nil
 }
 
-func GetAllProducts() ([]Product, error) {
+func (repository *GormProductRepository) GetAllProducts() ([]Product, error) {
 	var products []Product
 
-	if err := db.Find(&products).Error; err != nil {
+	if err := repository.db.Preload("Skus").Preload("StockLevels", "quantity > 0").Find(&products).Error; err != nil {
 		return nil, err
 	}
 
 	return products, nil
 }
 
-func GetProduct(id int32) (*Product, error) {
-	var product *Product
+func (repository *GormProductRepository) GetProduct(id int32) (*Product, error) {
+	return findProduct(repository.db, id)
+}
+
+func (repository *GormProductRepository) GetProductsByIds(ids []int64) ([]Product, error) {
+	var products []Product
 
-	if err := db.First(&product, id).Error; err != nil {
+	if err := repository.db.Preload("Skus").Preload("StockLevels", "quantity > 0").Where("id IN ?", ids).Find(&products).Error; err != nil {
 		return nil, err
 	}
 
-	return product, nil
+	return products, nil
 }
 
-func DeleteProduct(id int32) error {
-	if db.Delete(&Product{}, id).RowsAffected < 1 {
-		return fmt.Errorf("product with id %d does not exist", id)
+func (repository *GormProductRepository) GetLowStockProducts() ([]Product, error) {
+	var products []Product
+
+	if err := repository.db.Preload("Skus").Preload("StockLevels", "quantity > 0").Where("reorder_threshold > 0 AND quantity <= reorder_threshold").Find(&products).Error; err != nil {
+		return nil, err
 	}
 
-	return nil
+	return products, nil
 }
 
-func AddProducts(id int32, quantity int32) (*Product, error) {
+func (repository *GormProductRepository) FindProductByName(name string) (*Product, error) {
 	var product *Product
 
-	if quantity <= 0 {
-		return nil, errors.New("quantity added cannot be less than 0")
+	result := repository.db.Where("name = ?", name).Find(&product)
+
+	if result.Error != nil {
+		return nil, result.Error
 	}
 
-	if err := db.First(&product, id).Error; err != nil {
+	if result.RowsAffected == 0 {
+		return nil, nil
+	}
+
+	return product, nil
+}
+
+func (repository *GormProductRepository) GetDeletedProducts() ([]Product, error) {
+	var products []Product
+
+	if err := repository.db.Unscoped().Preload("Skus").Preload("StockLevels", "quantity > 0").Where("deleted_at IS NOT NULL").Find(&products).Error; err != nil {
 		return nil, err
 	}
 
-	product.Quantity += quantity
+	return products, nil
+}
+
+func (repository *GormProductRepository) RestoreProduct(id int32) (*Product, error) {
+	err := repository.db.Transaction(func(tx *gorm.DB) error {
+		var product Product
+
+		if err := tx.Unscoped().Where("deleted_at IS NOT NULL").First(&product, id).Error; err != nil {
+			return NotFoundError("deleted product with id %d does not exist", id)
+		}
+
+		var active int64
+
+		if err := tx.Model(&Product{}).Where("name = ?", product.Name).Count(&active).Error; err != nil {
+			return err
+		}
+
+		if active > 0 {
+			return AlreadyExistsError("product with the same name already exists")
+		}
+
+		return tx.Unscoped().Model(&Product{}).Where("id = ?", product.ID).Updates(map[string]interface{}{
+			"deleted_at": nil,
+			"version":    gorm.Expr("version + 1"),
+		}).Error
+	})
 
-	if err := db.Save(&product).Error; err != nil {
+	if err != nil {
 		return nil, err
 	}
 
-	return product, nil
+	return repository.GetProduct(id)
 }
 
-func RemoveProducts(id int32, quantity int32) (*Product, error) {
+func (repository *GormProductRepository) PurgeProduct(id int32) error {
+	return repository.db.Transaction(func(tx *gorm.DB) error {
+		var product Product
+
+		if err := tx.Unscoped().First(&product, id).Error; err != nil {
+			return NotFoundError("product with id %d does not exist", id)
+		}
+
+		if !product.DeletedAt.Valid {
+			return FailedPreconditionError("only deleted products can be purged")
+		}
+
+		if err := tx.Where("product_id = ?", product.ID).Delete(&StockLevel{}).Error; err != nil {
+			return err
+		}
+
+		if err := tx.Where("product_id = ?", product.ID).Delete(&ProductCategory{}).Error; err != nil {
+			return err
+		}
+
+		if err := tx.Unscoped().Where("product_id = ?", product.ID).Delete(&PriceChange{}).Error; err != nil {
+			return err
+		}
+
+		if err := tx.Unscoped().Where("product_id = ?", product.ID).Delete(&Sku{}).Error; err != nil {
+			return err
+		}
+
+		return tx.Unscoped().Delete(&product).Error
+	})
+}
+
+func (repository *GormProductRepository) UpdateProductDetails(id int32, fields map[string]interface{}, expectedVersion int64) (*Product, error) {
 	var product *Product
 
-	if quantity <= 0 {
-		return nil, errors.New("quantity removed cannot be less than 0")
+	if len(fields) == 0 {
+		return nil, InvalidArgumentError("no product details to update")
 	}
 
-	if err := db.First(&product, id).Error; err != nil {
+	if err := repository.db.First(&product, id).Error; err != nil {
 		return nil, err
 	}
 
-	if product.Quantity-quantity < 0 {
-		return nil, errors.New("too many products to be removed")
+	if expectedVersion != 0 && product.Version != expectedVersion {
+		return nil, ErrVersionMismatch
 	}
 
-	product.Quantity -= quantity
+	_, priceChanged := fields["price_minor"]
 
-	if err := db.Save(&product).Error; err != nil {
-		return nil, err
+	err := repository.db.Transaction(func(tx *gorm.DB) error {
+		if err := updateWithVersion(tx, product, fields); err != nil {
+			return err
+		}
+
+		if priceChanged {
+			return recordPrice(tx, product)
+		}
+
+		return nil
+	})
+
+	if err != nil {
+		if errors.Is(err, ErrVersionMismatch) {
+			return nil, err
+		}
+
+		if isUniqueViolation(repository.db, err) {
+			return nil, AlreadyExistsError("product with the same name already exists")
+		}
+
+		slog.Error("failed to update product details", "operation", "update", "entity", "product", "product_id", id, "error", err)
+		return nil, fmt.Errorf("failed to update product details: %w", err)
 	}
 
 	return product, nil
 }
 
-func UpdateProducts(ids []int64, quantities []int32) error {
-	var products []Product
+func (repository *GormProductRepository) UpdateProductDetailsAndStock(id int32, fields map[string]interface{}, quantityDelta int32) (*Product, error) {
+	if len(fields) == 0 && quantityDelta == 0 {
+		return nil, InvalidArgumentError("no product details to update")
+	}
 
-	if len(ids) == 0 {
-		return errors.New("empty id set passed")
+	err := repository.db.Transaction(func(tx *gorm.DB) error {
+		product, err := findProduct(tx, id)
+
+		if err != nil {
+			return err
+		}
+
+		if len(fields) > 0 {
+			_, priceChanged := fields["price_minor"]
+
+			if err := updateWithVersion(tx, product, fields); err != nil {
+				return err
+			}
+
+			if priceChanged {
+				if err := recordPrice(tx, product); err != nil {
+					return err
+				}
+			}
+		}
+
+		if quantityDelta == 0 {
+			return nil
+		}
+
+		if err := checkStockTarget(tx, product.ID, 0); err != nil {
+			return err
+		}
+
+		if quantityDelta > 0 {
+			warehouseID, err := defaultWarehouseID(tx)
+
+			if err != nil {
+				return err
+			}
+
+			if err := changeStock(tx, warehouseID, product.ID, 0, quantityDelta); err != nil {
+				return err
+			}
+		} else if err := allocateStock(tx, product.ID, 0, -quantityDelta, repository.strategy, nil); err != nil {
+			return err
+		}
+
+		return syncStock(tx, product.ID, 0, 0)
+	})
+
+	if err != nil {
+		if errors.Is(err, ErrInsufficientStock) {
+			return nil, FailedPreconditionError("too many products to be removed")
+		}
+
+		if isUniqueViolation(repository.db, err) {
+			return nil, AlreadyExistsError("product with the same name already exists")
+		}
+
+		return nil, err
 	}
 
-	if len(ids) != len(quantities) {
-		return errors.New("items no.s are mismatched")
+	return repository.GetProduct(id)
+}
+
+func (repository *GormProductRepository) DeleteProduct(id int32, expectedVersion int64) error {
+	return repository.db.Transaction(func(tx *gorm.DB) error {
+		query := tx
+
+		if expectedVersion != 0 {
+			query = query.Where("version = ?", expectedVersion)
+		}
+
+		if query.Delete(&Product{}, id).RowsAffected < 1 {
+			if expectedVersion != 0 && tx.First(&Product{}, id).Error == nil {
+				return ErrVersionMismatch
+			}
+
+			return NotFoundError("product with id %d does not exist", id)
+		}
+
+		return enqueueEvent(tx, events.ProductDeleted, int64(id), events.ProductDeletedPayload{ProductID: int64(id)})
+	})
+}
+
+func (repository *GormProductRepository) AddProducts(id int32, skuID int32, warehouseID int32, quantity int32, expectedVersion int64) (*Product, error) {
+	if quantity <= 0 {
+		return nil, InvalidArgumentError("quantity added cannot be less than 0")
 	}
 
-	if err := db.Where("id IN ?", ids).Find(&products).Error; err != nil {
-		return errors.New("failed to update products")
+	err := repository.db.Transaction(func(tx *gorm.DB) error {
+		var product Product
+
+		if err := tx.First(&product, id).Error; err != nil {
+			return err
+		}
+
+		if err := checkStockTarget(tx, product.ID, int64(skuID)); err != nil {
+			return err
+		}
+
+		target := int64(warehouseID)
+
+		if target == 0 {
+			defaultID, err := defaultWarehouseID(tx)
+
+			if err != nil {
+				return err
+			}
+
+			target = defaultID
+		} else if err := tx.First(&Warehouse{}, warehouseID).Error; err != nil {
+			return NotFoundError("warehouse with id %d does not exist", warehouseID)
+		}
+
+		if err := changeStock(tx, target, product.ID, int64(skuID), quantity); err != nil {
+			return err
+		}
+
+		return syncStock(tx, product.ID, int64(skuID), expectedVersion)
+	})
+
+	if err != nil {
+		return nil, err
 	}
 
-	if len(ids) != len(products) {
-		return errors.New("some ids are invalid")
+	return repository.GetProduct(id)
+}
+
+func (repository *GormProductRepository) RemoveProducts(id int32, skuID int32, warehouseID int32, quantity int32, expectedVersion int64) (*Product, error) {
+	if quantity <= 0 {
+		return nil, InvalidArgumentError("quantity removed cannot be less than 0")
 	}
 
-	for idx, quantity := range quantities {
-		if quantity > products[idx].Quantity {
-			return errors.New("trying to order more items than there is in the inventory")
+	err := repository.db.Transaction(func(tx *gorm.DB) error {
+		var product Product
+
+		if err := tx.First(&product, id).Error; err != nil {
+			return err
+		}
+
+		if err := checkStockTarget(tx, product.ID, int64(skuID)); err != nil {
+			return err
+		}
+
+		var err error
+
+		if warehouseID != 0 {
+			err = changeStock(tx, int64(warehouseID), product.ID, int64(skuID), -quantity)
+		} else {
+			err = allocateStock(tx, product.ID, int64(skuID), quantity, repository.strategy, nil)
+		}
+
+		if err != nil {
+			return err
 		}
+
+		return syncStock(tx, product.ID, int64(skuID), expectedVersion)
+	})
+
+	if err != nil {
+		if errors.Is(err, ErrInsufficientStock) {
+			return nil, FailedPreconditionError("too many products to be removed")
+		}
+
+		return nil, err
 	}
 
-	for idx, quantity := range quantities {
-		products[idx].Quantity -= quantity
+	return repository.GetProduct(id)
+}
+
+func (repository *GormProductRepository) UpdateProducts(ids []int64, skuIds []int64, quantities []int32, strategy string, destination *Location) error {
+	if len(ids) == 0 {
+		return InvalidArgumentError("empty id set passed")
 	}
 
-	return db.Transaction(func(tx *gorm.DB) error {
-		for _, product := range products {
-			if err := db.Save(&product).Error; err != nil {
-				return errors.New("failed to update products")
+	if len(ids) != len(quantities) || (skuIds != nil && len(skuIds) != len(ids)) {
+		return InvalidArgumentError("items no.s are mismatched")
+	}
+
+	allocation, err := ParseAllocationStrategy(strategy, repository.strategy)
+
+	if err != nil {
+		return err
+	}
+
+	return repository.db.Transaction(func(tx *gorm.DB) error {
+		for idx, id := range ids {
+			var product Product
+			var skuID int64
+
+			if skuIds != nil {
+				skuID = skuIds[idx]
+			}
+
+			if err := tx.First(&product, id).Error; err != nil {
+				if errors.Is(err, gorm.ErrRecordNotFound) {
+					return InvalidArgumentError("some ids are invalid")
+				}
+
+				slog.Error("failed to update products", "operation", "update_stock", "entity", "product", "product_id", id, "error", err)
+				return fmt.Errorf("failed to update products: %w", err)
+			}
+
+			if err := checkStockTarget(tx, product.ID, skuID); err != nil {
+				if errors.Is(err, gorm.ErrRecordNotFound) {
+					return InvalidArgumentError("some ids are invalid")
+				}
+
+				return err
+			}
+
+			if err := allocateStock(tx, product.ID, skuID, quantities[idx], allocation, destination); err != nil {
+				if errors.Is(err, ErrInsufficientStock) {
+					return FailedPreconditionError("trying to order more items than there is in the inventory")
+				}
+
+				slog.Error("failed to update products", "operation", "allocate_stock", "entity", "product", "product_id", product.ID, "sku_id", skuID, "error", err)
+				return fmt.Errorf("failed to update products: %w", err)
+			}
+
+			if err := syncStock(tx, product.ID, skuID, 0); err != nil {
+				slog.Error("failed to update products", "operation", "sync_stock", "entity", "product", "product_id", product.ID, "sku_id", skuID, "error", err)
+				return fmt.Errorf("failed to update products: %w", err)
 			}
 		}
 
 		return nil
 	})
 }
+
+func findProduct(tx *gorm.DB, id int32) (*Product, error) {
+	var product *Product
+
+	if err := tx.Preload("Skus").Preload("StockLevels", "quantity > 0").First(&product, id).Error; err != nil {
+		return nil, err
+	}
+
+	return product, nil
+}
+
+func updateWithVersion(tx *gorm.DB, product *Product, fields map[string]interface{}) error {
+	fields["version"] = product.Version + 1
+
+	result := tx.Model(&Product{}).Where("id = ? AND version = ?", product.ID, product.Version).Updates(fields)
+
+	if result.Error != nil {
+		return result.Error
+	}
+
+	if result.RowsAffected == 0 {
+		return ErrVersionMismatch
+	}
+
+	return tx.Preload("Skus").Preload("StockLevels", "quantity > 0").First(product, product.ID).Error
+}
@@ -1,6 +1,8 @@
 package models
 
 import (
+	"errors"
+	"strings"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
@@ -8,11 +10,22 @@ import (
 	"gorm.io/gorm"
 )
 
+var products *GormProductRepository
+
 func setupDatabase(t *testing.T) *gorm.DB {
 	db, err := gorm.Open(sqlite.Open("file:yourDbName?mode=memory&cache=shared"), &gorm.Config{})
 	assert.NoError(t, err)
 
-	InitProductModel(db)
+	assert.NoError(t, InitProductModel(db))
+	assert.NoError(t, InitCategoryModel(db))
+	assert.NoError(t, InitSkuModel(db))
+	assert.NoError(t, InitWarehouseModel(db))
+	assert.NoError(t, InitPriceHistoryModel(db))
+	assert.NoError(t, InitPromotionModel(db))
+	assert.NoError(t, InitIdempotencyModel(db))
+	assert.NoError(t, InitOutboxModel(db))
+
+	products = NewGormProductRepository(db, AllocationPriority)
 
 	return db
 }
@@ -38,17 +51,17 @@ func TestShouldCreateProductWorkCorrectly(t *testing.T) {
 	newProduct := &Product{
 		Name:        "Test Product",
 		Description: "This is a test product",
-		Price:       9.99,
+		PriceMinor:  999,
 		Quantity:    10,
 	}
 
-	createdProduct, err := CreateProduct(newProduct)
+	createdProduct, err := products.CreateProduct(newProduct)
 
 	assert.NoError(t, err)
 	assert.NotNil(t, createdProduct)
 	assert.Equal(t, newProduct.Name, createdProduct.Name)
 	assert.Equal(t, newProduct.Description, createdProduct.Description)
-	assert.Equal(t, newProduct.Price, createdProduct.Price)
+	assert.Equal(t, newProduct.PriceMinor, createdProduct.PriceMinor)
 	assert.Equal(t, newProduct.Quantity, createdProduct.Quantity)
 }
 
@@ -56,7 +69,7 @@ func TestShouldCreateProductThrowAnErrorIfTryingToPassAnInvalidProuct(t *testing
 	db := setupDatabase(t)
 	defer teardownDatabase(db)
 
-	createdProduct, err := CreateProduct(nil)
+	createdProduct, err := products.CreateProduct(nil)
 
 	assert.Error(t, err)
 	assert.Nil(t, createdProduct)
@@ -70,22 +83,22 @@ func TestShouldCreateProductThrowAnErrorIfFailedToCreateANewProduct(t *testing.T
 	newProduct := &Product{
 		Name:        "Test Product",
 		Description: "This is a test product",
-		Price:       9.99,
+		PriceMinor:  999,
 		Quantity:    10,
 	}
 
-	createdProduct1, err1 := CreateProduct(newProduct)
-	createdProduct2, err2 := CreateProduct(newProduct)
+	createdProduct1, err1 := products.CreateProduct(newProduct)
+	createdProduct2, err2 := products.CreateProduct(newProduct)
 
 	assert.NoError(t, err1)
 	assert.NotNil(t, createdProduct1)
 	assert.Equal(t, newProduct.Name, createdProduct1.Name)
 	assert.Equal(t, newProduct.Description, createdProduct1.Description)
-	assert.Equal(t, newProduct.Price, createdProduct1.Price)
+	assert.Equal(t, newProduct.PriceMinor, createdProduct1.PriceMinor)
 	assert.Equal(t, newProduct.Quantity, createdProduct1.Quantity)
 	assert.Error(t, err2)
 	assert.Nil(t, createdProduct2)
-	assert.Equal(t, "error in creating a new product", err2.Error())
+	assert.Equal(t, "error in creating a new product: UNIQUE constraint failed: products.id", err2.Error())
 }
 
 func TestGetAllProductsShouldWorkCorrectly(t *testing.T) {
@@ -95,21 +108,21 @@ func TestGetAllProductsShouldWorkCorrectly(t *testing.T) {
 	newProduct1 := &Product{
 		Name:        "Test Product",
 		Description: "This is a test product",
-		Price:       9.99,
+		PriceMinor:  999,
 		Quantity:    10,
 	}
 
 	newProduct2 := &Product{
 		Name:        "Test Product 2",
 		Description: "This is a test product 2",
-		Price:       8.75,
+		PriceMinor:  875,
 		Quantity:    4,
 	}
 
-	CreateProduct(newProduct1)
-	CreateProduct(newProduct2)
+	products.CreateProduct(newProduct1)
+	products.CreateProduct(newProduct2)
 
-	products, err := GetAllProducts()
+	products, err := products.GetAllProducts()
 
 	assert.NoError(t, err)
 	assert.NotEmpty(t, products)
@@ -117,12 +130,12 @@ func TestGetAllProductsShouldWorkCorrectly(t *testing.T) {
 	assert.NotNil(t, products[0])
 	assert.Equal(t, newProduct1.Name, products[0].Name)
 	assert.Equal(t, newProduct1.Description, products[0].Description)
-	assert.Equal(t, newProduct1.Price, products[0].Price)
+	assert.Equal(t, newProduct1.PriceMinor, products[0].PriceMinor)
 	assert.Equal(t, newProduct1.Quantity, products[0].Quantity)
 	assert.NotNil(t, products[1])
 	assert.Equal(t, newProduct2.Name, products[1].Name)
 	assert.Equal(t, newProduct2.Description, products[1].Description)
-	assert.Equal(t, newProduct2.Price, products[1].Price)
+	assert.Equal(t, newProduct2.PriceMinor, products[1].PriceMinor)
 	assert.Equal(t, newProduct2.Quantity, products[1].Quantity)
 }
 
@@ -133,13 +146,13 @@ func TestGetProductShouldWorkCorrectly(t *testing.T) {
 	newProduct := &Product{
 		Name:        "Test Product",
 		Description: "This is a test product",
-		Price:       9.99,
+		PriceMinor:  999,
 		Quantity:    10,
 	}
 
-	createdProduct, err1 := CreateProduct(newProduct)
+	createdProduct, err1 := products.CreateProduct(newProduct)
 
-	product, err2 := GetProduct(int32(createdProduct.ID))
+	product, err2 := products.GetProduct(int32(createdProduct.ID))
 
 	assert.NoError(t, err1)
 	assert.NoError(t, err2)
@@ -148,7 +161,7 @@ func TestGetProductShouldWorkCorrectly(t *testing.T) {
 	assert.Equal(t, createdProduct.ID, product.ID)
 	assert.Equal(t, createdProduct.Name, product.Name)
 	assert.Equal(t, createdProduct.Description, product.Description)
-	assert.Equal(t, createdProduct.Price, product.Price)
+	assert.Equal(t, createdProduct.PriceMinor, product.PriceMinor)
 	assert.Equal(t, createdProduct.Quantity, product.Quantity)
 }
 
@@ -156,7 +169,7 @@ func TestGetProductShouldThrowAnErrorIfTheProductDoesNotExist(t *testing.T) {
 	db := setupDatabase(t)
 	defer teardownDatabase(db)
 
-	product, err := GetProduct(100)
+	product, err := products.GetProduct(100)
 
 	assert.Error(t, err)
 	assert.Nil(t, product)
@@ -170,15 +183,15 @@ func TestShouldDeleteProductWorkCorrectly(t *testing.T) {
 	newProduct := &Product{
 		Name:        "Test Product",
 		Description: "This is a test product",
-		Price:       9.99,
+		PriceMinor:  999,
 		Quantity:    10,
 	}
 
-	createdProduct, err1 := CreateProduct(newProduct)
+	createdProduct, err1 := products.CreateProduct(newProduct)
 
-	err2 := DeleteProduct(int32(createdProduct.ID))
+	err2 := products.DeleteProduct(int32(createdProduct.ID), 0)
 
-	deletedProduct, err3 := GetProduct(int32(createdProduct.ID))
+	deletedProduct, err3 := products.GetProduct(int32(createdProduct.ID))
 
 	assert.NoError(t, err1)
 	assert.NoError(t, err2)
@@ -192,7 +205,7 @@ func TestShouldDeleteProductThrowAnErrorIfProductDoesNotExist(t *testing.T) {
 	db := setupDatabase(t)
 	defer teardownDatabase(db)
 
-	err := DeleteProduct(1001)
+	err := products.DeleteProduct(1001, 0)
 
 	assert.Error(t, err)
 	assert.Equal(t, "product with id 1001 does not exist", err.Error())
@@ -205,13 +218,13 @@ func TestAddProductsShouldWorkCorrectly(t *testing.T) {
 	newProduct := &Product{
 		Name:        "Test Product",
 		Description: "This is a test product",
-		Price:       9.99,
+		PriceMinor:  999,
 		Quantity:    10,
 	}
 
-	CreateProduct(newProduct)
+	products.CreateProduct(newProduct)
 
-	product, err := AddProducts(1, 5)
+	product, err := products.AddProducts(1, 0, 0, 5, 0)
 
 	assert.NoError(t, err)
 	assert.NotNil(t, product)
@@ -219,14 +232,14 @@ func TestAddProductsShouldWorkCorrectly(t *testing.T) {
 	assert.Equal(t, int64(1), product.ID)
 	assert.Equal(t, newProduct.Name, product.Name)
 	assert.Equal(t, newProduct.Description, product.Description)
-	assert.Equal(t, newProduct.Price, product.Price)
+	assert.Equal(t, newProduct.PriceMinor, product.PriceMinor)
 }
 
 func TestAddProductsShouldThrowAnErrorIfQuantityIsLessThanZero(t *testing.T) {
 	db := setupDatabase(t)
 	defer teardownDatabase(db)
 
-	product, err := AddProducts(1, -5)
+	product, err := products.AddProducts(1, 0, 0, -5, 0)
 
 	assert.Error(t, err)
 	assert.Nil(t, product)
@@ -237,7 +250,7 @@ func TestAddProductsShouldThrowAnErrorIfProductDoesNotExist(t *testing.T) {
 	db := setupDatabase(t)
 	defer teardownDatabase(db)
 
-	product, err := AddProducts(1, 5)
+	product, err := products.AddProducts(1, 0, 0, 5, 0)
 
 	assert.Error(t, err)
 	assert.Nil(t, product)
@@ -251,13 +264,13 @@ func TestRemoveProductsShouldWorkCorrectly(t *testing.T) {
 	newProduct := &Product{
 		Name:        "Test Product",
 		Description: "This is a test product",
-		Price:       9.99,
+		PriceMinor:  999,
 		Quantity:    15,
 	}
 
-	CreateProduct(newProduct)
+	products.CreateProduct(newProduct)
 
-	product, err := RemoveProducts(1, 5)
+	product, err := products.RemoveProducts(1, 0, 0, 5, 0)
 
 	assert.NoError(t, err)
 	assert.NotNil(t, product)
@@ -265,14 +278,14 @@ func TestRemoveProductsShouldWorkCorrectly(t *testing.T) {
 	assert.Equal(t, int64(1), product.ID)
 	assert.Equal(t, newProduct.Name, product.Name)
 	assert.Equal(t, newProduct.Description, product.Description)
-	assert.Equal(t, newProduct.Price, product.Price)
+	assert.Equal(t, newProduct.PriceMinor, product.PriceMinor)
 }
 
 func TestRemoveProductsShouldThrowErrorIfQuantityIsLessThanZero(t *testing.T) {
 	db := setupDatabase(t)
 	defer teardownDatabase(db)
 
-	product, err := RemoveProducts(1, -5)
+	product, err := products.RemoveProducts(1, 0, 0, -5, 0)
 
 	assert.Error(t, err)
 	assert.Nil(t, product)
@@ -286,13 +299,13 @@ func TestRemoveProductsShouldThrowAnErrorIfInsufficentQuantityLeft(t *testing.T)
 	newProduct := &Product{
 		Name:        "Test Product",
 		Description: "This is a test product",
-		Price:       9.99,
+		PriceMinor:  999,
 		Quantity:    5,
 	}
 
-	CreateProduct(newProduct)
+	products.CreateProduct(newProduct)
 
-	product, err := RemoveProducts(1, 10)
+	product, err := products.RemoveProducts(1, 0, 0, 10, 0)
 
 	assert.Error(t, err)
 	assert.Nil(t, product)
@@ -303,7 +316,7 @@ func TestRemoveProductsShouldThrowAnErrorIfProductDoesNotExist(t *testing.T) {
 	db := setupDatabase(t)
 	defer teardownDatabase(db)
 
-	product, err := RemoveProducts(1, 5)
+	product, err := products.RemoveProducts(1, 0, 0, 5, 0)
 
 	assert.Error(t, err)
 	assert.Nil(t, product)
@@ -317,25 +330,25 @@ func TestUpdateProductsShouldWorkCorrectly(t *testing.T) {
 	newProduct1 := &Product{
 		Name:        "Test Product",
 		Description: "This is a test product",
-		Price:       9.99,
+		PriceMinor:  999,
 		Quantity:    10,
 	}
 
 	newProduct2 := &Product{
 		Name:        "Test Product 2",
 		Description: "This is a test product 2",
-		Price:       8.75,
+		PriceMinor:  875,
 		Quantity:    4,
 	}
 
 	ids := []int64{1, 2}
 	quantities := []int32{6, 2}
 
-	CreateProduct(newProduct1)
-	CreateProduct(newProduct2)
+	products.CreateProduct(newProduct1)
+	products.CreateProduct(newProduct2)
 
-	err1 := UpdateProducts(ids, quantities)
-	products, err2 := GetAllProducts()
+	err1 := products.UpdateProducts(ids, nil, quantities, "", nil)
+	products, err2 := products.GetAllProducts()
 
 	assert.NoError(t, err1)
 	assert.NoError(t, err2)
@@ -344,12 +357,12 @@ func TestUpdateProductsShouldWorkCorrectly(t *testing.T) {
 	assert.NotNil(t, products[0])
 	assert.Equal(t, newProduct1.Name, products[0].Name)
 	assert.Equal(t, newProduct1.Description, products[0].Description)
-	assert.Equal(t, newProduct1.Price, products[0].Price)
+	assert.Equal(t, newProduct1.PriceMinor, products[0].PriceMinor)
 	assert.Equal(t, int32(4), products[0].Quantity)
 	assert.NotNil(t, products[1])
 	assert.Equal(t, newProduct2.Name, products[1].Name)
 	assert.Equal(t, newProduct2.Description, products[1].Description)
-	assert.Equal(t, newProduct2.Price, products[1].Price)
+	assert.Equal(t, newProduct2.PriceMinor, products[1].PriceMinor)
 	assert.Equal(t, int32(2), products[1].Quantity)
 }
 
@@ -360,24 +373,24 @@ func TestUpdateProductsShouldThrowErrorIfNotAllIdsAreValid(t *testing.T) {
 	newProduct1 := &Product{
 		Name:        "Test Product",
 		Description: "This is a test product",
-		Price:       9.99,
+		PriceMinor:  999,
 		Quantity:    10,
 	}
 
 	newProduct2 := &Product{
 		Name:        "Test Product 2",
 		Description: "This is a test product 2",
-		Price:       8.75,
+		PriceMinor:  875,
 		Quantity:    4,
 	}
 
 	ids := []int64{}
 	quantities := []int32{}
 
-	CreateProduct(newProduct1)
-	CreateProduct(newProduct2)
+	products.CreateProduct(newProduct1)
+	products.CreateProduct(newProduct2)
 
-	err := UpdateProducts(ids, quantities)
+	err := products.UpdateProducts(ids, nil, quantities, "", nil)
 
 	assert.Error(t, err)
 	assert.Equal(t, "empty id set passed", err.Error())
@@ -390,24 +403,24 @@ func TestUpdateProductsShouldThrowErrorIfIdsAndQuantitiesAreOfDifferentLength(t
 	newProduct1 := &Product{
 		Name:        "Test Product",
 		Description: "This is a test product",
-		Price:       9.99,
+		PriceMinor:  999,
 		Quantity:    10,
 	}
 
 	newProduct2 := &Product{
 		Name:        "Test Product 2",
 		Description: "This is a test product 2",
-		Price:       8.75,
+		PriceMinor:  875,
 		Quantity:    4,
 	}
 
 	ids := []int64{1, 2}
 	quantities := []int32{6}
 
-	CreateProduct(newProduct1)
-	CreateProduct(newProduct2)
+	products.CreateProduct(newProduct1)
+	products.CreateProduct(newProduct2)
 
-	err := UpdateProducts(ids, quantities)
+	err := products.UpdateProducts(ids, nil, quantities, "", nil)
 
 	assert.Error(t, err)
 	assert.Equal(t, "items no.s are mismatched", err.Error())
@@ -420,24 +433,24 @@ func TestUpdateProductsShouldThrowErrorIfSomeIdsAreInvalid(t *testing.T) {
 	newProduct1 := &Product{
 		Name:        "Test Product",
 		Description: "This is a test product",
-		Price:       9.99,
+		PriceMinor:  999,
 		Quantity:    10,
 	}
 
 	newProduct2 := &Product{
 		Name:        "Test Product 2",
 		Description: "This is a test product 2",
-		Price:       8.75,
+		PriceMinor:  875,
 		Quantity:    4,
 	}
 
 	ids := []int64{1, 3}
 	quantities := []int32{6, 2}
 
-	CreateProduct(newProduct1)
-	CreateProduct(newProduct2)
+	products.CreateProduct(newProduct1)
+	products.CreateProduct(newProduct2)
 
-	err := UpdateProducts(ids, quantities)
+	err := products.UpdateProducts(ids, nil, quantities, "", nil)
 
 	assert.Error(t, err)
 	assert.Equal(t, "some ids are invalid", err.Error())
@@ -450,25 +463,360 @@ func TestUpdateProductsShouldBuyMoreItemsThanPrsentInTheInventory(t *testing.T)
 	newProduct1 := &Product{
 		Name:        "Test Product",
 		Description: "This is a test product",
-		Price:       9.99,
+		PriceMinor:  999,
 		Quantity:    10,
 	}
 
 	newProduct2 := &Product{
 		Name:        "Test Product 2",
 		Description: "This is a test product 2",
-		Price:       8.75,
+		PriceMinor:  875,
 		Quantity:    4,
 	}
 
 	ids := []int64{1, 2}
 	quantities := []int32{11, 2}
 
-	CreateProduct(newProduct1)
-	CreateProduct(newProduct2)
+	products.CreateProduct(newProduct1)
+	products.CreateProduct(newProduct2)
 
-	err := UpdateProducts(ids, quantities)
+	err := products.UpdateProducts(ids, nil, quantities, "", nil)
 
 	assert.Error(t, err)
 	assert.Equal(t, "trying to order more items than there is in the inventory", err.Error())
 }
+
+func TestFindProductByNameShouldWorkCorrectly(t *testing.T) {
+	db := setupDatabase(t)
+	defer teardownDatabase(db)
+
+	newProduct := &Product{
+		Name:        "Test Product",
+		Description: "This is a test product",
+		PriceMinor:  999,
+		Quantity:    10,
+	}
+
+	products.CreateProduct(newProduct)
+
+	product, err1 := products.FindProductByName("Test Product")
+	missingProduct, err2 := products.FindProductByName("Missing Product")
+
+	assert.NoError(t, err1)
+	assert.NoError(t, err2)
+	assert.NotNil(t, product)
+	assert.Nil(t, missingProduct)
+	assert.Equal(t, newProduct.ID, product.ID)
+}
+
+func TestUpdateProductDetailsShouldWorkCorrectly(t *testing.T) {
+	db := setupDatabase(t)
+	defer teardownDatabase(db)
+
+	newProduct := &Product{
+		Name:        "Test Product",
+		Description: "This is a test product",
+		PriceMinor:  999,
+		Quantity:    10,
+	}
+
+	products.CreateProduct(newProduct)
+
+	product, err := products.UpdateProductDetails(1, map[string]interface{}{"name": "Updated Product", "price_minor": 1999}, 0)
+
+	assert.NoError(t, err)
+	assert.NotNil(t, product)
+	assert.Equal(t, "Updated Product", product.Name)
+	assert.Equal(t, newProduct.Description, product.Description)
+	assert.Equal(t, int64(1999), product.PriceMinor)
+	assert.Equal(t, newProduct.Quantity, product.Quantity)
+}
+
+func TestUpdateProductDetailsShouldThrowAnErrorIfNoFieldsArePassed(t *testing.T) {
+	db := setupDatabase(t)
+	defer teardownDatabase(db)
+
+	product, err := products.UpdateProductDetails(1, map[string]interface{}{}, 0)
+
+	assert.Error(t, err)
+	assert.Nil(t, product)
+	assert.Equal(t, "no product details to update", err.Error())
+}
+
+func TestUpdateProductDetailsShouldThrowAnErrorIfProductDoesNotExist(t *testing.T) {
+	db := setupDatabase(t)
+	defer teardownDatabase(db)
+
+	product, err := products.UpdateProductDetails(1, map[string]interface{}{"name": "Updated Product"}, 0)
+
+	assert.Error(t, err)
+	assert.Nil(t, product)
+	assert.Equal(t, gorm.ErrRecordNotFound, err)
+}
+
+func TestCreateProductShouldStartAtVersionOne(t *testing.T) {
+	db := setupDatabase(t)
+	defer teardownDatabase(db)
+
+	createdProduct, err := products.CreateProduct(&Product{Name: "Test Product", PriceMinor: 999, Quantity: 10})
+
+	assert.NoError(t, err)
+	assert.Equal(t, int64(1), createdProduct.Version)
+}
+
+func TestAddProductsShouldIncrementTheVersion(t *testing.T) {
+	db := setupDatabase(t)
+	defer teardownDatabase(db)
+
+	products.CreateProduct(&Product{Name: "Test Product", PriceMinor: 999, Quantity: 10})
+
+	product1, err1 := products.AddProducts(1, 0, 0, 5, 1)
+	product2, err2 := products.AddProducts(1, 0, 0, 5, 0)
+
+	assert.NoError(t, err1)
+	assert.NoError(t, err2)
+	assert.Equal(t, int64(2), product1.Version)
+	assert.Equal(t, int64(3), product2.Version)
+	assert.Equal(t, int32(20), product2.Quantity)
+}
+
+func TestAddProductsShouldThrowAnErrorIfVersionIsStale(t *testing.T) {
+	db := setupDatabase(t)
+	defer teardownDatabase(db)
+
+	products.CreateProduct(&Product{Name: "Test Product", PriceMinor: 999, Quantity: 10})
+	products.AddProducts(1, 0, 0, 5, 1)
+
+	product, err := products.AddProducts(1, 0, 0, 5, 1)
+	current, _ := products.GetProduct(1)
+
+	assert.Error(t, err)
+	assert.Nil(t, product)
+	assert.Equal(t, ErrVersionMismatch, err)
+	assert.Equal(t, int32(15), current.Quantity)
+}
+
+func TestRemoveProductsShouldThrowAnErrorIfVersionIsStale(t *testing.T) {
+	db := setupDatabase(t)
+	defer teardownDatabase(db)
+
+	products.CreateProduct(&Product{Name: "Test Product", PriceMinor: 999, Quantity: 10})
+
+	product, err := products.RemoveProducts(1, 0, 0, 5, 2)
+
+	assert.Error(t, err)
+	assert.Nil(t, product)
+	assert.Equal(t, ErrVersionMismatch, err)
+}
+
+func TestUpdateProductDetailsShouldThrowAnErrorIfVersionIsStale(t *testing.T) {
+	db := setupDatabase(t)
+	defer teardownDatabase(db)
+
+	products.CreateProduct(&Product{Name: "Test Product", PriceMinor: 999, Quantity: 10})
+
+	product, err := products.UpdateProductDetails(1, map[string]interface{}{"price_minor": 1999}, 3)
+
+	assert.Error(t, err)
+	assert.Nil(t, product)
+	assert.Equal(t, ErrVersionMismatch, err)
+}
+
+func TestUpdateProductDetailsShouldWrapUnexpectedDatabaseErrors(t *testing.T) {
+	db := setupDatabase(t)
+	defer teardownDatabase(db)
+
+	products.CreateProduct(&Product{Name: "Test Product", PriceMinor: 999, Quantity: 10})
+
+	product, err := products.UpdateProductDetails(1, map[string]interface{}{"colour": "red"}, 0)
+
+	assert.Nil(t, product)
+	assert.NotErrorIs(t, err, ErrAlreadyExists)
+	assert.True(t, strings.HasPrefix(err.Error(), "failed to update product details: "))
+	assert.NotNil(t, errors.Unwrap(err))
+}
+
+func TestDeleteProductShouldThrowAnErrorIfVersionIsStale(t *testing.T) {
+	db := setupDatabase(t)
+	defer teardownDatabase(db)
+
+	products.CreateProduct(&Product{Name: "Test Product", PriceMinor: 999, Quantity: 10})
+
+	err1 := products.DeleteProduct(1, 2)
+	err2 := products.DeleteProduct(1, 1)
+
+	assert.Equal(t, ErrVersionMismatch, err1)
+	assert.NoError(t, err2)
+}
+
+type legacyProduct struct {
+	gorm.Model
+	ID          int64   `gorm:"primarykey;AUTO_INCREMENT"`
+	Name        string  `gorm:"column:name;unique"`
+	Description string  `gorm:"column:description"`
+	Price       float64 `gorm:"column:price"`
+	Quantity    int32   `gorm:"column:quantity"`
+}
+
+func (legacyProduct) TableName() string {
+	return "products"
+}
+
+func TestInitProductModelShouldMigrateLegacyPrices(t *testing.T) {
+	db := setupDatabase(t)
+	defer teardownDatabase(db)
+
+	assert.NoError(t, db.Migrator().DropTable(&Product{}))
+	assert.NoError(t, db.AutoMigrate(&legacyProduct{}))
+	assert.NoError(t, db.Create(&legacyProduct{Name: "Legacy Product", Price: 19.99, Quantity: 3}).Error)
+
+	assert.NoError(t, InitProductModel(db))
+
+	product, err := products.GetProduct(1)
+
+	assert.NoError(t, err)
+	assert.Equal(t, int64(1999), product.PriceMinor)
+	assert.Equal(t, "USD", product.Currency)
+	assert.Equal(t, int32(3), product.Quantity)
+	assert.False(t, db.Migrator().HasColumn(&Product{}, "price"))
+}
+
+func TestInitProductModelShouldThrowAnErrorIfLegacyPriceMigrationFails(t *testing.T) {
+	db := setupDatabase(t)
+	defer teardownDatabase(db)
+
+	assert.NoError(t, db.Migrator().DropTable(&Product{}))
+	assert.NoError(t, db.AutoMigrate(&legacyProduct{}))
+	assert.NoError(t, db.Create(&legacyProduct{Name: "Legacy Product", Price: 19.99, Quantity: 3}).Error)
+	assert.NoError(t, db.Callback().Raw().Before("gorm:raw").Register("test:reject_price_migration", func(tx *gorm.DB) {
+		if strings.Contains(tx.Statement.SQL.String(), "SET price_minor") {
+			tx.AddError(errors.New("price migration rejected"))
+		}
+	}))
+
+	err := InitProductModel(db)
+
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "failed to migrate legacy product prices")
+	assert.True(t, db.Migrator().HasColumn(&Product{}, "price"))
+}
+
+func TestGetLowStockProductsShouldReturnProductsAtOrBelowThreshold(t *testing.T) {
+	db := setupDatabase(t)
+	defer teardownDatabase(db)
+
+	products.CreateProduct(&Product{Name: "Lamp", Quantity: 5, ReorderThreshold: 5})
+	products.CreateProduct(&Product{Name: "Desk", Quantity: 6, ReorderThreshold: 5})
+	products.CreateProduct(&Product{Name: "Chair", Quantity: 0})
+
+	products, err := products.GetLowStockProducts()
+
+	assert.NoError(t, err)
+	assert.Equal(t, 1, len(products))
+	assert.Equal(t, "Lamp", products[0].Name)
+}
+
+func TestCreateProductShouldAllowReusingTheNameOfADeletedProduct(t *testing.T) {
+	db := setupDatabase(t)
+	defer teardownDatabase(db)
+
+	products.CreateProduct(&Product{Name: "Lamp"})
+	products.DeleteProduct(1, 0)
+
+	product, err := products.CreateProduct(&Product{Name: "Lamp"})
+
+	assert.NoError(t, err)
+	assert.Equal(t, int64(2), product.ID)
+}
+
+func TestGetDeletedProductsShouldReturnOnlyDeletedProducts(t *testing.T) {
+	db := setupDatabase(t)
+	defer teardownDatabase(db)
+
+	products.CreateProduct(&Product{Name: "Lamp"})
+	products.CreateProduct(&Product{Name: "Desk"})
+	products.DeleteProduct(1, 0)
+
+	products, err := products.GetDeletedProducts()
+
+	assert.NoError(t, err)
+	assert.Equal(t, 1, len(products))
+	assert.Equal(t, "Lamp", products[0].Name)
+	assert.True(t, products[0].DeletedAt.Valid)
+}
+
+func TestRestoreProductShouldWorkCorrectly(t *testing.T) {
+	db := setupDatabase(t)
+	defer teardownDatabase(db)
+
+	products.CreateProduct(&Product{Name: "Lamp", Quantity: 3})
+	products.DeleteProduct(1, 0)
+
+	product, err := products.RestoreProduct(1)
+
+	assert.NoError(t, err)
+	assert.False(t, product.DeletedAt.Valid)
+	assert.Equal(t, int32(3), product.Quantity)
+	assert.Equal(t, int64(2), product.Version)
+}
+
+func TestRestoreProductShouldThrowAnErrorIfNameIsTaken(t *testing.T) {
+	db := setupDatabase(t)
+	defer teardownDatabase(db)
+
+	products.CreateProduct(&Product{Name: "Lamp"})
+	products.DeleteProduct(1, 0)
+	products.CreateProduct(&Product{Name: "Lamp"})
+
+	product, err := products.RestoreProduct(1)
+
+	assert.Error(t, err)
+	assert.Nil(t, product)
+	assert.Equal(t, "product with the same name already exists", err.Error())
+}
+
+func TestRestoreProductShouldThrowAnErrorIfProductIsNotDeleted(t *testing.T) {
+	db := setupDatabase(t)
+	defer teardownDatabase(db)
+
+	products.CreateProduct(&Product{Name: "Lamp"})
+
+	product, err := products.RestoreProduct(1)
+
+	assert.Error(t, err)
+	assert.Nil(t, product)
+	assert.Equal(t, "deleted product with id 1 does not exist", err.Error())
+}
+
+func TestPurgeProductShouldRemoveTheProductPermanently(t *testing.T) {
+	db := setupDatabase(t)
+	defer teardownDatabase(db)
+
+	products.CreateProduct(&Product{Name: "T-Shirt"})
+	NewGormSkuRepository(db).CreateSku(&Sku{ProductID: 1, Code: "TS-M", Quantity: 2})
+	products.DeleteProduct(1, 0)
+
+	err := products.PurgeProduct(1)
+
+	var products, skus, levels int64
+	db.Unscoped().Model(&Product{}).Count(&products)
+	db.Unscoped().Model(&Sku{}).Count(&skus)
+	db.Model(&StockLevel{}).Count(&levels)
+
+	assert.NoError(t, err)
+	assert.Equal(t, int64(0), products)
+	assert.Equal(t, int64(0), skus)
+	assert.Equal(t, int64(0), levels)
+}
+
+func TestPurgeProductShouldThrowAnErrorIfProductIsNotDeleted(t *testing.T) {
+	db := setupDatabase(t)
+	defer teardownDatabase(db)
+
+	products.CreateProduct(&Product{Name: "Lamp"})
+
+	err := products.PurgeProduct(1)
+
+	assert.Error(t, err)
+	assert.Equal(t, "only deleted products can be purged", err.Error())
+}
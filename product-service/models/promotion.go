@@ -0,0 +1,120 @@
+package models
+
+import (
+	"fmt"
+	"log/slog"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+type PromotionType string
+
+const (
+	PromotionPercentage  PromotionType = "percentage"
+	PromotionFixedAmount PromotionType = "fixed_amount"
+	PromotionBuyXGetY    PromotionType = "buy_x_get_y"
+)
+
+type Promotion struct {
+	gorm.Model
+	ID             int64         `gorm:"primarykey;AUTO_INCREMENT"`
+	Name           string        `gorm:"column:name;not null"`
+	Type           PromotionType `gorm:"column:type;not null"`
+	ProductID      *int64        `gorm:"column:product_id;index"`
+	PercentOff     int32         `gorm:"column:percent_off;not null;default:0"`
+	AmountOffMinor int64         `gorm:"column:amount_off_minor;not null;default:0"`
+	Currency       string        `gorm:"column:currency;size:3"`
+	BuyQuantity    int32         `gorm:"column:buy_quantity;not null;default:0"`
+	GetQuantity    int32         `gorm:"column:get_quantity;not null;default:0"`
+	CouponCode     *string       `gorm:"column:coupon_code;uniqueIndex"`
+	StartsAt       *time.Time    `gorm:"column:starts_at"`
+	EndsAt         *time.Time    `gorm:"column:ends_at"`
+}
+
+type GormPromotionRepository struct {
+	db *gorm.DB
+}
+
+func NewGormPromotionRepository(dbInstance *gorm.DB) *GormPromotionRepository {
+	return &GormPromotionRepository{db: dbInstance}
+}
+
+func InitPromotionModel(db *gorm.DB) error {
+	return db.AutoMigrate(&Promotion{})
+}
+
+func (repository *GormPromotionRepository) CreatePromotion(newPromotion *Promotion) (*Promotion, error) {
+	if newPromotion == nil {
+		return nil, InvalidArgumentError("invalid promotion")
+	}
+
+	if err := repository.db.Create(newPromotion).Error; err != nil {
+		slog.Error("failed to create promotion", "operation", "create", "entity", "promotion", "error", err)
+		return nil, fmt.Errorf("error in creating a new promotion: %w", err)
+	}
+
+	return newPromotion, nil
+}
+
+func (repository *GormPromotionRepository) GetAllPromotions() ([]Promotion, error) {
+	var promotions []Promotion
+
+	if err := repository.db.Order("id").Find(&promotions).Error; err != nil {
+		return nil, err
+	}
+
+	return promotions, nil
+}
+
+func (repository *GormPromotionRepository) GetPromotion(id int32) (*Promotion, error) {
+	var promotion *Promotion
+
+	if err := repository.db.First(&promotion, id).Error; err != nil {
+		return nil, err
+	}
+
+	return promotion, nil
+}
+
+func (repository *GormPromotionRepository) FindPromotionByCouponCode(code string) (*Promotion, error) {
+	var promotion *Promotion
+
+	result := repository.db.Where("coupon_code = ?", code).Find(&promotion)
+
+	if result.Error != nil {
+		return nil, result.Error
+	}
+
+	if result.RowsAffected == 0 {
+		return nil, nil
+	}
+
+	return promotion, nil
+}
+
+func (repository *GormPromotionRepository) GetActivePromotions(at time.Time, couponCodes []string) ([]Promotion, error) {
+	var promotions []Promotion
+
+	query := repository.db.Where("(starts_at IS NULL OR starts_at <= ?) AND (ends_at IS NULL OR ends_at > ?)", at, at)
+
+	if len(couponCodes) > 0 {
+		query = query.Where("coupon_code IS NULL OR coupon_code IN ?", couponCodes)
+	} else {
+		query = query.Where("coupon_code IS NULL")
+	}
+
+	if err := query.Order("id").Find(&promotions).Error; err != nil {
+		return nil, err
+	}
+
+	return promotions, nil
+}
+
+func (repository *GormPromotionRepository) DeletePromotion(id int32) error {
+	if repository.db.Delete(&Promotion{}, id).RowsAffected < 1 {
+		return NotFoundError("promotion with id %d does not exist", id)
+	}
+
+	return nil
+}
@@ -0,0 +1,57 @@
+package models
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestShouldCreatePromotionWorkCorrectly(t *testing.T) {
+	db := setupDatabase(t)
+	defer teardownDatabase(db)
+	repository := NewGormPromotionRepository(db)
+
+	promotion, err := repository.CreatePromotion(&Promotion{Name: "Summer sale", Type: PromotionPercentage, PercentOff: 20})
+	promotions, _ := repository.GetAllPromotions()
+
+	assert.NoError(t, err)
+	assert.Equal(t, int64(1), promotion.ID)
+	assert.Equal(t, 1, len(promotions))
+}
+
+func TestShouldGetActivePromotionsFilterByWindowAndCoupon(t *testing.T) {
+	db := setupDatabase(t)
+	defer teardownDatabase(db)
+	promotions := NewGormPromotionRepository(db)
+
+	now := time.Now()
+	past, future := now.Add(-time.Hour), now.Add(time.Hour)
+	code := "WELCOME"
+
+	promotions.CreatePromotion(&Promotion{Name: "Always", Type: PromotionPercentage, PercentOff: 5})
+	promotions.CreatePromotion(&Promotion{Name: "Expired", Type: PromotionPercentage, PercentOff: 10, EndsAt: &past})
+	promotions.CreatePromotion(&Promotion{Name: "Upcoming", Type: PromotionPercentage, PercentOff: 15, StartsAt: &future})
+	promotions.CreatePromotion(&Promotion{Name: "Coupon", Type: PromotionPercentage, PercentOff: 20, CouponCode: &code})
+
+	withoutCoupon, err1 := promotions.GetActivePromotions(now, nil)
+	withCoupon, err2 := promotions.GetActivePromotions(now, []string{"WELCOME"})
+
+	assert.NoError(t, err1)
+	assert.NoError(t, err2)
+	assert.Equal(t, 1, len(withoutCoupon))
+	assert.Equal(t, "Always", withoutCoupon[0].Name)
+	assert.Equal(t, 2, len(withCoupon))
+	assert.Equal(t, "Coupon", withCoupon[1].Name)
+}
+
+func TestShouldDeletePromotionThrowAnErrorIfItDoesNotExist(t *testing.T) {
+	db := setupDatabase(t)
+	defer teardownDatabase(db)
+	promotions := NewGormPromotionRepository(db)
+
+	err := promotions.DeletePromotion(1001)
+
+	assert.Error(t, err)
+	assert.Equal(t, "promotion with id 1001 does not exist", err.Error())
+}
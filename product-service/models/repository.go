@@ -0,0 +1,94 @@
+package models
+
+import "time"
+
+type ProductRepository interface {
+	CreateProduct(newProduct *Product) (*Product, error)
+	GetAllProducts() ([]Product, error)
+	GetProduct(id int32) (*Product, error)
+	GetProductsByIds(ids []int64) ([]Product, error)
+	GetLowStockProducts() ([]Product, error)
+	FindProductByName(name string) (*Product, error)
+	GetDeletedProducts() ([]Product, error)
+	RestoreProduct(id int32) (*Product, error)
+	PurgeProduct(id int32) error
+	UpdateProductDetails(id int32, fields map[string]interface{}, expectedVersion int64) (*Product, error)
+	UpdateProductDetailsAndStock(id int32, fields map[string]interface{}, quantityDelta int32) (*Product, error)
+	DeleteProduct(id int32, expectedVersion int64) error
+	AddProducts(id int32, skuID int32, warehouseID int32, quantity int32, expectedVersion int64) (*Product, error)
+	RemoveProducts(id int32, skuID int32, warehouseID int32, quantity int32, expectedVersion int64) (*Product, error)
+	UpdateProducts(ids []int64, skuIds []int64, quantities []int32, strategy string, destination *Location) error
+}
+
+type CategoryRepository interface {
+	CreateCategory(newCategory *Category) (*Category, error)
+	GetAllCategories() ([]Category, error)
+	GetCategory(id int32) (*Category, error)
+	FindCategoryByName(name string, parentID *int64) (*Category, error)
+	UpdateCategory(id int32, fields map[string]interface{}) (*Category, error)
+	DeleteCategory(id int32) error
+	GetCategoryDescendantIds(id int32) ([]int64, error)
+	AddProductToCategory(productID int32, categoryID int32) error
+	RemoveProductFromCategory(productID int32, categoryID int32) error
+	GetCategoryProductIds(categoryIds []int64) ([]int64, error)
+}
+
+type SkuRepository interface {
+	CreateSku(newSku *Sku) (*Sku, error)
+	GetSku(id int32) (*Sku, error)
+	FindSkuByCode(code string) (*Sku, error)
+	UpdateSku(id int32, fields map[string]interface{}) (*Sku, error)
+	DeleteSku(id int32) error
+}
+
+type WarehouseRepository interface {
+	CreateWarehouse(newWarehouse *Warehouse) (*Warehouse, error)
+	GetAllWarehouses() ([]Warehouse, error)
+	GetWarehouse(id int32) (*Warehouse, error)
+	FindWarehouseByName(name string) (*Warehouse, error)
+	DeleteWarehouse(id int32) error
+	TransferStock(productID int32, skuID int32, fromWarehouseID int32, toWarehouseID int32, quantity int32, expectedVersion int64) (*Product, error)
+}
+
+type PriceRepository interface {
+	SchedulePriceChange(productID int32, priceMinor int64, currency string, effectiveAt time.Time) (*PriceChange, error)
+	GetPriceHistory(productID int32) ([]PriceChange, error)
+	GetEffectivePrice(productID int32, at time.Time) (*PriceChange, error)
+	ApplyDuePriceChanges(now time.Time) (int, error)
+}
+
+type PromotionRepository interface {
+	CreatePromotion(newPromotion *Promotion) (*Promotion, error)
+	GetAllPromotions() ([]Promotion, error)
+	GetPromotion(id int32) (*Promotion, error)
+	FindPromotionByCouponCode(code string) (*Promotion, error)
+	GetActivePromotions(at time.Time, couponCodes []string) ([]Promotion, error)
+	DeletePromotion(id int32) error
+}
+
+type OutboxRepository interface {
+	ClaimPendingEvents(claimant string, limit int, lease time.Duration) ([]OutboxEvent, error)
+	ReleaseClaimedEvents(claimant string) error
+	GetParkedEvents() ([]OutboxEvent, error)
+	MarkEventPublished(id int64, at time.Time) error
+	MarkEventFailed(id int64, cause error, maxAttempts int32) (bool, error)
+}
+
+type IdempotencyRepository interface {
+	ReserveIdempotencyKey(caller string, key string, method string, fingerprint string, lease time.Duration) (*IdempotencyRecord, bool, error)
+	CompleteIdempotencyKey(caller string, key string, responseType string, response []byte) error
+	ReleaseIdempotencyKey(caller string, key string) error
+	PurgeIdempotencyKeys(before time.Time) (int64, error)
+}
+
+var (
+	_ ProductRepository     = (*GormProductRepository)(nil)
+	_ ProductRepository     = (*MemoryProductRepository)(nil)
+	_ CategoryRepository    = (*GormCategoryRepository)(nil)
+	_ SkuRepository         = (*GormSkuRepository)(nil)
+	_ WarehouseRepository   = (*GormWarehouseRepository)(nil)
+	_ PriceRepository       = (*GormPriceRepository)(nil)
+	_ PromotionRepository   = (*GormPromotionRepository)(nil)
+	_ OutboxRepository      = (*GormOutboxRepository)(nil)
+	_ IdempotencyRepository = (*GormIdempotencyRepository)(nil)
+)
@@ -0,0 +1,299 @@
+package models
+
+import (
+	"fmt"
+	"product-service/events"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"gorm.io/driver/sqlite"
+	"gorm.io/gorm"
+)
+
+func newGormRepository(t *testing.T) ProductRepository {
+	return NewGormProductRepository(openRepositoryDatabase(t), AllocationPriority)
+}
+
+func openRepositoryDatabase(t *testing.T) *gorm.DB {
+	name := strings.NewReplacer("/", "_", " ", "_").Replace(t.Name())
+	db, err := gorm.Open(sqlite.Open(fmt.Sprintf("file:%s?mode=memory&cache=shared", name)), &gorm.Config{})
+	assert.NoError(t, err)
+
+	assert.NoError(t, db.AutoMigrate(&Product{}, &Category{}, &ProductCategory{}, &Sku{}, &Warehouse{}, &StockLevel{}, &PriceChange{}, &OutboxEvent{}))
+
+	t.Cleanup(func() {
+		sql, _ := db.DB()
+		sql.Close()
+	})
+
+	return db
+}
+
+func newMemoryRepository(t *testing.T) ProductRepository {
+	return NewMemoryProductRepository()
+}
+
+func TestGormProductRepositoryConformance(t *testing.T) {
+	runProductRepositoryConformance(t, newGormRepository)
+}
+
+func TestMemoryProductRepositoryConformance(t *testing.T) {
+	runProductRepositoryConformance(t, newMemoryRepository)
+}
+
+// runProductRepositoryConformance covers the product rows both repositories
+// keep. SKUs, warehouses, outbox events and price history only exist in the
+// gorm repository and are tested against it in the model tests;
+// TestMemoryProductRepositoryRejectsStockLocations pins down the memory
+// repository's side of that gap.
+func runProductRepositoryConformance(t *testing.T, newRepository func(t *testing.T) ProductRepository) {
+	cases := map[string]func(t *testing.T, repository ProductRepository){
+		"CreateAndGet":             conformCreateAndGet,
+		"GetByIds":                 conformGetByIds,
+		"UniqueActiveNames":        conformUniqueActiveNames,
+		"UpdateDetailsWithVersion": conformUpdateDetailsWithVersion,
+		"UpdateDetailsToTakenName": conformUpdateDetailsToTakenName,
+		"UpdateDetailsAndStock":    conformUpdateDetailsAndStock,
+		"DeleteRestorePurge":       conformDeleteRestorePurge,
+		"AddAndRemoveStock":        conformAddAndRemoveStock,
+		"UpdateProductsIsAtomic":   conformUpdateProductsIsAtomic,
+		"LowStockListing":          conformLowStockListing,
+	}
+
+	for name, run := range cases {
+		run := run
+
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+			run(t, newRepository(t))
+		})
+	}
+}
+
+func conformCreateAndGet(t *testing.T, repository ProductRepository) {
+	created, err1 := repository.CreateProduct(&Product{Name: "Lamp", PriceMinor: 2999, Currency: "USD"})
+	product, err2 := repository.GetProduct(int32(created.ID))
+	missing, err3 := repository.GetProduct(1001)
+	found, err4 := repository.FindProductByName("Lamp")
+	notFound, err5 := repository.FindProductByName("Desk")
+
+	assert.NoError(t, err1)
+	assert.Equal(t, int64(1), created.ID)
+	assert.Equal(t, int64(1), created.Version)
+	assert.NoError(t, err2)
+	assert.Equal(t, "Lamp", product.Name)
+	assert.Equal(t, int64(2999), product.PriceMinor)
+	assert.Error(t, err3)
+	assert.Nil(t, missing)
+	assert.NoError(t, err4)
+	assert.Equal(t, created.ID, found.ID)
+	assert.NoError(t, err5)
+	assert.Nil(t, notFound)
+}
+
+func conformGetByIds(t *testing.T, repository ProductRepository) {
+	repository.CreateProduct(&Product{Name: "Lamp", Currency: "USD"})
+	repository.CreateProduct(&Product{Name: "Desk", Currency: "USD"})
+	repository.CreateProduct(&Product{Name: "Chair", Currency: "USD"})
+	repository.DeleteProduct(3, 0)
+
+	products, err1 := repository.GetProductsByIds([]int64{2, 3, 1001})
+	none, err2 := repository.GetProductsByIds([]int64{})
+
+	assert.NoError(t, err1)
+	assert.Equal(t, 1, len(products))
+	assert.Equal(t, "Desk", products[0].Name)
+	assert.NoError(t, err2)
+	assert.Empty(t, none)
+}
+
+func conformUniqueActiveNames(t *testing.T, repository ProductRepository) {
+	repository.CreateProduct(&Product{Name: "Lamp", Currency: "USD"})
+
+	duplicate, err1 := repository.CreateProduct(&Product{Name: "Lamp", Currency: "USD"})
+	err2 := repository.DeleteProduct(1, 0)
+	replacement, err3 := repository.CreateProduct(&Product{Name: "Lamp", Currency: "USD"})
+	products, _ := repository.GetAllProducts()
+
+	assert.Error(t, err1)
+	assert.Nil(t, duplicate)
+	assert.NoError(t, err2)
+	assert.NoError(t, err3)
+	assert.Equal(t, "Lamp", replacement.Name)
+	assert.Equal(t, 1, len(products))
+	assert.Equal(t, replacement.ID, products[0].ID)
+}
+
+func conformUpdateDetailsWithVersion(t *testing.T, repository ProductRepository) {
+	repository.CreateProduct(&Product{Name: "Lamp", PriceMinor: 2999, Currency: "USD"})
+
+	updated, err1 := repository.UpdateProductDetails(1, map[string]interface{}{"name": "Desk Lamp", "price_minor": int64(3499)}, 1)
+	stale, err2 := repository.UpdateProductDetails(1, map[string]interface{}{"description": "Bright"}, 1)
+	empty, err3 := repository.UpdateProductDetails(1, map[string]interface{}{}, 0)
+
+	assert.NoError(t, err1)
+	assert.Equal(t, "Desk Lamp", updated.Name)
+	assert.Equal(t, int64(3499), updated.PriceMinor)
+	assert.Equal(t, int64(2), updated.Version)
+	assert.ErrorIs(t, err2, ErrVersionMismatch)
+	assert.Nil(t, stale)
+	assert.Error(t, err3)
+	assert.Nil(t, empty)
+}
+
+func conformUpdateDetailsToTakenName(t *testing.T, repository ProductRepository) {
+	repository.CreateProduct(&Product{Name: "Lamp", Currency: "USD"})
+	repository.CreateProduct(&Product{Name: "Desk", Currency: "USD"})
+
+	renamed, err1 := repository.UpdateProductDetails(2, map[string]interface{}{"name": "Lamp"}, 0)
+	restocked, err2 := repository.UpdateProductDetailsAndStock(2, map[string]interface{}{"name": "Lamp"}, 1)
+	product, _ := repository.GetProduct(2)
+
+	assert.ErrorIs(t, err1, ErrAlreadyExists)
+	assert.Nil(t, renamed)
+	assert.ErrorIs(t, err2, ErrAlreadyExists)
+	assert.Nil(t, restocked)
+	assert.Equal(t, "Desk", product.Name)
+	assert.Equal(t, int32(0), product.Quantity)
+}
+
+func conformUpdateDetailsAndStock(t *testing.T, repository ProductRepository) {
+	repository.CreateProduct(&Product{Name: "Lamp", Description: "Bright", PriceMinor: 2999, Currency: "USD", Quantity: 5})
+
+	updated, err1 := repository.UpdateProductDetailsAndStock(1, map[string]interface{}{"price_minor": int64(3499)}, -2)
+	tooMany, err2 := repository.UpdateProductDetailsAndStock(1, map[string]interface{}{"description": "Dim"}, -10)
+	product, _ := repository.GetProduct(1)
+	empty, err3 := repository.UpdateProductDetailsAndStock(1, map[string]interface{}{}, 0)
+
+	assert.NoError(t, err1)
+	assert.Equal(t, int64(3499), updated.PriceMinor)
+	assert.Equal(t, int32(3), updated.Quantity)
+	assert.Equal(t, "too many products to be removed", err2.Error())
+	assert.Nil(t, tooMany)
+	assert.Equal(t, "Bright", product.Description)
+	assert.Equal(t, int32(3), product.Quantity)
+	assert.Error(t, err3)
+	assert.Nil(t, empty)
+}
+
+func conformDeleteRestorePurge(t *testing.T, repository ProductRepository) {
+	repository.CreateProduct(&Product{Name: "Lamp", Currency: "USD"})
+
+	assert.ErrorIs(t, repository.DeleteProduct(1, 5), ErrVersionMismatch)
+	assert.Equal(t, "product with id 1001 does not exist", repository.DeleteProduct(1001, 0).Error())
+	assert.Equal(t, "only deleted products can be purged", repository.PurgeProduct(1).Error())
+	assert.NoError(t, repository.DeleteProduct(1, 1))
+
+	_, err := repository.GetProduct(1)
+	deleted, _ := repository.GetDeletedProducts()
+
+	assert.Error(t, err)
+	assert.Equal(t, 1, len(deleted))
+	assert.True(t, deleted[0].DeletedAt.Valid)
+
+	restored, err := repository.RestoreProduct(1)
+
+	assert.NoError(t, err)
+	assert.False(t, restored.DeletedAt.Valid)
+	assert.Equal(t, int64(2), restored.Version)
+
+	repository.DeleteProduct(1, 0)
+
+	assert.NoError(t, repository.PurgeProduct(1))
+
+	deleted, _ = repository.GetDeletedProducts()
+	_, err = repository.RestoreProduct(1)
+
+	assert.Empty(t, deleted)
+	assert.Equal(t, "deleted product with id 1 does not exist", err.Error())
+}
+
+func conformAddAndRemoveStock(t *testing.T, repository ProductRepository) {
+	repository.CreateProduct(&Product{Name: "Lamp", Currency: "USD", Quantity: 5})
+
+	added, err1 := repository.AddProducts(1, 0, 0, 3, 1)
+	removed, err2 := repository.RemoveProducts(1, 0, 0, 2, 0)
+	tooMany, err3 := repository.RemoveProducts(1, 0, 0, 100, 0)
+	stale, err4 := repository.AddProducts(1, 0, 0, 1, 1)
+	negative, err5 := repository.AddProducts(1, 0, 0, -1, 0)
+
+	assert.NoError(t, err1)
+	assert.Equal(t, int32(8), added.Quantity)
+	assert.Equal(t, int64(2), added.Version)
+	assert.NoError(t, err2)
+	assert.Equal(t, int32(6), removed.Quantity)
+	assert.Equal(t, "too many products to be removed", err3.Error())
+	assert.Nil(t, tooMany)
+	assert.ErrorIs(t, err4, ErrVersionMismatch)
+	assert.Nil(t, stale)
+	assert.Equal(t, "quantity added cannot be less than 0", err5.Error())
+	assert.Nil(t, negative)
+}
+
+func conformUpdateProductsIsAtomic(t *testing.T, repository ProductRepository) {
+	repository.CreateProduct(&Product{Name: "Lamp", Currency: "USD", Quantity: 5})
+	repository.CreateProduct(&Product{Name: "Desk", Currency: "USD", Quantity: 1})
+
+	err1 := repository.UpdateProducts([]int64{1, 2}, nil, []int32{2, 5}, "", nil)
+	err2 := repository.UpdateProducts([]int64{1, 1001}, nil, []int32{1, 1}, "", nil)
+	err3 := repository.UpdateProducts([]int64{1, 2}, nil, []int32{2, 1}, "", nil)
+	lamp, _ := repository.GetProduct(1)
+	desk, _ := repository.GetProduct(2)
+
+	assert.Equal(t, "trying to order more items than there is in the inventory", err1.Error())
+	assert.Equal(t, "some ids are invalid", err2.Error())
+	assert.NoError(t, err3)
+	assert.Equal(t, int32(3), lamp.Quantity)
+	assert.Equal(t, int32(0), desk.Quantity)
+}
+
+func conformLowStockListing(t *testing.T, repository ProductRepository) {
+	repository.CreateProduct(&Product{Name: "Lamp", Currency: "USD", Quantity: 2, ReorderThreshold: 3})
+	repository.CreateProduct(&Product{Name: "Desk", Currency: "USD", Quantity: 10, ReorderThreshold: 3})
+	repository.CreateProduct(&Product{Name: "Chair", Currency: "USD", Quantity: 0})
+
+	products, err := repository.GetLowStockProducts()
+
+	assert.NoError(t, err)
+	assert.Equal(t, 1, len(products))
+	assert.Equal(t, "Lamp", products[0].Name)
+}
+
+func TestGormProductRepositoryRecordsEventsAndPriceHistory(t *testing.T) {
+	db := openRepositoryDatabase(t)
+	prices := NewGormPriceRepository(db)
+	outbox := NewGormOutboxRepository(db)
+	repository := NewGormProductRepository(db, AllocationPriority)
+
+	repository.CreateProduct(&Product{Name: "Lamp", PriceMinor: 2999, Currency: "USD"})
+	repository.UpdateProductDetails(1, map[string]interface{}{"price_minor": int64(3499)}, 0)
+	repository.DeleteProduct(1, 0)
+
+	history, err1 := prices.GetPriceHistory(1)
+	claimed, err2 := outbox.ClaimPendingEvents("test", 10, time.Minute)
+
+	assert.NoError(t, err1)
+	assert.Equal(t, 2, len(history))
+	assert.Equal(t, int64(3499), history[1].PriceMinor)
+	assert.NoError(t, err2)
+	assert.Equal(t, 2, len(claimed))
+	assert.Equal(t, string(events.ProductCreated), claimed[0].EventType)
+	assert.Equal(t, string(events.ProductDeleted), claimed[1].EventType)
+}
+
+func TestMemoryProductRepositoryRejectsStockLocations(t *testing.T) {
+	repository := NewMemoryProductRepository()
+	repository.CreateProduct(&Product{Name: "Lamp", Currency: "USD", Quantity: 5})
+
+	_, err1 := repository.AddProducts(1, 1, 0, 1, 0)
+	_, err2 := repository.RemoveProducts(1, 0, 1, 1, 0)
+	err3 := repository.UpdateProducts([]int64{1}, []int64{1}, []int32{1}, "", nil)
+	product, _ := repository.GetProduct(1)
+
+	assert.ErrorIs(t, err1, ErrStockLocationUnsupported)
+	assert.ErrorIs(t, err2, ErrStockLocationUnsupported)
+	assert.ErrorIs(t, err3, ErrStockLocationUnsupported)
+	assert.Equal(t, int32(5), product.Quantity)
+}
@@ -0,0 +1,253 @@
+package models
+
+import (
+	"database/sql/driver"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log/slog"
+	"product-service/events"
+
+	"gorm.io/gorm"
+)
+
+var (
+	ErrProductHasSkus    = FailedPreconditionError("product has skus, stock must be updated per sku")
+	ErrInsufficientStock = FailedPreconditionError("insufficient stock")
+)
+
+type SkuAttributes map[string]string
+
+func (attributes SkuAttributes) Value() (driver.Value, error) {
+	if attributes == nil {
+		return "{}", nil
+	}
+
+	bytes, err := json.Marshal(attributes)
+
+	if err != nil {
+		return nil, err
+	}
+
+	return string(bytes), nil
+}
+
+func (attributes *SkuAttributes) Scan(value interface{}) error {
+	switch data := value.(type) {
+	case nil:
+		*attributes = nil
+		return nil
+	case []byte:
+		return json.Unmarshal(data, attributes)
+	case string:
+		return json.Unmarshal([]byte(data), attributes)
+	default:
+		return errors.New("invalid sku attributes")
+	}
+}
+
+type Sku struct {
+	gorm.Model
+	ID         int64         `gorm:"primarykey;AUTO_INCREMENT"`
+	ProductID  int64         `gorm:"column:product_id;not null;index"`
+	Code       string        `gorm:"column:code;uniqueIndex:idx_skus_code_active,where:deleted_at IS NULL"`
+	Attributes SkuAttributes `gorm:"column:attributes;type:text"`
+	PriceMinor *int64        `gorm:"column:price_minor"`
+	Quantity   int32         `gorm:"column:quantity"`
+}
+
+type GormSkuRepository struct {
+	db *gorm.DB
+}
+
+func NewGormSkuRepository(dbInstance *gorm.DB) *GormSkuRepository {
+	return &GormSkuRepository{db: dbInstance}
+}
+
+func InitSkuModel(db *gorm.DB) error {
+	if err := dropLegacyIndex(db, &Sku{}, "idx_skus_code"); err != nil {
+		return fmt.Errorf("failed to drop the legacy sku code index: %w", err)
+	}
+
+	return db.AutoMigrate(&Sku{})
+}
+
+func (repository *GormSkuRepository) CreateSku(newSku *Sku) (*Sku, error) {
+	if newSku == nil {
+		return nil, InvalidArgumentError("invalid sku")
+	}
+
+	err := repository.db.Transaction(func(tx *gorm.DB) error {
+		var product Product
+
+		if err := tx.First(&product, newSku.ProductID).Error; err != nil {
+			return err
+		}
+
+		var skus int64
+
+		if err := tx.Model(&Sku{}).Where("product_id = ?", product.ID).Count(&skus).Error; err != nil {
+			return err
+		}
+
+		if skus == 0 && product.Quantity != 0 {
+			return FailedPreconditionError("product with existing stock cannot be split into skus")
+		}
+
+		if err := tx.Create(newSku).Error; err != nil {
+			slog.Error("failed to create sku", "operation", "create", "entity", "sku", "product_id", product.ID, "error", err)
+			return fmt.Errorf("error in creating a new sku: %w", err)
+		}
+
+		if newSku.Quantity > 0 {
+			warehouseID, err := defaultWarehouseID(tx)
+
+			if err != nil {
+				return err
+			}
+
+			if err := changeStock(tx, warehouseID, product.ID, newSku.ID, newSku.Quantity); err != nil {
+				return err
+			}
+		}
+
+		return syncProductQuantity(tx, product.ID, 0)
+	})
+
+	if err != nil {
+		return nil, err
+	}
+
+	return newSku, nil
+}
+
+func (repository *GormSkuRepository) GetSku(id int32) (*Sku, error) {
+	var sku *Sku
+
+	if err := repository.db.First(&sku, id).Error; err != nil {
+		return nil, err
+	}
+
+	return sku, nil
+}
+
+func (repository *GormSkuRepository) FindSkuByCode(code string) (*Sku, error) {
+	var sku *Sku
+
+	result := repository.db.Where("code = ?", code).Find(&sku)
+
+	if result.Error != nil {
+		return nil, result.Error
+	}
+
+	if result.RowsAffected == 0 {
+		return nil, nil
+	}
+
+	return sku, nil
+}
+
+func (repository *GormSkuRepository) UpdateSku(id int32, fields map[string]interface{}) (*Sku, error) {
+	var sku *Sku
+
+	if len(fields) == 0 {
+		return nil, InvalidArgumentError("no sku details to update")
+	}
+
+	if err := repository.db.First(&sku, id).Error; err != nil {
+		return nil, err
+	}
+
+	if err := repository.db.Model(&sku).Updates(fields).Error; err != nil {
+		slog.Error("failed to update sku", "operation", "update", "entity", "sku", "sku_id", id, "error", err)
+		return nil, fmt.Errorf("failed to update sku: %w", err)
+	}
+
+	return sku, nil
+}
+
+func (repository *GormSkuRepository) DeleteSku(id int32) error {
+	return repository.db.Transaction(func(tx *gorm.DB) error {
+		var sku Sku
+
+		if err := tx.First(&sku, id).Error; err != nil {
+			return NotFoundError("sku with id %d does not exist", id)
+		}
+
+		if err := tx.Where("sku_id = ?", sku.ID).Delete(&StockLevel{}).Error; err != nil {
+			return err
+		}
+
+		if err := tx.Delete(&sku).Error; err != nil {
+			return err
+		}
+
+		return syncProductQuantity(tx, sku.ProductID, 0)
+	})
+}
+
+func hasSkus(tx *gorm.DB, productID int64) (bool, error) {
+	var skus int64
+
+	if err := tx.Model(&Sku{}).Where("product_id = ?", productID).Count(&skus).Error; err != nil {
+		return false, err
+	}
+
+	return skus > 0, nil
+}
+
+func syncStock(tx *gorm.DB, productID int64, skuID int64, expectedVersion int64) error {
+	if skuID != 0 {
+		quantity := tx.Model(&StockLevel{}).Select("COALESCE(SUM(quantity), 0)").Where("sku_id = ?", skuID)
+
+		if err := tx.Model(&Sku{}).Where("id = ?", skuID).Update("quantity", quantity).Error; err != nil {
+			return err
+		}
+	}
+
+	return syncProductQuantity(tx, productID, expectedVersion)
+}
+
+func syncProductQuantity(tx *gorm.DB, productID int64, expectedVersion int64) error {
+	var before Product
+
+	if err := tx.Select("id", "quantity").Where("id = ?", productID).Find(&before).Error; err != nil {
+		return err
+	}
+
+	query := tx.Model(&Product{}).Where("id = ?", productID)
+
+	if expectedVersion != 0 {
+		query = query.Where("version = ?", expectedVersion)
+	}
+
+	result := query.Updates(map[string]interface{}{
+		"quantity": tx.Model(&StockLevel{}).Select("COALESCE(SUM(quantity), 0)").Where("product_id = ?", productID),
+		"version":  gorm.Expr("version + 1"),
+	})
+
+	if result.Error != nil {
+		return result.Error
+	}
+
+	if result.RowsAffected == 0 {
+		return ErrVersionMismatch
+	}
+
+	var after Product
+
+	if err := tx.Select("id", "quantity", "version").First(&after, productID).Error; err != nil {
+		return err
+	}
+
+	if after.Quantity == before.Quantity {
+		return nil
+	}
+
+	return enqueueEvent(tx, events.StockChanged, productID, events.StockChangedPayload{
+		ProductID:        productID,
+		PreviousQuantity: before.Quantity,
+		Quantity:         after.Quantity,
+		Version:          after.Version,
+	})
+}
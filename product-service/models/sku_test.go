@@ -0,0 +1,178 @@
+package models
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"gorm.io/gorm"
+)
+
+func createProductWithSkus(t *testing.T, db *gorm.DB) *Product {
+	skus := NewGormSkuRepository(db)
+	product, err := products.CreateProduct(&Product{Name: "T-Shirt", PriceMinor: 1999, Currency: "USD"})
+	assert.NoError(t, err)
+
+	_, err = skus.CreateSku(&Sku{ProductID: product.ID, Code: "TS-S-RED", Attributes: SkuAttributes{"size": "S", "color": "red"}, Quantity: 3})
+	assert.NoError(t, err)
+
+	_, err = skus.CreateSku(&Sku{ProductID: product.ID, Code: "TS-M-RED", Attributes: SkuAttributes{"size": "M", "color": "red"}, Quantity: 4})
+	assert.NoError(t, err)
+
+	return product
+}
+
+type legacySku struct {
+	gorm.Model
+	ProductID int64  `gorm:"column:product_id"`
+	Code      string `gorm:"column:code;uniqueIndex"`
+	Quantity  int32  `gorm:"column:quantity"`
+}
+
+func (legacySku) TableName() string {
+	return "skus"
+}
+
+func TestInitSkuModelShouldReplaceTheLegacyCodeIndex(t *testing.T) {
+	db := setupDatabase(t)
+	defer teardownDatabase(db)
+
+	assert.NoError(t, db.Migrator().DropTable(&Sku{}))
+	assert.NoError(t, db.AutoMigrate(&legacySku{}))
+	assert.True(t, db.Migrator().HasIndex(&Sku{}, "idx_skus_code"))
+
+	assert.NoError(t, InitSkuModel(db))
+	assert.NoError(t, InitSkuModel(db))
+
+	assert.False(t, db.Migrator().HasIndex(&Sku{}, "idx_skus_code"))
+	assert.True(t, db.Migrator().HasIndex(&Sku{}, "idx_skus_code_active"))
+}
+
+func TestShouldCreateSkuReuseTheCodeOfADeletedSku(t *testing.T) {
+	db := setupDatabase(t)
+	defer teardownDatabase(db)
+	skus := NewGormSkuRepository(db)
+
+	product := createProductWithSkus(t, db)
+	deleted, _ := skus.FindSkuByCode("TS-S-RED")
+
+	assert.NoError(t, skus.DeleteSku(int32(deleted.ID)))
+
+	replacement, err1 := skus.CreateSku(&Sku{ProductID: product.ID, Code: "TS-S-RED", Quantity: 1})
+	duplicate, err2 := skus.CreateSku(&Sku{ProductID: product.ID, Code: "TS-S-RED", Quantity: 1})
+
+	assert.NoError(t, err1)
+	assert.NotEqual(t, deleted.ID, replacement.ID)
+	assert.Error(t, err2)
+	assert.Nil(t, duplicate)
+}
+
+func TestShouldCreateSkuSyncProductQuantity(t *testing.T) {
+	db := setupDatabase(t)
+	defer teardownDatabase(db)
+
+	createProductWithSkus(t, db)
+
+	product, err := products.GetProduct(1)
+
+	assert.NoError(t, err)
+	assert.Equal(t, int32(7), product.Quantity)
+	assert.Equal(t, 2, len(product.Skus))
+	assert.Equal(t, "S", product.Skus[0].Attributes["size"])
+}
+
+func TestShouldCreateSkuThrowAnErrorIfProductHasUnassignedStock(t *testing.T) {
+	db := setupDatabase(t)
+	defer teardownDatabase(db)
+	skus := NewGormSkuRepository(db)
+
+	product, _ := products.CreateProduct(&Product{Name: "T-Shirt", Quantity: 5})
+
+	sku, err := skus.CreateSku(&Sku{ProductID: product.ID, Code: "TS-S-RED"})
+
+	assert.Error(t, err)
+	assert.Nil(t, sku)
+	assert.Equal(t, "product with existing stock cannot be split into skus", err.Error())
+}
+
+func TestAddProductsShouldUpdateSkuStock(t *testing.T) {
+	db := setupDatabase(t)
+	defer teardownDatabase(db)
+
+	createProductWithSkus(t, db)
+
+	product, err := products.AddProducts(1, 2, 0, 5, 0)
+
+	assert.NoError(t, err)
+	assert.Equal(t, int32(12), product.Quantity)
+	assert.Equal(t, int32(9), product.Skus[1].Quantity)
+}
+
+func TestAddProductsShouldRequireSkuIfProductHasSkus(t *testing.T) {
+	db := setupDatabase(t)
+	defer teardownDatabase(db)
+
+	createProductWithSkus(t, db)
+
+	product, err := products.AddProducts(1, 0, 0, 5, 0)
+
+	assert.ErrorIs(t, err, ErrProductHasSkus)
+	assert.Nil(t, product)
+}
+
+func TestRemoveProductsShouldThrowAnErrorIfSkuStockIsInsufficient(t *testing.T) {
+	db := setupDatabase(t)
+	defer teardownDatabase(db)
+
+	createProductWithSkus(t, db)
+
+	product, err := products.RemoveProducts(1, 1, 0, 4, 0)
+
+	assert.Error(t, err)
+	assert.Nil(t, product)
+	assert.Equal(t, "too many products to be removed", err.Error())
+}
+
+func TestUpdateProductsShouldDecrementSkuStock(t *testing.T) {
+	db := setupDatabase(t)
+	defer teardownDatabase(db)
+
+	createProductWithSkus(t, db)
+
+	err := products.UpdateProducts([]int64{1, 1}, []int64{1, 2}, []int32{1, 4}, "", nil)
+	product, _ := products.GetProduct(1)
+
+	assert.NoError(t, err)
+	assert.Equal(t, int32(2), product.Quantity)
+	assert.Equal(t, int32(2), product.Skus[0].Quantity)
+	assert.Equal(t, int32(0), product.Skus[1].Quantity)
+}
+
+func TestUpdateProductsShouldRollbackIfAnySkuIsOutOfStock(t *testing.T) {
+	db := setupDatabase(t)
+	defer teardownDatabase(db)
+
+	createProductWithSkus(t, db)
+
+	err := products.UpdateProducts([]int64{1, 1}, []int64{1, 2}, []int32{1, 5}, "", nil)
+	product, _ := products.GetProduct(1)
+
+	assert.Error(t, err)
+	assert.Equal(t, "trying to order more items than there is in the inventory", err.Error())
+	assert.Equal(t, int32(7), product.Quantity)
+	assert.Equal(t, int32(3), product.Skus[0].Quantity)
+}
+
+func TestDeleteSkuShouldSyncProductQuantity(t *testing.T) {
+	db := setupDatabase(t)
+	defer teardownDatabase(db)
+	skus := NewGormSkuRepository(db)
+
+	createProductWithSkus(t, db)
+
+	err := skus.DeleteSku(1)
+	product, _ := products.GetProduct(1)
+
+	assert.NoError(t, err)
+	assert.Equal(t, int32(4), product.Quantity)
+	assert.Equal(t, 1, len(product.Skus))
+}
@@ -0,0 +1,391 @@
+package models
+
+import (
+	"errors"
+	"fmt"
+	"log/slog"
+	"math"
+	"sort"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type AllocationStrategy string
+
+const (
+	AllocationNearest   AllocationStrategy = "nearest"
+	AllocationMostStock AllocationStrategy = "most_stock"
+	AllocationPriority  AllocationStrategy = "priority"
+)
+
+type Warehouse struct {
+	gorm.Model
+	ID        int64   `gorm:"primarykey;AUTO_INCREMENT"`
+	Name      string  `gorm:"column:name;uniqueIndex:idx_warehouses_name_active,where:deleted_at IS NULL"`
+	Latitude  float64 `gorm:"column:latitude"`
+	Longitude float64 `gorm:"column:longitude"`
+	Priority  int32   `gorm:"column:priority;not null;default:0"`
+	IsDefault bool    `gorm:"column:is_default;not null;default:false"`
+}
+
+type StockLevel struct {
+	WarehouseID int64 `gorm:"column:warehouse_id;primaryKey"`
+	ProductID   int64 `gorm:"column:product_id;primaryKey;index"`
+	SkuID       int64 `gorm:"column:sku_id;primaryKey"`
+	Quantity    int32 `gorm:"column:quantity;not null;default:0"`
+}
+
+type Location struct {
+	Latitude  float64
+	Longitude float64
+}
+
+type GormWarehouseRepository struct {
+	db *gorm.DB
+}
+
+func NewGormWarehouseRepository(dbInstance *gorm.DB) *GormWarehouseRepository {
+	return &GormWarehouseRepository{db: dbInstance}
+}
+
+func InitWarehouseModel(db *gorm.DB) error {
+	if err := dropLegacyConstraint(db, &Warehouse{}, "warehouses_name_key"); err != nil {
+		return fmt.Errorf("failed to drop the legacy warehouse name constraint: %w", err)
+	}
+
+	if err := db.AutoMigrate(&Warehouse{}, &StockLevel{}); err != nil {
+		return err
+	}
+
+	if err := migrateLegacyStock(db); err != nil {
+		return fmt.Errorf("failed to migrate legacy stock into warehouses: %w", err)
+	}
+
+	return nil
+}
+
+// ParseAllocationStrategy returns fallback for an empty strategy, so callers
+// can leave the choice to the configured default.
+func ParseAllocationStrategy(strategy string, fallback AllocationStrategy) (AllocationStrategy, error) {
+	switch AllocationStrategy(strategy) {
+	case "":
+		return fallback, nil
+	case AllocationNearest, AllocationMostStock, AllocationPriority:
+		return AllocationStrategy(strategy), nil
+	default:
+		return "", InvalidArgumentError("unknown allocation strategy %s", strategy)
+	}
+}
+
+func migrateLegacyStock(db *gorm.DB) error {
+	return db.Transaction(func(tx *gorm.DB) error {
+		warehouseID, err := defaultWarehouseID(tx)
+
+		if err != nil {
+			return err
+		}
+
+		if err := tx.Exec("INSERT INTO stock_levels (warehouse_id, product_id, sku_id, quantity) SELECT ?, product_id, id, quantity FROM skus WHERE deleted_at IS NULL AND quantity > 0 AND NOT EXISTS (SELECT 1 FROM stock_levels WHERE stock_levels.sku_id = skus.id)", warehouseID).Error; err != nil {
+			return err
+		}
+
+		return tx.Exec("INSERT INTO stock_levels (warehouse_id, product_id, sku_id, quantity) SELECT ?, id, 0, quantity FROM products WHERE deleted_at IS NULL AND quantity > 0 AND NOT EXISTS (SELECT 1 FROM skus WHERE skus.product_id = products.id AND skus.deleted_at IS NULL) AND NOT EXISTS (SELECT 1 FROM stock_levels WHERE stock_levels.product_id = products.id AND stock_levels.sku_id = 0)", warehouseID).Error
+	})
+}
+
+func (repository *GormWarehouseRepository) CreateWarehouse(newWarehouse *Warehouse) (*Warehouse, error) {
+	if newWarehouse == nil {
+		return nil, InvalidArgumentError("invalid warehouse")
+	}
+
+	newWarehouse.IsDefault = false
+
+	if err := repository.db.Create(newWarehouse).Error; err != nil {
+		slog.Error("failed to create warehouse", "operation", "create", "entity", "warehouse", "error", err)
+		return nil, fmt.Errorf("error in creating a new warehouse: %w", err)
+	}
+
+	return newWarehouse, nil
+}
+
+func (repository *GormWarehouseRepository) GetAllWarehouses() ([]Warehouse, error) {
+	var warehouses []Warehouse
+
+	if err := repository.db.Find(&warehouses).Error; err != nil {
+		return nil, err
+	}
+
+	return warehouses, nil
+}
+
+func (repository *GormWarehouseRepository) GetWarehouse(id int32) (*Warehouse, error) {
+	var warehouse *Warehouse
+
+	if err := repository.db.First(&warehouse, id).Error; err != nil {
+		return nil, err
+	}
+
+	return warehouse, nil
+}
+
+func (repository *GormWarehouseRepository) FindWarehouseByName(name string) (*Warehouse, error) {
+	var warehouse *Warehouse
+
+	result := repository.db.Where("name = ?", name).Find(&warehouse)
+
+	if result.Error != nil {
+		return nil, result.Error
+	}
+
+	if result.RowsAffected == 0 {
+		return nil, nil
+	}
+
+	return warehouse, nil
+}
+
+func (repository *GormWarehouseRepository) DeleteWarehouse(id int32) error {
+	return repository.db.Transaction(func(tx *gorm.DB) error {
+		var warehouse Warehouse
+
+		if err := tx.First(&warehouse, id).Error; err != nil {
+			return NotFoundError("warehouse with id %d does not exist", id)
+		}
+
+		if warehouse.IsDefault {
+			return FailedPreconditionError("the default warehouse cannot be deleted")
+		}
+
+		var stock int64
+
+		if err := tx.Model(&StockLevel{}).Where("warehouse_id = ? AND quantity > 0", id).Count(&stock).Error; err != nil {
+			return err
+		}
+
+		if stock > 0 {
+			return FailedPreconditionError("warehouse still holds stock")
+		}
+
+		if err := tx.Where("warehouse_id = ?", id).Delete(&StockLevel{}).Error; err != nil {
+			return err
+		}
+
+		return tx.Delete(&warehouse).Error
+	})
+}
+
+func (repository *GormWarehouseRepository) TransferStock(productID int32, skuID int32, fromWarehouseID int32, toWarehouseID int32, quantity int32, expectedVersion int64) (*Product, error) {
+	if quantity <= 0 {
+		return nil, InvalidArgumentError("quantity transferred cannot be less than 0")
+	}
+
+	if fromWarehouseID == toWarehouseID {
+		return nil, InvalidArgumentError("source and destination warehouses must differ")
+	}
+
+	err := repository.db.Transaction(func(tx *gorm.DB) error {
+		var product Product
+
+		if err := tx.First(&product, productID).Error; err != nil {
+			return err
+		}
+
+		if err := checkStockTarget(tx, product.ID, int64(skuID)); err != nil {
+			return err
+		}
+
+		for _, id := range []int32{fromWarehouseID, toWarehouseID} {
+			if err := tx.First(&Warehouse{}, id).Error; err != nil {
+				return NotFoundError("warehouse with id %d does not exist", id)
+			}
+		}
+
+		if err := changeStock(tx, int64(fromWarehouseID), product.ID, int64(skuID), -quantity); err != nil {
+			if errors.Is(err, ErrInsufficientStock) {
+				return FailedPreconditionError("not enough stock in the source warehouse")
+			}
+
+			return err
+		}
+
+		if err := changeStock(tx, int64(toWarehouseID), product.ID, int64(skuID), quantity); err != nil {
+			return err
+		}
+
+		return syncProductQuantity(tx, product.ID, expectedVersion)
+	})
+
+	if err != nil {
+		return nil, err
+	}
+
+	return findProduct(repository.db, productID)
+}
+
+func defaultWarehouseID(tx *gorm.DB) (int64, error) {
+	var warehouse Warehouse
+
+	if err := tx.Where("is_default = ?", true).Attrs(Warehouse{Name: "default", IsDefault: true}).FirstOrCreate(&warehouse).Error; err != nil {
+		return 0, err
+	}
+
+	return warehouse.ID, nil
+}
+
+func checkStockTarget(tx *gorm.DB, productID int64, skuID int64) error {
+	if skuID != 0 {
+		var sku Sku
+
+		if err := tx.First(&sku, skuID).Error; err != nil {
+			return err
+		}
+
+		if sku.ProductID != productID {
+			return InvalidArgumentError("sku with id %d does not belong to product with id %d", skuID, productID)
+		}
+
+		return nil
+	}
+
+	skus, err := hasSkus(tx, productID)
+
+	if err != nil {
+		return err
+	}
+
+	if skus {
+		return ErrProductHasSkus
+	}
+
+	return nil
+}
+
+func changeStock(tx *gorm.DB, warehouseID int64, productID int64, skuID int64, delta int32) error {
+	if delta >= 0 {
+		return tx.Clauses(clause.OnConflict{
+			Columns:   []clause.Column{{Name: "warehouse_id"}, {Name: "product_id"}, {Name: "sku_id"}},
+			DoUpdates: clause.Assignments(map[string]interface{}{"quantity": gorm.Expr("stock_levels.quantity + ?", delta)}),
+		}).Create(&StockLevel{WarehouseID: warehouseID, ProductID: productID, SkuID: skuID, Quantity: delta}).Error
+	}
+
+	result := tx.Model(&StockLevel{}).
+		Where("warehouse_id = ? AND product_id = ? AND sku_id = ? AND quantity >= ?", warehouseID, productID, skuID, -delta).
+		Update("quantity", gorm.Expr("quantity + ?", delta))
+
+	if result.Error != nil {
+		return result.Error
+	}
+
+	if result.RowsAffected == 0 {
+		return ErrInsufficientStock
+	}
+
+	return nil
+}
+
+func allocateStock(tx *gorm.DB, productID int64, skuID int64, quantity int32, strategy AllocationStrategy, destination *Location) error {
+	var levels []StockLevel
+
+	if err := tx.Where("product_id = ? AND sku_id = ? AND quantity > 0", productID, skuID).Find(&levels).Error; err != nil {
+		return err
+	}
+
+	warehouseIds := make([]int64, len(levels))
+
+	for idx, level := range levels {
+		warehouseIds[idx] = level.WarehouseID
+	}
+
+	var warehouses []Warehouse
+
+	if len(warehouseIds) > 0 {
+		if err := tx.Where("id IN ?", warehouseIds).Find(&warehouses).Error; err != nil {
+			return err
+		}
+	}
+
+	rankStockLevels(levels, warehouses, strategy, destination)
+
+	for _, level := range levels {
+		if level.Quantity >= quantity {
+			return changeStock(tx, level.WarehouseID, productID, skuID, -quantity)
+		}
+	}
+
+	for _, level := range levels {
+		if quantity == 0 {
+			break
+		}
+
+		taken := level.Quantity
+
+		if taken > quantity {
+			taken = quantity
+		}
+
+		if err := changeStock(tx, level.WarehouseID, productID, skuID, -taken); err != nil {
+			return err
+		}
+
+		quantity -= taken
+	}
+
+	if quantity > 0 {
+		return ErrInsufficientStock
+	}
+
+	return nil
+}
+
+func rankStockLevels(levels []StockLevel, warehouses []Warehouse, strategy AllocationStrategy, destination *Location) {
+	byID := make(map[int64]Warehouse, len(warehouses))
+
+	for _, warehouse := range warehouses {
+		byID[warehouse.ID] = warehouse
+	}
+
+	if strategy == AllocationNearest && destination == nil {
+		strategy = AllocationPriority
+	}
+
+	sort.SliceStable(levels, func(i, j int) bool {
+		first, second := byID[levels[i].WarehouseID], byID[levels[j].WarehouseID]
+
+		switch strategy {
+		case AllocationNearest:
+			firstDistance, secondDistance := distance(first, *destination), distance(second, *destination)
+
+			if firstDistance != secondDistance {
+				return firstDistance < secondDistance
+			}
+		case AllocationMostStock:
+			if levels[i].Quantity != levels[j].Quantity {
+				return levels[i].Quantity > levels[j].Quantity
+			}
+		}
+
+		if first.Priority != second.Priority {
+			return first.Priority > second.Priority
+		}
+
+		return first.ID < second.ID
+	})
+}
+
+func distance(warehouse Warehouse, destination Location) float64 {
+	const earthRadiusKm = 6371.0
+
+	toRadians := func(degrees float64) float64 {
+		return degrees * math.Pi / 180
+	}
+
+	latitudeDelta := toRadians(destination.Latitude - warehouse.Latitude)
+	longitudeDelta := toRadians(destination.Longitude - warehouse.Longitude)
+
+	a := math.Sin(latitudeDelta/2)*math.Sin(latitudeDelta/2) +
+		math.Cos(toRadians(warehouse.Latitude))*math.Cos(toRadians(destination.Latitude))*
+			math.Sin(longitudeDelta/2)*math.Sin(longitudeDelta/2)
+
+	return 2 * earthRadiusKm * math.Asin(math.Sqrt(a))
+}
@@ -0,0 +1,283 @@
+package models
+
+import (
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"gorm.io/gorm"
+)
+
+func createWarehouses(t *testing.T, db *gorm.DB) (*Warehouse, *Warehouse) {
+	warehouses := NewGormWarehouseRepository(db)
+	east, err := warehouses.CreateWarehouse(&Warehouse{Name: "East", Latitude: 40.71, Longitude: -74.00, Priority: 1})
+	assert.NoError(t, err)
+
+	west, err := warehouses.CreateWarehouse(&Warehouse{Name: "West", Latitude: 34.05, Longitude: -118.24, Priority: 2})
+	assert.NoError(t, err)
+
+	return east, west
+}
+
+func TestShouldInitWarehouseModelCreateDefaultWarehouse(t *testing.T) {
+	db := setupDatabase(t)
+	defer teardownDatabase(db)
+	repository := NewGormWarehouseRepository(db)
+
+	warehouses, err := repository.GetAllWarehouses()
+
+	assert.NoError(t, err)
+	assert.Equal(t, 1, len(warehouses))
+	assert.True(t, warehouses[0].IsDefault)
+}
+
+func TestShouldCreateProductStockInDefaultWarehouse(t *testing.T) {
+	db := setupDatabase(t)
+	defer teardownDatabase(db)
+
+	products.CreateProduct(&Product{Name: "Lamp", Quantity: 5})
+
+	product, err := products.GetProduct(1)
+
+	assert.NoError(t, err)
+	assert.Equal(t, int32(5), product.Quantity)
+	assert.Equal(t, 1, len(product.StockLevels))
+	assert.Equal(t, int64(1), product.StockLevels[0].WarehouseID)
+}
+
+func TestShouldMigrateLegacyStockIntoDefaultWarehouse(t *testing.T) {
+	db := setupDatabase(t)
+	defer teardownDatabase(db)
+
+	db.Create(&Product{Name: "Legacy", Quantity: 7, Version: 1})
+	assert.NoError(t, InitWarehouseModel(db))
+	assert.NoError(t, InitWarehouseModel(db))
+
+	product, err := products.GetProduct(1)
+
+	assert.NoError(t, err)
+	assert.Equal(t, 1, len(product.StockLevels))
+	assert.Equal(t, int32(7), product.StockLevels[0].Quantity)
+}
+
+func TestInitWarehouseModelShouldThrowAnErrorIfLegacyStockMigrationFails(t *testing.T) {
+	db := setupDatabase(t)
+	defer teardownDatabase(db)
+
+	db.Create(&Product{Name: "Legacy", Quantity: 7, Version: 1})
+	assert.NoError(t, db.Callback().Raw().Before("gorm:raw").Register("test:reject_stock_migration", func(tx *gorm.DB) {
+		if strings.Contains(tx.Statement.SQL.String(), "INSERT INTO stock_levels") {
+			tx.AddError(errors.New("stock migration rejected"))
+		}
+	}))
+
+	err := InitWarehouseModel(db)
+
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "failed to migrate legacy stock into warehouses")
+}
+
+func TestCreateWarehouseShouldReuseTheNameOfADeletedWarehouse(t *testing.T) {
+	db := setupDatabase(t)
+	defer teardownDatabase(db)
+	warehouses := NewGormWarehouseRepository(db)
+
+	created, _ := warehouses.CreateWarehouse(&Warehouse{Name: "West"})
+
+	assert.NoError(t, warehouses.DeleteWarehouse(int32(created.ID)))
+
+	replacement, err1 := warehouses.CreateWarehouse(&Warehouse{Name: "West"})
+	duplicate, err2 := warehouses.CreateWarehouse(&Warehouse{Name: "West"})
+
+	assert.NoError(t, err1)
+	assert.NotEqual(t, created.ID, replacement.ID)
+	assert.Error(t, err2)
+	assert.Nil(t, duplicate)
+}
+
+func TestAddProductsShouldStockTheGivenWarehouse(t *testing.T) {
+	db := setupDatabase(t)
+	defer teardownDatabase(db)
+
+	_, west := createWarehouses(t, db)
+	products.CreateProduct(&Product{Name: "Lamp", Quantity: 5})
+
+	product, err := products.AddProducts(1, 0, int32(west.ID), 3, 0)
+
+	assert.NoError(t, err)
+	assert.Equal(t, int32(8), product.Quantity)
+	assert.Equal(t, 2, len(product.StockLevels))
+}
+
+func TestAddProductsShouldThrowAnErrorIfWarehouseDoesNotExist(t *testing.T) {
+	db := setupDatabase(t)
+	defer teardownDatabase(db)
+
+	products.CreateProduct(&Product{Name: "Lamp", Quantity: 5})
+
+	product, err := products.AddProducts(1, 0, 1001, 3, 0)
+
+	assert.Error(t, err)
+	assert.Nil(t, product)
+	assert.Equal(t, "warehouse with id 1001 does not exist", err.Error())
+}
+
+func TestTransferStockShouldMoveStockBetweenWarehouses(t *testing.T) {
+	db := setupDatabase(t)
+	defer teardownDatabase(db)
+	warehouses := NewGormWarehouseRepository(db)
+
+	east, _ := createWarehouses(t, db)
+	products.CreateProduct(&Product{Name: "Lamp", Quantity: 5})
+
+	product, err := warehouses.TransferStock(1, 0, 1, int32(east.ID), 2, 1)
+
+	assert.NoError(t, err)
+	assert.Equal(t, int32(5), product.Quantity)
+	assert.Equal(t, int64(2), product.Version)
+	assert.Equal(t, int32(3), product.StockLevels[0].Quantity)
+	assert.Equal(t, int32(2), product.StockLevels[1].Quantity)
+}
+
+func TestTransferStockShouldThrowAnErrorIfSourceHasNotEnoughStock(t *testing.T) {
+	db := setupDatabase(t)
+	defer teardownDatabase(db)
+	warehouses := NewGormWarehouseRepository(db)
+
+	east, _ := createWarehouses(t, db)
+	products.CreateProduct(&Product{Name: "Lamp", Quantity: 5})
+
+	product, err := warehouses.TransferStock(1, 0, 1, int32(east.ID), 6, 0)
+
+	assert.Error(t, err)
+	assert.Nil(t, product)
+	assert.Equal(t, "not enough stock in the source warehouse", err.Error())
+}
+
+func TestUpdateProductsShouldAllocateByPriority(t *testing.T) {
+	db := setupDatabase(t)
+	defer teardownDatabase(db)
+
+	east, west := createWarehouses(t, db)
+	products.CreateProduct(&Product{Name: "Lamp"})
+	products.AddProducts(1, 0, int32(east.ID), 5, 0)
+	products.AddProducts(1, 0, int32(west.ID), 5, 0)
+
+	err := products.UpdateProducts([]int64{1}, nil, []int32{4}, "priority", nil)
+	product, _ := products.GetProduct(1)
+
+	assert.NoError(t, err)
+	assert.Equal(t, int32(5), stockIn(product, east.ID))
+	assert.Equal(t, int32(1), stockIn(product, west.ID))
+}
+
+func TestUpdateProductsShouldAllocateByMostStock(t *testing.T) {
+	db := setupDatabase(t)
+	defer teardownDatabase(db)
+
+	east, west := createWarehouses(t, db)
+	products.CreateProduct(&Product{Name: "Lamp"})
+	products.AddProducts(1, 0, int32(east.ID), 8, 0)
+	products.AddProducts(1, 0, int32(west.ID), 5, 0)
+
+	err := products.UpdateProducts([]int64{1}, nil, []int32{4}, "most_stock", nil)
+	product, _ := products.GetProduct(1)
+
+	assert.NoError(t, err)
+	assert.Equal(t, int32(4), stockIn(product, east.ID))
+	assert.Equal(t, int32(5), stockIn(product, west.ID))
+}
+
+func TestUpdateProductsShouldUseTheRepositoryStrategyIfNoneIsRequested(t *testing.T) {
+	db := setupDatabase(t)
+	defer teardownDatabase(db)
+
+	east, west := createWarehouses(t, db)
+	repository := NewGormProductRepository(db, AllocationMostStock)
+	repository.CreateProduct(&Product{Name: "Lamp"})
+	repository.AddProducts(1, 0, int32(east.ID), 5, 0)
+	repository.AddProducts(1, 0, int32(west.ID), 8, 0)
+
+	err := repository.UpdateProducts([]int64{1}, nil, []int32{4}, "", nil)
+	product, _ := repository.GetProduct(1)
+
+	assert.NoError(t, err)
+	assert.Equal(t, int32(5), stockIn(product, east.ID))
+	assert.Equal(t, int32(4), stockIn(product, west.ID))
+}
+
+func TestUpdateProductsShouldAllocateByNearestWarehouse(t *testing.T) {
+	db := setupDatabase(t)
+	defer teardownDatabase(db)
+
+	east, west := createWarehouses(t, db)
+	products.CreateProduct(&Product{Name: "Lamp"})
+	products.AddProducts(1, 0, int32(east.ID), 5, 0)
+	products.AddProducts(1, 0, int32(west.ID), 5, 0)
+
+	err := products.UpdateProducts([]int64{1}, nil, []int32{4}, "nearest", &Location{Latitude: 42.36, Longitude: -71.06})
+	product, _ := products.GetProduct(1)
+
+	assert.NoError(t, err)
+	assert.Equal(t, int32(1), stockIn(product, east.ID))
+	assert.Equal(t, int32(5), stockIn(product, west.ID))
+}
+
+func TestUpdateProductsShouldSplitAcrossWarehousesIfNoneCanFulfil(t *testing.T) {
+	db := setupDatabase(t)
+	defer teardownDatabase(db)
+
+	east, west := createWarehouses(t, db)
+	products.CreateProduct(&Product{Name: "Lamp"})
+	products.AddProducts(1, 0, int32(east.ID), 5, 0)
+	products.AddProducts(1, 0, int32(west.ID), 5, 0)
+
+	err := products.UpdateProducts([]int64{1}, nil, []int32{7}, "", nil)
+	product, _ := products.GetProduct(1)
+
+	assert.NoError(t, err)
+	assert.Equal(t, int32(3), product.Quantity)
+	assert.Equal(t, int32(3), stockIn(product, east.ID))
+	assert.Equal(t, int32(0), stockIn(product, west.ID))
+}
+
+func TestUpdateProductsShouldThrowAnErrorForUnknownStrategy(t *testing.T) {
+	db := setupDatabase(t)
+	defer teardownDatabase(db)
+
+	products.CreateProduct(&Product{Name: "Lamp", Quantity: 5})
+
+	err := products.UpdateProducts([]int64{1}, nil, []int32{1}, "cheapest", nil)
+
+	assert.Error(t, err)
+	assert.Equal(t, "unknown allocation strategy cheapest", err.Error())
+}
+
+func TestDeleteWarehouseShouldThrowAnErrorIfItHoldsStock(t *testing.T) {
+	db := setupDatabase(t)
+	defer teardownDatabase(db)
+	warehouses := NewGormWarehouseRepository(db)
+
+	east, _ := createWarehouses(t, db)
+	products.CreateProduct(&Product{Name: "Lamp"})
+	products.AddProducts(1, 0, int32(east.ID), 5, 0)
+
+	err1 := warehouses.DeleteWarehouse(int32(east.ID))
+	err2 := warehouses.DeleteWarehouse(1)
+
+	assert.Error(t, err1)
+	assert.Equal(t, "warehouse still holds stock", err1.Error())
+	assert.Error(t, err2)
+	assert.Equal(t, "the default warehouse cannot be deleted", err2.Error())
+}
+
+func stockIn(product *Product, warehouseID int64) int32 {
+	for _, level := range product.StockLevels {
+		if level.WarehouseID == warehouseID {
+			return level.Quantity
+		}
+	}
+
+	return 0
+}
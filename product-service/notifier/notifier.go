@@ -0,0 +1,82 @@
+package notifier
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log/slog"
+	"net/http"
+	"time"
+)
+
+type AlertKind string
+
+const (
+	LowStock  AlertKind = "low_stock"
+	Restocked AlertKind = "restocked"
+)
+
+type Alert struct {
+	Kind        AlertKind `json:"kind"`
+	ProductID   int64     `json:"productId"`
+	ProductName string    `json:"productName"`
+	Quantity    int32     `json:"quantity"`
+	Threshold   int32     `json:"threshold"`
+	At          time.Time `json:"at"`
+}
+
+type Notifier interface {
+	Notify(alert Alert) error
+}
+
+type LogNotifier struct{}
+
+func (LogNotifier) Notify(alert Alert) error {
+	slog.Warn("stock alert", "kind", alert.Kind, "product_id", alert.ProductID, "product_name", alert.ProductName, "quantity", alert.Quantity, "threshold", alert.Threshold)
+	return nil
+}
+
+type WebhookNotifier struct {
+	URL    string
+	Client *http.Client
+}
+
+func NewWebhookNotifier(url string) *WebhookNotifier {
+	return &WebhookNotifier{URL: url, Client: &http.Client{Timeout: 5 * time.Second}}
+}
+
+func (notifier *WebhookNotifier) Notify(alert Alert) error {
+	body, err := json.Marshal(alert)
+
+	if err != nil {
+		return err
+	}
+
+	res, err := notifier.Client.Post(notifier.URL, "application/json", bytes.NewReader(body))
+
+	if err != nil {
+		return err
+	}
+	defer res.Body.Close()
+
+	if res.StatusCode >= http.StatusMultipleChoices {
+		return fmt.Errorf("webhook responded with status %d", res.StatusCode)
+	}
+
+	return nil
+}
+
+type MultiNotifier []Notifier
+
+func (notifiers MultiNotifier) Notify(alert Alert) error {
+	var errs []error
+
+	for _, notifier := range notifiers {
+		if err := notifier.Notify(alert); err != nil {
+			errs = append(errs, err)
+		}
+	}
+
+	return errors.Join(errs...)
+}
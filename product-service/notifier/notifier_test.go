@@ -0,0 +1,61 @@
+package notifier
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type failingNotifier struct{}
+
+func (failingNotifier) Notify(alert Alert) error {
+	return errors.New("sink unavailable")
+}
+
+func TestWebhookNotifierShouldPostAlertAsJson(t *testing.T) {
+	var received Alert
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "application/json", r.Header.Get("Content-Type"))
+		assert.NoError(t, json.NewDecoder(r.Body).Decode(&received))
+		w.WriteHeader(http.StatusNoContent)
+	}))
+	defer server.Close()
+
+	err := NewWebhookNotifier(server.URL).Notify(Alert{Kind: LowStock, ProductID: 1, Quantity: 2, Threshold: 5})
+
+	assert.NoError(t, err)
+	assert.Equal(t, LowStock, received.Kind)
+	assert.Equal(t, int64(1), received.ProductID)
+}
+
+func TestWebhookNotifierShouldFailOnErrorStatus(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusInternalServerError)
+	}))
+	defer server.Close()
+
+	err := NewWebhookNotifier(server.URL).Notify(Alert{Kind: LowStock})
+
+	assert.Error(t, err)
+	assert.Equal(t, "webhook responded with status 500", err.Error())
+}
+
+func TestMultiNotifierShouldNotifyEverySink(t *testing.T) {
+	calls := 0
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls++
+	}))
+	defer server.Close()
+
+	err := MultiNotifier{failingNotifier{}, NewWebhookNotifier(server.URL), LogNotifier{}}.Notify(Alert{Kind: Restocked})
+
+	assert.Error(t, err)
+	assert.Equal(t, "sink unavailable", err.Error())
+	assert.Equal(t, 1, calls)
+}
@@ -0,0 +1,94 @@
+package server
+
+import (
+	"context"
+	"product-service/models"
+	proto "product-service/proto/product"
+
+	"google.golang.org/protobuf/types/known/emptypb"
+)
+
+func (s *GRPCServer) CreateCategory(ctx context.Context, req *proto.CreateCategoryRequest) (*proto.Category, error) {
+	category, err := s.categories.CreateCategory(req.Name, req.Description, req.ParentId)
+
+	if err != nil {
+		return nil, err
+	}
+
+	return toCategoryResponse(category), nil
+}
+
+func (s *GRPCServer) GetAllCategories(ctx context.Context, _ *emptypb.Empty) (*proto.GetAllCategoriesResponse, error) {
+	categories, err := s.categories.GetAllCategories()
+
+	if err != nil {
+		return nil, err
+	}
+
+	categoriesResponse := make([]*proto.Category, len(categories))
+
+	for idx := range categories {
+		categoriesResponse[idx] = toCategoryResponse(&categories[idx])
+	}
+
+	return &proto.GetAllCategoriesResponse{Categories: categoriesResponse}, nil
+}
+
+func (s *GRPCServer) GetCategory(ctx context.Context, req *proto.CategoryIdRequest) (*proto.Category, error) {
+	category, err := s.categories.GetCategory(req.Id)
+
+	if err != nil {
+		return nil, err
+	}
+
+	return toCategoryResponse(category), nil
+}
+
+func (s *GRPCServer) UpdateCategory(ctx context.Context, req *proto.UpdateCategoryRequest) (*proto.Category, error) {
+	category, err := s.categories.UpdateCategory(req.Id, req.Name, req.Description, req.ParentId, req.UpdateMask.GetPaths())
+
+	if err != nil {
+		return nil, err
+	}
+
+	return toCategoryResponse(category), nil
+}
+
+func (s *GRPCServer) DeleteCategory(ctx context.Context, req *proto.CategoryIdRequest) (*proto.CategoryIdRequest, error) {
+	if err := s.categories.DeleteCategory(req.Id); err != nil {
+		return nil, err
+	}
+
+	return &proto.CategoryIdRequest{Id: req.Id}, nil
+}
+
+func (s *GRPCServer) AddProductToCategory(ctx context.Context, req *proto.ProductCategoryRequest) (*proto.ProductCategoryRequest, error) {
+	if err := s.categories.AddProductToCategory(req.ProductId, req.CategoryId); err != nil {
+		return nil, err
+	}
+
+	return &proto.ProductCategoryRequest{ProductId: req.ProductId, CategoryId: req.CategoryId}, nil
+}
+
+func (s *GRPCServer) RemoveProductFromCategory(ctx context.Context, req *proto.ProductCategoryRequest) (*proto.ProductCategoryRequest, error) {
+	if err := s.categories.RemoveProductFromCategory(req.ProductId, req.CategoryId); err != nil {
+		return nil, err
+	}
+
+	return &proto.ProductCategoryRequest{ProductId: req.ProductId, CategoryId: req.CategoryId}, nil
+}
+
+func toCategoryResponse(category *models.Category) *proto.Category {
+	var parentID int32
+
+	if category.ParentID != nil {
+		parentID = int32(*category.ParentID)
+	}
+
+	return &proto.Category{
+		Id:          int32(category.ID),
+		Name:        category.Name,
+		Description: category.Description,
+		ParentId:    parentID,
+	}
+}
@@ -0,0 +1,76 @@
+package server
+
+import (
+	"context"
+	proto "product-service/proto/product"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/protobuf/types/known/emptypb"
+	"google.golang.org/protobuf/types/known/fieldmaskpb"
+)
+
+func Test_Server_ShouldCreateCategoryWorkCorrectly(t *testing.T) {
+	db := setupDatabase(t)
+	defer teardownDatabase(db)
+
+	root, err1 := server.CreateCategory(context.Background(), &proto.CreateCategoryRequest{Name: "Clothing"})
+	child, err2 := server.CreateCategory(context.Background(), &proto.CreateCategoryRequest{Name: "Men", ParentId: root.Id})
+	categories, err3 := server.GetAllCategories(context.Background(), &emptypb.Empty{})
+
+	assert.NoError(t, err1)
+	assert.NoError(t, err2)
+	assert.NoError(t, err3)
+	assert.Equal(t, int32(0), root.ParentId)
+	assert.Equal(t, root.Id, child.ParentId)
+	assert.Equal(t, 2, len(categories.Categories))
+}
+
+func Test_Server_UpdateCategoryShouldWorkCorrectly(t *testing.T) {
+	db := setupDatabase(t)
+	defer teardownDatabase(db)
+
+	server.CreateCategory(context.Background(), &proto.CreateCategoryRequest{Name: "Clothing"})
+
+	category, err := server.UpdateCategory(context.Background(), &proto.UpdateCategoryRequest{
+		Id:         1,
+		Name:       "Apparel",
+		UpdateMask: &fieldmaskpb.FieldMask{Paths: []string{"name"}},
+	})
+
+	assert.NoError(t, err)
+	assert.Equal(t, "Apparel", category.Name)
+}
+
+func Test_Server_GetAllProductsShouldFilterByCategory(t *testing.T) {
+	db := setupDatabase(t)
+	defer teardownDatabase(db)
+
+	server.CreateProduct(context.Background(), &proto.CreateProductRequest{Name: "Sneakers", Price: &proto.Money{MinorUnits: 4999}, Quantity: 10})
+	server.CreateProduct(context.Background(), &proto.CreateProductRequest{Name: "Phone", Price: &proto.Money{MinorUnits: 49999}, Quantity: 10})
+	clothing, _ := server.CreateCategory(context.Background(), &proto.CreateCategoryRequest{Name: "Clothing"})
+	shoes, _ := server.CreateCategory(context.Background(), &proto.CreateCategoryRequest{Name: "Shoes", ParentId: clothing.Id})
+
+	_, err1 := server.AddProductToCategory(context.Background(), &proto.ProductCategoryRequest{ProductId: 1, CategoryId: shoes.Id})
+	products, err2 := server.GetAllProducts(context.Background(), &proto.GetAllProductsRequest{CategoryId: clothing.Id})
+
+	assert.NoError(t, err1)
+	assert.NoError(t, err2)
+	assert.Equal(t, 1, len(products.Products))
+	assert.Equal(t, "Sneakers", products.Products[0].Name)
+}
+
+func Test_Server_ShouldDeleteCategoryWorkCorrectly(t *testing.T) {
+	db := setupDatabase(t)
+	defer teardownDatabase(db)
+
+	server.CreateCategory(context.Background(), &proto.CreateCategoryRequest{Name: "Clothing"})
+
+	deletedCategory, err1 := server.DeleteCategory(context.Background(), &proto.CategoryIdRequest{Id: 1})
+	category, err2 := server.GetCategory(context.Background(), &proto.CategoryIdRequest{Id: 1})
+
+	assert.NoError(t, err1)
+	assert.Equal(t, int32(1), deletedCategory.Id)
+	assert.Error(t, err2)
+	assert.Nil(t, category)
+}
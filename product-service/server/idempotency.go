@@ -0,0 +1,170 @@
+package server
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"log/slog"
+	proto "product-service/proto/product"
+	"product-service/services"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+	protobuf "google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protoreflect"
+	"google.golang.org/protobuf/reflect/protoregistry"
+)
+
+const (
+	IdempotencyKeyHeader   = "idempotency-key"
+	UserIdHeader           = "user-id"
+	IdempotentReplayHeader = "idempotent-replayed"
+)
+
+var (
+	idempotentMethods = map[string]bool{
+		proto.ProductService_AddProducts_FullMethodName:    true,
+		proto.ProductService_RemoveProducts_FullMethodName: true,
+		proto.ProductService_UpdateProducts_FullMethodName: true,
+		proto.ProductService_TransferStock_FullMethodName:  true,
+	}
+)
+
+// NewIdempotencyInterceptor stores the responses of the idempotent methods
+// through idempotency and replays them for retried requests carrying the same
+// key.
+//
+// The response is stored after the handler's own transaction has committed,
+// so delivery is at least once: if storing fails or the process dies in
+// between, the key stays reserved without a response and a retry arriving
+// after the lease expired runs the request again.
+func NewIdempotencyInterceptor(idempotency *services.IdempotencyService) grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+		if !idempotentMethods[info.FullMethod] {
+			return handler(ctx, req)
+		}
+
+		key := metadataValue(ctx, IdempotencyKeyHeader)
+
+		if len(key) == 0 {
+			return handler(ctx, req)
+		}
+
+		message, ok := req.(protobuf.Message)
+
+		if !ok {
+			return handler(ctx, req)
+		}
+
+		fingerprint, err := requestFingerprint(info.FullMethod, message)
+
+		if err != nil {
+			return nil, err
+		}
+
+		caller := metadataValue(ctx, UserIdHeader)
+		record, err := idempotency.BeginIdempotentRequest(caller, key, info.FullMethod, fingerprint)
+
+		if err != nil {
+			return nil, idempotencyError(err)
+		}
+
+		if record != nil {
+			response, err := replayResponse(record.ResponseType, record.Response)
+
+			if err != nil {
+				return nil, err
+			}
+
+			grpc.SetHeader(ctx, metadata.Pairs(IdempotentReplayHeader, "true"))
+
+			return response, nil
+		}
+
+		response, err := handler(ctx, req)
+
+		if err != nil {
+			if abandonErr := idempotency.AbandonIdempotentRequest(caller, key); abandonErr != nil {
+				slog.ErrorContext(ctx, "failed to release idempotency key", "key", key, "error", abandonErr)
+			}
+
+			return response, err
+		}
+
+		if message, ok := response.(protobuf.Message); ok {
+			bytes, err := protobuf.Marshal(message)
+
+			if err == nil {
+				err = idempotency.CompleteIdempotentRequest(caller, key, string(message.ProtoReflect().Descriptor().FullName()), bytes)
+			}
+
+			// The change is already committed, so the caller still gets its
+			// response and a retry after the lease re-executes the request.
+			if err != nil {
+				slog.ErrorContext(ctx, "failed to store the idempotent response", "key", key, "error", err)
+			}
+		}
+
+		return response, nil
+	}
+}
+
+func metadataValue(ctx context.Context, header string) string {
+	md, ok := metadata.FromIncomingContext(ctx)
+
+	if !ok {
+		return ""
+	}
+
+	values := md.Get(header)
+
+	if len(values) == 0 {
+		return ""
+	}
+
+	return values[0]
+}
+
+func requestFingerprint(method string, message protobuf.Message) (string, error) {
+	bytes, err := protobuf.MarshalOptions{Deterministic: true}.Marshal(message)
+
+	if err != nil {
+		return "", err
+	}
+
+	hash := sha256.New()
+	hash.Write([]byte(method))
+	hash.Write(bytes)
+
+	return hex.EncodeToString(hash.Sum(nil)), nil
+}
+
+func replayResponse(responseType string, bytes []byte) (protobuf.Message, error) {
+	messageType, err := protoregistry.GlobalTypes.FindMessageByName(protoreflect.FullName(responseType))
+
+	if err != nil {
+		return nil, err
+	}
+
+	response := messageType.New().Interface()
+
+	if err := protobuf.Unmarshal(bytes, response); err != nil {
+		return nil, err
+	}
+
+	return response, nil
+}
+
+func idempotencyError(err error) error {
+	switch {
+	case errors.Is(err, services.ErrIdempotencyKeyReused):
+		return status.Error(codes.FailedPrecondition, err.Error())
+	case errors.Is(err, services.ErrIdempotencyKeyInProgress):
+		return status.Error(codes.AlreadyExists, err.Error())
+	}
+
+	return err
+}
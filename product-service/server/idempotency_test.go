@@ -0,0 +1,141 @@
+package server
+
+import (
+	"context"
+	"errors"
+	"product-service/models"
+	proto "product-service/proto/product"
+	"product-service/services"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+	"gorm.io/gorm"
+)
+
+func newIdempotencyService(t *testing.T, db *gorm.DB) *services.IdempotencyService {
+	idempotency, err := services.NewIdempotencyService(models.NewGormIdempotencyRepository(db), 24*time.Hour, 30*time.Second)
+	assert.NoError(t, err)
+
+	return idempotency
+}
+
+func addProducts(ctx context.Context, req interface{}) (interface{}, error) {
+	return server.AddProducts(ctx, req.(*proto.UpdateProductQuantityRequest))
+}
+
+func Test_Server_IdempotencyInterceptorShouldReplayDuplicates(t *testing.T) {
+	db := setupDatabase(t)
+	defer teardownDatabase(db)
+
+	server.CreateProduct(context.Background(), &proto.CreateProductRequest{Name: "Lamp", Price: &proto.Money{MinorUnits: 2999}, Quantity: 5})
+
+	ctx := metadata.NewIncomingContext(context.Background(), metadata.Pairs(IdempotencyKeyHeader, "retry-1"))
+	info := &grpc.UnaryServerInfo{FullMethod: proto.ProductService_AddProducts_FullMethodName}
+	interceptor := NewIdempotencyInterceptor(newIdempotencyService(t, db))
+
+	first, err1 := interceptor(ctx, &proto.UpdateProductQuantityRequest{Id: 1, Quantity: 3}, info, addProducts)
+	second, err2 := interceptor(ctx, &proto.UpdateProductQuantityRequest{Id: 1, Quantity: 3}, info, addProducts)
+	product, _ := server.GetProduct(context.Background(), &proto.ProductIdRequest{Id: 1})
+
+	assert.NoError(t, err1)
+	assert.NoError(t, err2)
+	assert.Equal(t, int32(8), first.(*proto.CreateProductResponse).Quantity)
+	assert.Equal(t, int32(8), second.(*proto.CreateProductResponse).Quantity)
+	assert.Equal(t, int32(8), product.Quantity)
+}
+
+func Test_Server_IdempotencyInterceptorShouldRejectKeyReuseWithADifferentPayload(t *testing.T) {
+	db := setupDatabase(t)
+	defer teardownDatabase(db)
+
+	server.CreateProduct(context.Background(), &proto.CreateProductRequest{Name: "Lamp", Price: &proto.Money{MinorUnits: 2999}, Quantity: 5})
+
+	ctx := metadata.NewIncomingContext(context.Background(), metadata.Pairs(IdempotencyKeyHeader, "retry-1"))
+	info := &grpc.UnaryServerInfo{FullMethod: proto.ProductService_AddProducts_FullMethodName}
+	interceptor := NewIdempotencyInterceptor(newIdempotencyService(t, db))
+
+	interceptor(ctx, &proto.UpdateProductQuantityRequest{Id: 1, Quantity: 3}, info, addProducts)
+	_, err := interceptor(ctx, &proto.UpdateProductQuantityRequest{Id: 1, Quantity: 4}, info, addProducts)
+
+	assert.Error(t, err)
+	assert.Equal(t, codes.FailedPrecondition, status.Code(err))
+}
+
+func Test_Server_IdempotencyInterceptorShouldNotStoreFailures(t *testing.T) {
+	db := setupDatabase(t)
+	defer teardownDatabase(db)
+
+	server.CreateProduct(context.Background(), &proto.CreateProductRequest{Name: "Lamp", Price: &proto.Money{MinorUnits: 2999}, Quantity: 5})
+
+	ctx := metadata.NewIncomingContext(context.Background(), metadata.Pairs(IdempotencyKeyHeader, "retry-1"))
+	info := &grpc.UnaryServerInfo{FullMethod: proto.ProductService_RemoveProducts_FullMethodName}
+	interceptor := NewIdempotencyInterceptor(newIdempotencyService(t, db))
+	removeProducts := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return server.RemoveProducts(ctx, req.(*proto.UpdateProductQuantityRequest))
+	}
+
+	_, err1 := interceptor(ctx, &proto.UpdateProductQuantityRequest{Id: 1, Quantity: 10}, info, removeProducts)
+	server.AddProducts(context.Background(), &proto.UpdateProductQuantityRequest{Id: 1, Quantity: 5})
+	product, err2 := interceptor(ctx, &proto.UpdateProductQuantityRequest{Id: 1, Quantity: 10}, info, removeProducts)
+
+	assert.Error(t, err1)
+	assert.NoError(t, err2)
+	assert.Equal(t, int32(0), product.(*proto.CreateProductResponse).Quantity)
+}
+
+func Test_Server_IdempotencyInterceptorShouldRunTheRequestAgainIfItsResponseWasNotStored(t *testing.T) {
+	db := setupDatabase(t)
+	defer teardownDatabase(db)
+
+	server.CreateProduct(context.Background(), &proto.CreateProductRequest{Name: "Lamp", Price: &proto.Money{MinorUnits: 2999}, Quantity: 5})
+
+	idempotency, _ := services.NewIdempotencyService(models.NewGormIdempotencyRepository(db), 24*time.Hour, time.Millisecond)
+	ctx := metadata.NewIncomingContext(context.Background(), metadata.Pairs(IdempotencyKeyHeader, "retry-1"))
+	info := &grpc.UnaryServerInfo{FullMethod: proto.ProductService_AddProducts_FullMethodName}
+	interceptor := NewIdempotencyInterceptor(idempotency)
+
+	assert.NoError(t, db.Callback().Update().Before("gorm:update").Register("test:reject_idempotent_response", func(tx *gorm.DB) {
+		if tx.Statement.Table == "idempotency_records" {
+			tx.AddError(errors.New("response rejected"))
+		}
+	}))
+
+	first, err1 := interceptor(ctx, &proto.UpdateProductQuantityRequest{Id: 1, Quantity: 3}, info, addProducts)
+
+	assert.NoError(t, db.Callback().Update().Remove("test:reject_idempotent_response"))
+	time.Sleep(5 * time.Millisecond)
+
+	second, err2 := interceptor(ctx, &proto.UpdateProductQuantityRequest{Id: 1, Quantity: 3}, info, addProducts)
+	third, err3 := interceptor(ctx, &proto.UpdateProductQuantityRequest{Id: 1, Quantity: 3}, info, addProducts)
+
+	assert.NoError(t, err1)
+	assert.Equal(t, int32(8), first.(*proto.CreateProductResponse).Quantity)
+	assert.NoError(t, err2)
+	assert.Equal(t, int32(11), second.(*proto.CreateProductResponse).Quantity)
+	assert.NoError(t, err3)
+	assert.Equal(t, int32(11), third.(*proto.CreateProductResponse).Quantity)
+}
+
+func Test_Server_IdempotencyInterceptorShouldScopeKeysByUser(t *testing.T) {
+	db := setupDatabase(t)
+	defer teardownDatabase(db)
+
+	server.CreateProduct(context.Background(), &proto.CreateProductRequest{Name: "Lamp", Price: &proto.Money{MinorUnits: 2999}, Quantity: 5})
+
+	first := metadata.NewIncomingContext(context.Background(), metadata.Pairs(IdempotencyKeyHeader, "retry-1", UserIdHeader, "1"))
+	second := metadata.NewIncomingContext(context.Background(), metadata.Pairs(IdempotencyKeyHeader, "retry-1", UserIdHeader, "2"))
+	info := &grpc.UnaryServerInfo{FullMethod: proto.ProductService_AddProducts_FullMethodName}
+	interceptor := NewIdempotencyInterceptor(newIdempotencyService(t, db))
+
+	_, err1 := interceptor(first, &proto.UpdateProductQuantityRequest{Id: 1, Quantity: 3}, info, addProducts)
+	res, err2 := interceptor(second, &proto.UpdateProductQuantityRequest{Id: 1, Quantity: 4}, info, addProducts)
+
+	assert.NoError(t, err1)
+	assert.NoError(t, err2)
+	assert.Equal(t, int32(12), res.(*proto.CreateProductResponse).Quantity)
+}
@@ -0,0 +1,92 @@
+package server
+
+import (
+	"errors"
+	"io"
+	"product-service/models"
+	proto "product-service/proto/product"
+	"product-service/services"
+)
+
+func (s *GRPCServer) ImportProducts(stream proto.ProductService_ImportProductsServer) error {
+	var importer *services.Importer
+
+	for {
+		req, err := stream.Recv()
+
+		if err == io.EOF {
+			break
+		}
+
+		if err != nil {
+			return err
+		}
+
+		row := services.ImportRow{
+			Row:              req.Row,
+			Name:             req.Name,
+			Description:      req.Description,
+			Currency:         req.Price.GetCurrencyCode(),
+			Quantity:         req.Quantity,
+			ReorderThreshold: req.ReorderThreshold,
+		}
+
+		if req.Price != nil {
+			priceMinor := req.Price.GetMinorUnits()
+			row.PriceMinor = &priceMinor
+		}
+
+		if importer == nil {
+			importer = services.NewImporter(s.products, req.DryRun)
+		}
+
+		if req.DryRun != importer.DryRun() {
+			importer.Fail(row, errors.New("dry run must be the same for every row"))
+			continue
+		}
+
+		importer.Import(row)
+	}
+
+	if importer == nil {
+		importer = services.NewImporter(s.products, false)
+	}
+
+	result := importer.Result()
+	rowErrors := make([]*proto.ImportRowError, len(result.Errors))
+
+	for idx, rowError := range result.Errors {
+		rowErrors[idx] = &proto.ImportRowError{Row: rowError.Row, Name: rowError.Name, Message: rowError.Message}
+	}
+
+	return stream.SendAndClose(&proto.ImportProductsResponse{
+		Created: result.Created,
+		Updated: result.Updated,
+		Failed:  int32(len(result.Errors)),
+		DryRun:  result.DryRun,
+		Errors:  rowErrors,
+	})
+}
+
+func (s *GRPCServer) ExportProducts(req *proto.ExportProductsRequest, stream proto.ProductService_ExportProductsServer) error {
+	var products []models.Product
+	var err error
+
+	if req.CategoryId != 0 {
+		products, err = s.categories.GetProductsByCategory(req.CategoryId)
+	} else {
+		products, err = s.products.GetAllProducts()
+	}
+
+	if err != nil {
+		return err
+	}
+
+	for idx := range products {
+		if err := stream.Send(toProductResponse(&products[idx])); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,102 @@
+package server
+
+import (
+	"context"
+	"io"
+	"net"
+	proto "product-service/proto/product"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/test/bufconn"
+)
+
+func startClient(t *testing.T) proto.ProductServiceClient {
+	listener := bufconn.Listen(1024 * 1024)
+	grpcServer := grpc.NewServer()
+	proto.RegisterProductServiceServer(grpcServer, server)
+
+	go grpcServer.Serve(listener)
+	t.Cleanup(grpcServer.Stop)
+
+	conn, err := grpc.Dial("bufnet",
+		grpc.WithContextDialer(func(ctx context.Context, _ string) (net.Conn, error) { return listener.DialContext(ctx) }),
+		grpc.WithTransportCredentials(insecure.NewCredentials()))
+	assert.NoError(t, err)
+	t.Cleanup(func() { conn.Close() })
+
+	return proto.NewProductServiceClient(conn)
+}
+
+func Test_Server_ImportProductsShouldStreamRows(t *testing.T) {
+	db := setupDatabase(t)
+	defer teardownDatabase(db)
+
+	client := startClient(t)
+	stream, err := client.ImportProducts(context.Background())
+	assert.NoError(t, err)
+
+	quantity := int32(5)
+
+	stream.Send(&proto.ImportProductRow{Row: 1, Name: "Lamp", Price: &proto.Money{MinorUnits: 2999}, Quantity: &quantity})
+	stream.Send(&proto.ImportProductRow{Row: 2, Name: "Desk", Price: &proto.Money{MinorUnits: -1}})
+	stream.Send(&proto.ImportProductRow{Row: 3, Name: "Lamp", Price: &proto.Money{MinorUnits: 3499}})
+	res, err := stream.CloseAndRecv()
+	lamp, _ := server.GetProduct(context.Background(), &proto.ProductIdRequest{Id: 1})
+
+	assert.NoError(t, err)
+	assert.Equal(t, int32(1), res.Created)
+	assert.Equal(t, int32(1), res.Updated)
+	assert.Equal(t, int32(1), res.Failed)
+	assert.Equal(t, int32(2), res.Errors[0].Row)
+	assert.Equal(t, int64(3499), lamp.Price.MinorUnits)
+	assert.Equal(t, int32(5), lamp.Quantity)
+}
+
+func Test_Server_ImportProductsShouldRejectMixedDryRun(t *testing.T) {
+	db := setupDatabase(t)
+	defer teardownDatabase(db)
+
+	client := startClient(t)
+	stream, _ := client.ImportProducts(context.Background())
+
+	stream.Send(&proto.ImportProductRow{Row: 1, Name: "Lamp", DryRun: true})
+	stream.Send(&proto.ImportProductRow{Row: 2, Name: "Desk"})
+	res, err := stream.CloseAndRecv()
+	products, _ := server.GetAllProducts(context.Background(), &proto.GetAllProductsRequest{})
+
+	assert.NoError(t, err)
+	assert.True(t, res.DryRun)
+	assert.Equal(t, int32(1), res.Created)
+	assert.Equal(t, "dry run must be the same for every row", res.Errors[0].Message)
+	assert.Empty(t, products.Products)
+}
+
+func Test_Server_ExportProductsShouldStreamEveryProduct(t *testing.T) {
+	db := setupDatabase(t)
+	defer teardownDatabase(db)
+
+	server.CreateProduct(context.Background(), &proto.CreateProductRequest{Name: "Lamp", Price: &proto.Money{MinorUnits: 2999}, Quantity: 5})
+	server.CreateProduct(context.Background(), &proto.CreateProductRequest{Name: "Desk", Price: &proto.Money{MinorUnits: 9999}, Quantity: 1})
+
+	client := startClient(t)
+	stream, err := client.ExportProducts(context.Background(), &proto.ExportProductsRequest{})
+	assert.NoError(t, err)
+
+	var names []string
+
+	for {
+		product, err := stream.Recv()
+
+		if err == io.EOF {
+			break
+		}
+
+		assert.NoError(t, err)
+		names = append(names, product.Name)
+	}
+
+	assert.Equal(t, []string{"Lamp", "Desk"}, names)
+}
@@ -0,0 +1,55 @@
+package server
+
+import (
+	proto "product-service/proto/product"
+	"product-service/watcher"
+
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+func (s *GRPCServer) WatchInventory(req *proto.WatchInventoryRequest, stream proto.ProductService_WatchInventoryServer) error {
+	productIDs := make([]int64, len(req.ProductIds))
+
+	for idx, id := range req.ProductIds {
+		productIDs[idx] = int64(id)
+	}
+
+	products, subscription, err := s.products.WatchInventory(productIDs)
+
+	if err != nil {
+		return err
+	}
+	defer subscription.Close()
+
+	for _, product := range products {
+		if err := stream.Send(toInventoryUpdate(watcher.Update{ProductID: product.ID, Quantity: product.Quantity, Version: product.Version, At: product.UpdatedAt})); err != nil {
+			return err
+		}
+	}
+
+	for {
+		select {
+		case <-stream.Context().Done():
+			return nil
+		case _, ok := <-subscription.Ready():
+			if !ok {
+				return nil
+			}
+
+			for _, update := range subscription.Drain() {
+				if err := stream.Send(toInventoryUpdate(update)); err != nil {
+					return err
+				}
+			}
+		}
+	}
+}
+
+func toInventoryUpdate(update watcher.Update) *proto.InventoryUpdate {
+	return &proto.InventoryUpdate{
+		ProductId: int32(update.ProductID),
+		Quantity:  update.Quantity,
+		Version:   update.Version,
+		ChangedAt: timestamppb.New(update.At),
+	}
+}
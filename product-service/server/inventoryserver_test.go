@@ -0,0 +1,44 @@
+package server
+
+import (
+	"context"
+	proto "product-service/proto/product"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_Server_WatchInventoryShouldStreamQuantityChanges(t *testing.T) {
+	db := setupDatabase(t)
+	defer teardownDatabase(db)
+
+	server.CreateProduct(context.Background(), &proto.CreateProductRequest{Name: "Lamp", Price: &proto.Money{MinorUnits: 2999}, Quantity: 5})
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	client := startClient(t)
+	stream, err := client.WatchInventory(ctx, &proto.WatchInventoryRequest{ProductIds: []int32{1}})
+	assert.NoError(t, err)
+
+	initial, err1 := stream.Recv()
+	server.AddProducts(context.Background(), &proto.UpdateProductQuantityRequest{Id: 1, Quantity: 3})
+	update, err2 := stream.Recv()
+
+	assert.NoError(t, err1)
+	assert.NoError(t, err2)
+	assert.Equal(t, int32(5), initial.Quantity)
+	assert.Equal(t, int32(8), update.Quantity)
+	assert.Equal(t, initial.Version+1, update.Version)
+}
+
+func Test_Server_WatchInventoryShouldFailForUnknownProducts(t *testing.T) {
+	db := setupDatabase(t)
+	defer teardownDatabase(db)
+
+	client := startClient(t)
+	stream, _ := client.WatchInventory(context.Background(), &proto.WatchInventoryRequest{ProductIds: []int32{1001}})
+	_, err := stream.Recv()
+
+	assert.Error(t, err)
+}
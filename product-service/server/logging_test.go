@@ -0,0 +1,59 @@
+package server
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"log/slog"
+	"product-service/logging"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func captureLogs(t *testing.T) *bytes.Buffer {
+	var output bytes.Buffer
+	logger, err := logging.NewLogger(&output, "info", "json")
+	assert.NoError(t, err)
+
+	previous := slog.Default()
+	slog.SetDefault(logger)
+	t.Cleanup(func() { slog.SetDefault(previous) })
+
+	return &output
+}
+
+func Test_Server_LoggingInterceptorShouldLogMethodCodeAndRequestID(t *testing.T) {
+	output := captureLogs(t)
+	ctx := logging.WithRequestID(context.Background(), "req-44")
+	info := &grpc.UnaryServerInfo{FullMethod: "/product_service.ProductService/GetProduct"}
+
+	LoggingInterceptor(ctx, nil, info, func(ctx context.Context, _ interface{}) (interface{}, error) {
+		return nil, status.Error(codes.NotFound, "product with id 7 does not exist")
+	})
+
+	var record map[string]interface{}
+	assert.NoError(t, json.Unmarshal(output.Bytes(), &record))
+	assert.Equal(t, "WARN", record["level"])
+	assert.Equal(t, info.FullMethod, record["method"])
+	assert.Equal(t, "NotFound", record["code"])
+	assert.Equal(t, "product with id 7 does not exist", record["error"])
+	assert.Equal(t, "req-44", record["request_id"])
+	assert.Contains(t, record, "duration")
+}
+
+func Test_Server_LoggingInterceptorShouldLogServerFailuresAsErrors(t *testing.T) {
+	output := captureLogs(t)
+
+	LoggingInterceptor(context.Background(), nil, &grpc.UnaryServerInfo{}, func(ctx context.Context, _ interface{}) (interface{}, error) {
+		return nil, status.Error(codes.Internal, "disk full")
+	})
+
+	var record map[string]interface{}
+	assert.NoError(t, json.Unmarshal(output.Bytes(), &record))
+	assert.Equal(t, "ERROR", record["level"])
+	assert.Equal(t, "Internal", record["code"])
+}
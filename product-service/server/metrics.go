@@ -0,0 +1,28 @@
+package server
+
+import (
+	"context"
+	"product-service/metrics"
+	"time"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/status"
+)
+
+func MetricsInterceptor(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+	start := time.Now()
+
+	response, err := handler(ctx, req)
+	metrics.ObserveRPC(info.FullMethod, status.Code(err), time.Since(start))
+
+	return response, err
+}
+
+func StreamMetricsInterceptor(srv interface{}, stream grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+	start := time.Now()
+
+	err := handler(srv, stream)
+	metrics.ObserveRPC(info.FullMethod, status.Code(err), time.Since(start))
+
+	return err
+}
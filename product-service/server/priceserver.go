@@ -0,0 +1,63 @@
+package server
+
+import (
+	"context"
+	"product-service/models"
+	proto "product-service/proto/product"
+	"time"
+
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+func (s *GRPCServer) SchedulePriceChange(ctx context.Context, req *proto.SchedulePriceChangeRequest) (*proto.PriceChange, error) {
+	change, err := s.products.SchedulePriceChange(req.ProductId, req.Price.GetMinorUnits(), req.Price.GetCurrencyCode(), req.EffectiveAt.AsTime())
+
+	if err != nil {
+		return nil, err
+	}
+
+	return toPriceChangeResponse(change), nil
+}
+
+func (s *GRPCServer) GetPriceHistory(ctx context.Context, req *proto.GetPriceHistoryRequest) (*proto.GetPriceHistoryResponse, error) {
+	var at time.Time
+
+	if req.At != nil {
+		at = req.At.AsTime()
+	} else {
+		at = time.Now()
+	}
+
+	changes, effective, err := s.products.GetPriceHistory(req.ProductId, at)
+
+	if err != nil {
+		return nil, err
+	}
+
+	changesResponse := make([]*proto.PriceChange, len(changes))
+
+	for idx := range changes {
+		changesResponse[idx] = toPriceChangeResponse(&changes[idx])
+	}
+
+	return &proto.GetPriceHistoryResponse{
+		Changes:        changesResponse,
+		EffectivePrice: &proto.Money{CurrencyCode: effective.Currency, MinorUnits: effective.PriceMinor},
+		At:             timestamppb.New(at),
+	}, nil
+}
+
+func toPriceChangeResponse(change *models.PriceChange) *proto.PriceChange {
+	response := &proto.PriceChange{
+		Id:          int32(change.ID),
+		ProductId:   int32(change.ProductID),
+		Price:       &proto.Money{CurrencyCode: change.Currency, MinorUnits: change.PriceMinor},
+		EffectiveAt: timestamppb.New(change.EffectiveAt),
+	}
+
+	if change.AppliedAt != nil {
+		response.AppliedAt = timestamppb.New(*change.AppliedAt)
+	}
+
+	return response
+}
@@ -0,0 +1,37 @@
+package server
+
+import (
+	"context"
+	proto "product-service/proto/product"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+func Test_Server_ShouldScheduleAndListPriceChanges(t *testing.T) {
+	db := setupDatabase(t)
+	defer teardownDatabase(db)
+
+	server.CreateProduct(context.Background(), &proto.CreateProductRequest{Name: "Sneakers", Price: &proto.Money{MinorUnits: 4999}, Quantity: 10})
+
+	effectiveAt := time.Now().Add(time.Hour)
+
+	change, err1 := server.SchedulePriceChange(context.Background(), &proto.SchedulePriceChangeRequest{
+		ProductId:   1,
+		Price:       &proto.Money{MinorUnits: 3999},
+		EffectiveAt: timestamppb.New(effectiveAt),
+	})
+	now, err2 := server.GetPriceHistory(context.Background(), &proto.GetPriceHistoryRequest{ProductId: 1})
+	later, err3 := server.GetPriceHistory(context.Background(), &proto.GetPriceHistoryRequest{ProductId: 1, At: timestamppb.New(effectiveAt.Add(time.Minute))})
+
+	assert.NoError(t, err1)
+	assert.NoError(t, err2)
+	assert.NoError(t, err3)
+	assert.Nil(t, change.AppliedAt)
+	assert.Equal(t, "USD", change.Price.CurrencyCode)
+	assert.Equal(t, 2, len(now.Changes))
+	assert.Equal(t, int64(4999), now.EffectivePrice.MinorUnits)
+	assert.Equal(t, int64(3999), later.EffectivePrice.MinorUnits)
+}
@@ -0,0 +1,147 @@
+package server
+
+import (
+	"context"
+	"product-service/models"
+	proto "product-service/proto/product"
+	"product-service/services"
+
+	"google.golang.org/protobuf/types/known/emptypb"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+func (s *GRPCServer) CreatePromotion(ctx context.Context, req *proto.CreatePromotionRequest) (*proto.Promotion, error) {
+	newPromotion := models.Promotion{
+		Name:           req.Name,
+		Type:           models.PromotionType(req.Type),
+		PercentOff:     req.PercentOff,
+		AmountOffMinor: req.AmountOff.GetMinorUnits(),
+		Currency:       req.AmountOff.GetCurrencyCode(),
+		BuyQuantity:    req.BuyQuantity,
+		GetQuantity:    req.GetQuantity,
+	}
+
+	if req.ProductId != 0 {
+		productID := int64(req.ProductId)
+		newPromotion.ProductID = &productID
+	}
+
+	if len(req.CouponCode) != 0 {
+		newPromotion.CouponCode = &req.CouponCode
+	}
+
+	if req.StartsAt != nil {
+		startsAt := req.StartsAt.AsTime()
+		newPromotion.StartsAt = &startsAt
+	}
+
+	if req.EndsAt != nil {
+		endsAt := req.EndsAt.AsTime()
+		newPromotion.EndsAt = &endsAt
+	}
+
+	promotion, err := s.promotions.CreatePromotion(newPromotion)
+
+	if err != nil {
+		return nil, err
+	}
+
+	return toPromotionResponse(promotion), nil
+}
+
+func (s *GRPCServer) GetAllPromotions(ctx context.Context, _ *emptypb.Empty) (*proto.GetAllPromotionsResponse, error) {
+	promotions, err := s.promotions.GetAllPromotions()
+
+	if err != nil {
+		return nil, err
+	}
+
+	promotionsResponse := make([]*proto.Promotion, len(promotions))
+
+	for idx := range promotions {
+		promotionsResponse[idx] = toPromotionResponse(&promotions[idx])
+	}
+
+	return &proto.GetAllPromotionsResponse{Promotions: promotionsResponse}, nil
+}
+
+func (s *GRPCServer) DeletePromotion(ctx context.Context, req *proto.PromotionIdRequest) (*proto.PromotionIdRequest, error) {
+	if err := s.promotions.DeletePromotion(req.Id); err != nil {
+		return nil, err
+	}
+
+	return &proto.PromotionIdRequest{Id: req.Id}, nil
+}
+
+func (s *GRPCServer) PriceCart(ctx context.Context, req *proto.PriceCartRequest) (*proto.PriceCartResponse, error) {
+	var items []services.CartItem
+
+	for _, product := range req.Cart.GetProducts() {
+		items = append(items, services.CartItem{ProductID: product.Id, Quantity: product.Quantity})
+	}
+
+	cart, err := s.promotions.PriceCart(items, req.CouponCodes)
+
+	if err != nil {
+		return nil, err
+	}
+
+	lines := make([]*proto.PricedCartLine, len(cart.Lines))
+
+	for idx, line := range cart.Lines {
+		lines[idx] = &proto.PricedCartLine{
+			ProductId: line.ProductID,
+			Name:      line.Name,
+			Quantity:  line.Quantity,
+			UnitPrice: &proto.Money{CurrencyCode: cart.Currency, MinorUnits: line.UnitPriceMinor},
+			Subtotal:  &proto.Money{CurrencyCode: cart.Currency, MinorUnits: line.SubtotalMinor},
+			Discount:  &proto.Money{CurrencyCode: cart.Currency, MinorUnits: line.DiscountMinor},
+			Total:     &proto.Money{CurrencyCode: cart.Currency, MinorUnits: line.TotalMinor},
+		}
+
+		if line.Promotion != nil {
+			lines[idx].PromotionId = int32(line.Promotion.ID)
+			lines[idx].PromotionName = line.Promotion.Name
+		}
+	}
+
+	return &proto.PriceCartResponse{
+		Lines:    lines,
+		Subtotal: &proto.Money{CurrencyCode: cart.Currency, MinorUnits: cart.SubtotalMinor},
+		Discount: &proto.Money{CurrencyCode: cart.Currency, MinorUnits: cart.DiscountMinor},
+		Total:    &proto.Money{CurrencyCode: cart.Currency, MinorUnits: cart.TotalMinor},
+	}, nil
+}
+
+func toPromotionResponse(promotion *models.Promotion) *proto.Promotion {
+	response := &proto.Promotion{
+		Id:          int32(promotion.ID),
+		Name:        promotion.Name,
+		Type:        string(promotion.Type),
+		PercentOff:  promotion.PercentOff,
+		BuyQuantity: promotion.BuyQuantity,
+		GetQuantity: promotion.GetQuantity,
+	}
+
+	if promotion.Type == models.PromotionFixedAmount {
+		response.AmountOff = &proto.Money{CurrencyCode: promotion.Currency, MinorUnits: promotion.AmountOffMinor}
+	}
+
+	if promotion.ProductID != nil {
+		response.ProductId = int32(*promotion.ProductID)
+	}
+
+	if promotion.CouponCode != nil {
+		response.CouponCode = *promotion.CouponCode
+	}
+
+	if promotion.StartsAt != nil {
+		response.StartsAt = timestamppb.New(*promotion.StartsAt)
+	}
+
+	if promotion.EndsAt != nil {
+		response.EndsAt = timestamppb.New(*promotion.EndsAt)
+	}
+
+	return response
+}
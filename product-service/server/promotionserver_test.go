@@ -0,0 +1,37 @@
+package server
+
+import (
+	"context"
+	proto "product-service/proto/product"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_Server_ShouldPriceCartWithPromotions(t *testing.T) {
+	db := setupDatabase(t)
+	defer teardownDatabase(db)
+
+	server.CreateProduct(context.Background(), &proto.CreateProductRequest{Name: "Sneakers", Price: &proto.Money{MinorUnits: 5000}, Quantity: 10})
+
+	promotion, err1 := server.CreatePromotion(context.Background(), &proto.CreatePromotionRequest{
+		Name:       "Spring",
+		Type:       "fixed_amount",
+		ProductId:  1,
+		AmountOff:  &proto.Money{MinorUnits: 750},
+		CouponCode: "spring",
+	})
+	priced, err2 := server.PriceCart(context.Background(), &proto.PriceCartRequest{
+		Cart:        &proto.Cart{Products: []*proto.CartProduct{{Id: 1, Quantity: 2, Price: 1}}},
+		CouponCodes: []string{"SPRING"},
+	})
+
+	assert.NoError(t, err1)
+	assert.NoError(t, err2)
+	assert.Equal(t, "SPRING", promotion.CouponCode)
+	assert.Equal(t, "USD", promotion.AmountOff.CurrencyCode)
+	assert.Equal(t, int64(5000), priced.Lines[0].UnitPrice.MinorUnits)
+	assert.Equal(t, promotion.Id, priced.Lines[0].PromotionId)
+	assert.Equal(t, int64(1500), priced.Discount.MinorUnits)
+	assert.Equal(t, int64(8500), priced.Total.MinorUnits)
+}
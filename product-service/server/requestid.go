@@ -0,0 +1,44 @@
+package server
+
+import (
+	"context"
+	"product-service/logging"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/metadata"
+)
+
+type requestIDStream struct {
+	grpc.ServerStream
+	ctx context.Context
+}
+
+func (s *requestIDStream) Context() context.Context {
+	return s.ctx
+}
+
+func RequestIDInterceptor(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+	return handler(withRequestID(ctx), req)
+}
+
+func StreamRequestIDInterceptor(srv interface{}, stream grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+	return handler(srv, &requestIDStream{ServerStream: stream, ctx: withRequestID(stream.Context())})
+}
+
+func withRequestID(ctx context.Context) context.Context {
+	id := ""
+
+	if md, ok := metadata.FromIncomingContext(ctx); ok {
+		if values := md.Get(logging.RequestIDHeader); len(values) != 0 {
+			id = values[0]
+		}
+	}
+
+	if len(id) == 0 || len(id) > 128 {
+		id = logging.NewRequestID()
+	}
+
+	grpc.SetHeader(ctx, metadata.Pairs(logging.RequestIDHeader, id))
+
+	return logging.WithRequestID(ctx, id)
+}
@@ -0,0 +1,57 @@
+package server
+
+import (
+	"context"
+	"product-service/logging"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/metadata"
+)
+
+type contextStream struct {
+	grpc.ServerStream
+	ctx context.Context
+}
+
+func (s *contextStream) Context() context.Context {
+	return s.ctx
+}
+
+func Test_Server_RequestIDInterceptorShouldPropagateTheIncomingID(t *testing.T) {
+	ctx := metadata.NewIncomingContext(context.Background(), metadata.Pairs(logging.RequestIDHeader, "req-42"))
+	var seen string
+
+	_, err := RequestIDInterceptor(ctx, nil, &grpc.UnaryServerInfo{}, func(ctx context.Context, _ interface{}) (interface{}, error) {
+		seen = logging.RequestID(ctx)
+		return nil, nil
+	})
+
+	assert.NoError(t, err)
+	assert.Equal(t, "req-42", seen)
+}
+
+func Test_Server_RequestIDInterceptorShouldGenerateAMissingID(t *testing.T) {
+	var seen string
+
+	RequestIDInterceptor(context.Background(), nil, &grpc.UnaryServerInfo{}, func(ctx context.Context, _ interface{}) (interface{}, error) {
+		seen = logging.RequestID(ctx)
+		return nil, nil
+	})
+
+	assert.Len(t, seen, 36)
+}
+
+func Test_Server_StreamRequestIDInterceptorShouldExposeTheIDOnTheStream(t *testing.T) {
+	ctx := metadata.NewIncomingContext(context.Background(), metadata.Pairs(logging.RequestIDHeader, "req-43"))
+	var seen string
+
+	err := StreamRequestIDInterceptor(nil, &contextStream{ctx: ctx}, &grpc.StreamServerInfo{}, func(_ interface{}, stream grpc.ServerStream) error {
+		seen = logging.RequestID(stream.Context())
+		return nil
+	})
+
+	assert.NoError(t, err)
+	assert.Equal(t, "req-43", seen)
+}
@@ -2,33 +2,55 @@ package server
 
 import (
 	"context"
+	"errors"
+	"product-service/models"
 	proto "product-service/proto/product"
 	"product-service/services"
 
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
 	"google.golang.org/protobuf/types/known/emptypb"
+	"google.golang.org/protobuf/types/known/timestamppb"
 )
 
 type GRPCServer struct {
 	proto.UnimplementedProductServiceServer
+	products   *services.ProductService
+	skus       *services.SkuService
+	promotions *services.PromotionService
+	categories *services.CategoryService
+	warehouses *services.WarehouseService
+}
+
+func NewGRPCServer(products *services.ProductService, skus *services.SkuService, promotions *services.PromotionService, categories *services.CategoryService, warehouses *services.WarehouseService) *GRPCServer {
+	return &GRPCServer{
+		products:   products,
+		skus:       skus,
+		promotions: promotions,
+		categories: categories,
+		warehouses: warehouses,
+	}
 }
 
 func (s *GRPCServer) CreateProduct(ctx context.Context, req *proto.CreateProductRequest) (*proto.CreateProductResponse, error) {
-	product, err := services.CreateProduct(req.Name, req.Description, req.Price, req.Quantity)
+	product, err := s.products.CreateProduct(req.Name, req.Description, req.Price.GetMinorUnits(), req.Price.GetCurrencyCode(), req.Quantity, req.ReorderThreshold)
 
 	if err != nil {
 		return nil, err
 	}
 
-	return &proto.CreateProductResponse{
-		Id:          int32(product.ID),
-		Name:        product.Name,
-		Description: product.Description,
-		Price:       product.Price,
-		Quantity:    product.Quantity}, nil
+	return toProductResponse(product), nil
 }
 
-func (s *GRPCServer) GetAllProducts(ctx context.Context, _ *emptypb.Empty) (*proto.GetAllProductsResponse, error) {
-	products, err := services.GetAllProducts()
+func (s *GRPCServer) GetAllProducts(ctx context.Context, req *proto.GetAllProductsRequest) (*proto.GetAllProductsResponse, error) {
+	var products []models.Product
+	var err error
+
+	if req.CategoryId != 0 {
+		products, err = s.categories.GetProductsByCategory(req.CategoryId)
+	} else {
+		products, err = s.products.GetAllProducts()
+	}
 
 	if err != nil {
 		return nil, err
@@ -36,98 +58,172 @@ func (s *GRPCServer) GetAllProducts(ctx context.Context, _ *emptypb.Empty) (*pro
 
 	productsResponse := make([]*proto.CreateProductResponse, len(products))
 
-	for idx, product := range products {
-		productsResponse[idx] = &proto.CreateProductResponse{
-			Id:          int32(product.ID),
-			Name:        product.Name,
-			Description: product.Description,
-			Price:       product.Price,
-			Quantity:    product.Quantity}
+	for idx := range products {
+		productsResponse[idx] = toProductResponse(&products[idx])
 	}
 
 	return &proto.GetAllProductsResponse{Products: productsResponse}, nil
 }
 
 func (s *GRPCServer) GetProduct(ctx context.Context, req *proto.ProductIdRequest) (*proto.CreateProductResponse, error) {
-	product, err := services.GetProduct(req.Id)
+	product, err := s.products.GetProduct(req.Id)
 
 	if err != nil {
 		return nil, err
 	}
 
-	return &proto.CreateProductResponse{
-		Id:          int32(product.ID),
-		Name:        product.Name,
-		Description: product.Description,
-		Price:       product.Price,
-		Quantity:    product.Quantity}, nil
+	return toProductResponse(product), nil
+}
+
+func (s *GRPCServer) ListLowStockProducts(ctx context.Context, _ *emptypb.Empty) (*proto.GetAllProductsResponse, error) {
+	products, err := s.products.GetLowStockProducts()
+
+	if err != nil {
+		return nil, err
+	}
+
+	productsResponse := make([]*proto.CreateProductResponse, len(products))
+
+	for idx := range products {
+		productsResponse[idx] = toProductResponse(&products[idx])
+	}
+
+	return &proto.GetAllProductsResponse{Products: productsResponse}, nil
 }
 
 func (s *GRPCServer) DeleteProduct(ctx context.Context, req *proto.ProductIdRequest) (*proto.ProductIdRequest, error) {
-	if err := services.DeleteProduct(req.Id); err != nil {
+	if err := s.products.DeleteProduct(req.Id, req.ExpectedVersion); err != nil {
+		return nil, versionError(err)
+	}
+
+	return &proto.ProductIdRequest{Id: req.Id}, nil
+}
+
+func (s *GRPCServer) ListDeletedProducts(ctx context.Context, _ *emptypb.Empty) (*proto.GetAllProductsResponse, error) {
+	products, err := s.products.GetDeletedProducts()
+
+	if err != nil {
+		return nil, err
+	}
+
+	productsResponse := make([]*proto.CreateProductResponse, len(products))
+
+	for idx := range products {
+		productsResponse[idx] = toProductResponse(&products[idx])
+	}
+
+	return &proto.GetAllProductsResponse{Products: productsResponse}, nil
+}
+
+func (s *GRPCServer) RestoreProduct(ctx context.Context, req *proto.ProductIdRequest) (*proto.CreateProductResponse, error) {
+	product, err := s.products.RestoreProduct(req.Id)
+
+	if err != nil {
+		return nil, err
+	}
+
+	return toProductResponse(product), nil
+}
+
+func (s *GRPCServer) PurgeProduct(ctx context.Context, req *proto.ProductIdRequest) (*proto.ProductIdRequest, error) {
+	if err := s.products.PurgeProduct(req.Id); err != nil {
 		return nil, err
 	}
 
 	return &proto.ProductIdRequest{Id: req.Id}, nil
 }
 
+func (s *GRPCServer) UpdateProductDetails(ctx context.Context, req *proto.UpdateProductDetailsRequest) (*proto.CreateProductResponse, error) {
+	product, err := s.products.UpdateProductDetails(req.Id, req.Name, req.Description, req.Price.GetMinorUnits(), req.Price.GetCurrencyCode(), req.ReorderThreshold, req.UpdateMask.GetPaths(), req.ExpectedVersion)
+
+	if err != nil {
+		return nil, versionError(err)
+	}
+
+	return toProductResponse(product), nil
+}
+
 func (s *GRPCServer) AddProducts(ctx context.Context, req *proto.UpdateProductQuantityRequest) (*proto.CreateProductResponse, error) {
-	product, err := services.AddProducts(req.Id, req.Quantity)
+	product, err := s.products.AddProducts(req.Id, req.SkuId, req.WarehouseId, req.Quantity, req.ExpectedVersion)
 
 	if err != nil {
-		return nil, err
+		return nil, versionError(err)
 	}
 
-	return &proto.CreateProductResponse{
-		Id:          int32(product.ID),
-		Name:        product.Name,
-		Description: product.Description,
-		Price:       product.Price,
-		Quantity:    product.Quantity}, nil
+	return toProductResponse(product), nil
 }
 
 func (s *GRPCServer) RemoveProducts(ctx context.Context, req *proto.UpdateProductQuantityRequest) (*proto.CreateProductResponse, error) {
-	product, err := services.RemoveProducts(req.Id, req.Quantity)
+	product, err := s.products.RemoveProducts(req.Id, req.SkuId, req.WarehouseId, req.Quantity, req.ExpectedVersion)
 
 	if err != nil {
-		return nil, err
+		return nil, versionError(err)
 	}
 
-	return &proto.CreateProductResponse{
-		Id:          int32(product.ID),
-		Name:        product.Name,
-		Description: product.Description,
-		Price:       product.Price,
-		Quantity:    product.Quantity}, nil
+	return toProductResponse(product), nil
 }
 
 func (s *GRPCServer) UpdateProducts(ctx context.Context, req *proto.UpdateProductRequest) (*proto.UpdateProductResponse, error) {
 	var ids []int64
+	var skuIds []int64
 	var quantities []int32
 
 	for _, product := range req.Products {
 		ids = append(ids, product.Id)
+		skuIds = append(skuIds, product.SkuId)
 		quantities = append(quantities, product.Quantity)
 	}
 
-	response := &proto.UpdateProductResponse{}
+	var destination *models.Location
+
+	if req.Destination != nil {
+		destination = &models.Location{Latitude: req.Destination.Latitude, Longitude: req.Destination.Longitude}
+	}
+
+	if err := s.products.UpdateProducts(ids, skuIds, quantities, req.AllocationStrategy, destination); err != nil {
+		return nil, err
+	}
 
-	if err := services.UpdateProducts(ids, quantities); err != nil {
-		errorResponse := &proto.ErrorResponse{
-			Status:  400,
-			Message: err.Error(),
-		}
+	return &proto.UpdateProductResponse{
+		Response: &proto.UpdateProductResponse_SuccessResponse{SuccessResponse: &emptypb.Empty{}},
+	}, nil
+}
 
-		response.Response = &proto.UpdateProductResponse_ErrorResponse{
-			ErrorResponse: errorResponse,
-		}
+func toProductResponse(product *models.Product) *proto.CreateProductResponse {
+	skus := make([]*proto.Sku, len(product.Skus))
 
-		return response, nil
+	for idx := range product.Skus {
+		skus[idx] = toSkuResponse(&product.Skus[idx], product.Currency)
 	}
 
-	response.Response = &proto.UpdateProductResponse_SuccessResponse{
-		SuccessResponse: &emptypb.Empty{},
+	stock := make([]*proto.WarehouseStock, len(product.StockLevels))
+
+	for idx, level := range product.StockLevels {
+		stock[idx] = &proto.WarehouseStock{WarehouseId: int32(level.WarehouseID), SkuId: int32(level.SkuID), Quantity: level.Quantity}
+	}
+
+	response := &proto.CreateProductResponse{
+		Id:               int32(product.ID),
+		Name:             product.Name,
+		Description:      product.Description,
+		Price:            &proto.Money{CurrencyCode: product.Currency, MinorUnits: product.PriceMinor},
+		Quantity:         product.Quantity,
+		Version:          product.Version,
+		Skus:             skus,
+		Stock:            stock,
+		ReorderThreshold: product.ReorderThreshold}
+
+	if product.DeletedAt.Valid {
+		response.DeletedAt = timestamppb.New(product.DeletedAt.Time)
+	}
+
+	return response
+}
+
+func versionError(err error) error {
+	if errors.Is(err, models.ErrVersionMismatch) {
+		return status.Error(codes.Aborted, err.Error())
 	}
 
-	return response, nil
+	return err
 }
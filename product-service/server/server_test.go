@@ -3,23 +3,44 @@ package server
 import (
 	"context"
 	"product-service/models"
+	"product-service/notifier"
 	proto "product-service/proto/product"
+	"product-service/services"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
 	"google.golang.org/protobuf/types/known/emptypb"
+	"google.golang.org/protobuf/types/known/fieldmaskpb"
 	"gorm.io/driver/sqlite"
 	"gorm.io/gorm"
 )
 
-var server GRPCServer
+var server *GRPCServer
 
 func setupDatabase(t *testing.T) *gorm.DB {
 	db, err := gorm.Open(sqlite.Open("file:yourDbName?mode=memory&cache=shared"), &gorm.Config{})
 	assert.NoError(t, err)
 
-	server = GRPCServer{}
-	models.InitProductModel(db)
+	assert.NoError(t, models.InitProductModel(db))
+	assert.NoError(t, models.InitCategoryModel(db))
+	assert.NoError(t, models.InitSkuModel(db))
+	assert.NoError(t, models.InitWarehouseModel(db))
+	assert.NoError(t, models.InitPriceHistoryModel(db))
+	assert.NoError(t, models.InitPromotionModel(db))
+	assert.NoError(t, models.InitIdempotencyModel(db))
+	assert.NoError(t, models.InitOutboxModel(db))
+
+	products := models.NewGormProductRepository(db, models.AllocationPriority)
+	inventory := services.NewInventory(notifier.LogNotifier{})
+	server = NewGRPCServer(
+		services.NewProductService(products, models.NewGormPriceRepository(db), inventory),
+		services.NewSkuService(models.NewGormSkuRepository(db), products, inventory),
+		services.NewPromotionService(models.NewGormPromotionRepository(db), products),
+		services.NewCategoryService(models.NewGormCategoryRepository(db), products),
+		services.NewWarehouseService(models.NewGormWarehouseRepository(db), inventory),
+	)
 
 	return db
 }
@@ -37,7 +58,7 @@ func Test_Server_ShouldCreateProductWorkCorrectly(t *testing.T) {
 	product := &proto.CreateProductRequest{
 		Name:        "Test Product",
 		Description: "This is a test product",
-		Price:       9.99,
+		Price:       &proto.Money{CurrencyCode: "USD", MinorUnits: 999},
 		Quantity:    10,
 	}
 
@@ -47,7 +68,8 @@ func Test_Server_ShouldCreateProductWorkCorrectly(t *testing.T) {
 	assert.NotNil(t, createdProduct)
 	assert.Equal(t, product.Name, createdProduct.Name)
 	assert.Equal(t, product.Description, createdProduct.Description)
-	assert.Equal(t, product.Price, createdProduct.Price)
+	assert.Equal(t, product.Price.MinorUnits, createdProduct.Price.MinorUnits)
+	assert.Equal(t, product.Price.CurrencyCode, createdProduct.Price.CurrencyCode)
 	assert.Equal(t, product.Quantity, createdProduct.Quantity)
 }
 
@@ -58,7 +80,7 @@ func Test_Server_ShouldCreateProductThrowAnErrorIfFailedToCreateANewProduct(t *t
 	product := &proto.CreateProductRequest{
 		Name:        "Test Product",
 		Description: "This is a test product",
-		Price:       9.99,
+		Price:       &proto.Money{CurrencyCode: "USD", MinorUnits: 999},
 		Quantity:    10,
 	}
 
@@ -69,11 +91,12 @@ func Test_Server_ShouldCreateProductThrowAnErrorIfFailedToCreateANewProduct(t *t
 	assert.NotNil(t, createdProduct1)
 	assert.Equal(t, product.Name, createdProduct1.Name)
 	assert.Equal(t, product.Description, createdProduct1.Description)
-	assert.Equal(t, product.Price, createdProduct1.Price)
+	assert.Equal(t, product.Price.MinorUnits, createdProduct1.Price.MinorUnits)
+	assert.Equal(t, product.Price.CurrencyCode, createdProduct1.Price.CurrencyCode)
 	assert.Equal(t, product.Quantity, createdProduct1.Quantity)
 	assert.Error(t, err2)
 	assert.Nil(t, createdProduct2)
-	assert.Equal(t, "error in creating a new product", err2.Error())
+	assert.Equal(t, "error in creating a new product: UNIQUE constraint failed: products.name", err2.Error())
 }
 
 func Test_Server_GetAllProductsShouldWorkCorrectly(t *testing.T) {
@@ -83,21 +106,21 @@ func Test_Server_GetAllProductsShouldWorkCorrectly(t *testing.T) {
 	product1 := &proto.CreateProductRequest{
 		Name:        "Test Product",
 		Description: "This is a test product",
-		Price:       9.99,
+		Price:       &proto.Money{CurrencyCode: "USD", MinorUnits: 999},
 		Quantity:    10,
 	}
 
 	product2 := &proto.CreateProductRequest{
 		Name:        "Test Product 2",
 		Description: "This is a test product 2",
-		Price:       8.75,
+		Price:       &proto.Money{CurrencyCode: "USD", MinorUnits: 875},
 		Quantity:    4,
 	}
 
 	server.CreateProduct(context.Background(), product1)
 	server.CreateProduct(context.Background(), product2)
 
-	products, err := server.GetAllProducts(context.Background(), &emptypb.Empty{})
+	products, err := server.GetAllProducts(context.Background(), &proto.GetAllProductsRequest{})
 
 	assert.NoError(t, err)
 	assert.NotEmpty(t, products.Products)
@@ -105,11 +128,13 @@ func Test_Server_GetAllProductsShouldWorkCorrectly(t *testing.T) {
 	assert.NotNil(t, products.Products[0])
 	assert.Equal(t, product1.Name, products.Products[0].Name)
 	assert.Equal(t, product1.Description, products.Products[0].Description)
-	assert.Equal(t, product1.Price, products.Products[0].Price)
+	assert.Equal(t, product1.Price.MinorUnits, products.Products[0].Price.MinorUnits)
+	assert.Equal(t, product1.Price.CurrencyCode, products.Products[0].Price.CurrencyCode)
 	assert.Equal(t, product1.Quantity, products.Products[0].Quantity)
 	assert.Equal(t, product2.Name, products.Products[1].Name)
 	assert.Equal(t, product2.Description, products.Products[1].Description)
-	assert.Equal(t, product2.Price, products.Products[1].Price)
+	assert.Equal(t, product2.Price.MinorUnits, products.Products[1].Price.MinorUnits)
+	assert.Equal(t, product2.Price.CurrencyCode, products.Products[1].Price.CurrencyCode)
 	assert.Equal(t, product2.Quantity, products.Products[1].Quantity)
 }
 
@@ -120,7 +145,7 @@ func Test_Server_GetProductShouldWorkCorrectly(t *testing.T) {
 	newProduct := &proto.CreateProductRequest{
 		Name:        "Test Product",
 		Description: "This is a test product",
-		Price:       9.99,
+		Price:       &proto.Money{CurrencyCode: "USD", MinorUnits: 999},
 		Quantity:    10,
 	}
 
@@ -135,7 +160,8 @@ func Test_Server_GetProductShouldWorkCorrectly(t *testing.T) {
 	assert.Equal(t, createdProduct.Id, product.Id)
 	assert.Equal(t, createdProduct.Name, product.Name)
 	assert.Equal(t, createdProduct.Description, product.Description)
-	assert.Equal(t, createdProduct.Price, product.Price)
+	assert.Equal(t, createdProduct.Price.MinorUnits, product.Price.MinorUnits)
+	assert.Equal(t, createdProduct.Price.CurrencyCode, product.Price.CurrencyCode)
 	assert.Equal(t, createdProduct.Quantity, product.Quantity)
 }
 
@@ -157,7 +183,7 @@ func Test_Server_ShouldDeleteProductWorkCorrectly(t *testing.T) {
 	newProduct := &proto.CreateProductRequest{
 		Name:        "Test Product",
 		Description: "This is a test product",
-		Price:       9.99,
+		Price:       &proto.Money{CurrencyCode: "USD", MinorUnits: 999},
 		Quantity:    10,
 	}
 
@@ -194,7 +220,7 @@ func Test_Server_AddProductsShouldWorkCorrectly(t *testing.T) {
 	newProduct := &proto.CreateProductRequest{
 		Name:        "Test Product",
 		Description: "This is a test product",
-		Price:       9.99,
+		Price:       &proto.Money{CurrencyCode: "USD", MinorUnits: 999},
 		Quantity:    10,
 	}
 
@@ -208,7 +234,8 @@ func Test_Server_AddProductsShouldWorkCorrectly(t *testing.T) {
 	assert.Equal(t, int32(1), product.Id)
 	assert.Equal(t, newProduct.Name, product.Name)
 	assert.Equal(t, newProduct.Description, product.Description)
-	assert.Equal(t, newProduct.Price, product.Price)
+	assert.Equal(t, newProduct.Price.MinorUnits, product.Price.MinorUnits)
+	assert.Equal(t, newProduct.Price.CurrencyCode, product.Price.CurrencyCode)
 }
 
 func Test_Server_AddProductsShouldThrowAnErrorIfQuantityIsLessThanZero(t *testing.T) {
@@ -240,7 +267,7 @@ func Test_Server_RemoveProductsShouldWorkCorrectly(t *testing.T) {
 	newProduct := &proto.CreateProductRequest{
 		Name:        "Test Product",
 		Description: "This is a test product",
-		Price:       9.99,
+		Price:       &proto.Money{CurrencyCode: "USD", MinorUnits: 999},
 		Quantity:    15,
 	}
 
@@ -254,7 +281,8 @@ func Test_Server_RemoveProductsShouldWorkCorrectly(t *testing.T) {
 	assert.Equal(t, int32(1), product.Id)
 	assert.Equal(t, newProduct.Name, product.Name)
 	assert.Equal(t, newProduct.Description, product.Description)
-	assert.Equal(t, newProduct.Price, product.Price)
+	assert.Equal(t, newProduct.Price.MinorUnits, product.Price.MinorUnits)
+	assert.Equal(t, newProduct.Price.CurrencyCode, product.Price.CurrencyCode)
 }
 
 func Test_Server_RemoveProductsShouldThrowErrorIfQuantityIsLessThanZero(t *testing.T) {
@@ -275,7 +303,7 @@ func Test_Server_RemoveProductsShouldThrowAnErrorIfInsufficentQuantityLeft(t *te
 	newProduct := &proto.CreateProductRequest{
 		Name:        "Test Product",
 		Description: "This is a test product",
-		Price:       9.99,
+		Price:       &proto.Money{CurrencyCode: "USD", MinorUnits: 999},
 		Quantity:    5,
 	}
 
@@ -306,14 +334,14 @@ func TestUpdateProductsShouldWorkCorrectly(t *testing.T) {
 	newProduct1 := &proto.CreateProductRequest{
 		Name:        "Test Product",
 		Description: "This is a test product",
-		Price:       9.99,
+		Price:       &proto.Money{CurrencyCode: "USD", MinorUnits: 999},
 		Quantity:    10,
 	}
 
 	newProduct2 := &proto.CreateProductRequest{
 		Name:        "Test Product 2",
 		Description: "This is a test product 2",
-		Price:       8.75,
+		Price:       &proto.Money{CurrencyCode: "USD", MinorUnits: 875},
 		Quantity:    4,
 	}
 
@@ -333,7 +361,7 @@ func TestUpdateProductsShouldWorkCorrectly(t *testing.T) {
 		},
 	})
 
-	products, err2 := server.GetAllProducts(context.Background(), &emptypb.Empty{})
+	products, err2 := server.GetAllProducts(context.Background(), &proto.GetAllProductsRequest{})
 
 	assert.NoError(t, err1)
 	assert.NoError(t, err2)
@@ -342,12 +370,14 @@ func TestUpdateProductsShouldWorkCorrectly(t *testing.T) {
 	assert.NotNil(t, products.Products[0])
 	assert.Equal(t, newProduct1.Name, products.Products[0].Name)
 	assert.Equal(t, newProduct1.Description, products.Products[0].Description)
-	assert.Equal(t, newProduct1.Price, products.Products[0].Price)
+	assert.Equal(t, newProduct1.Price.MinorUnits, products.Products[0].Price.MinorUnits)
+	assert.Equal(t, newProduct1.Price.CurrencyCode, products.Products[0].Price.CurrencyCode)
 	assert.Equal(t, int32(4), products.Products[0].Quantity)
 	assert.NotNil(t, products.Products[1])
 	assert.Equal(t, newProduct2.Name, products.Products[1].Name)
 	assert.Equal(t, newProduct2.Description, products.Products[1].Description)
-	assert.Equal(t, newProduct2.Price, products.Products[1].Price)
+	assert.Equal(t, newProduct2.Price.MinorUnits, products.Products[1].Price.MinorUnits)
+	assert.Equal(t, newProduct2.Price.CurrencyCode, products.Products[1].Price.CurrencyCode)
 	assert.Equal(t, int32(2), products.Products[1].Quantity)
 }
 
@@ -358,14 +388,14 @@ func TestUpdateProductsShouldThrowErrorIfSomeIdsAreInvalid(t *testing.T) {
 	newProduct1 := &proto.CreateProductRequest{
 		Name:        "Test Product",
 		Description: "This is a test product",
-		Price:       9.99,
+		Price:       &proto.Money{CurrencyCode: "USD", MinorUnits: 999},
 		Quantity:    10,
 	}
 
 	newProduct2 := &proto.CreateProductRequest{
 		Name:        "Test Product 2",
 		Description: "This is a test product 2",
-		Price:       8.75,
+		Price:       &proto.Money{CurrencyCode: "USD", MinorUnits: 875},
 		Quantity:    4,
 	}
 
@@ -385,11 +415,9 @@ func TestUpdateProductsShouldThrowErrorIfSomeIdsAreInvalid(t *testing.T) {
 		},
 	})
 
-	assert.NoError(t, err)
-	assert.NotNil(t, res.Response)
-	assert.Nil(t, res.GetSuccessResponse())
-	assert.NotNil(t, res.GetErrorResponse())
-	assert.Equal(t, "some ids are invalid", res.GetErrorResponse().Message)
+	assert.Nil(t, res)
+	assert.Equal(t, codes.InvalidArgument, status.Code(statusError(err)))
+	assert.Equal(t, "some ids are invalid", err.Error())
 }
 
 func TestUpdateProductsShouldBuyMoreItemsThanPrsentInTheInventory(t *testing.T) {
@@ -399,14 +427,14 @@ func TestUpdateProductsShouldBuyMoreItemsThanPrsentInTheInventory(t *testing.T)
 	newProduct1 := &proto.CreateProductRequest{
 		Name:        "Test Product",
 		Description: "This is a test product",
-		Price:       9.99,
+		Price:       &proto.Money{CurrencyCode: "USD", MinorUnits: 999},
 		Quantity:    10,
 	}
 
 	newProduct2 := &proto.CreateProductRequest{
 		Name:        "Test Product 2",
 		Description: "This is a test product 2",
-		Price:       8.75,
+		Price:       &proto.Money{CurrencyCode: "USD", MinorUnits: 875},
 		Quantity:    4,
 	}
 
@@ -426,9 +454,98 @@ func TestUpdateProductsShouldBuyMoreItemsThanPrsentInTheInventory(t *testing.T)
 		},
 	})
 
+	assert.Nil(t, res)
+	assert.Equal(t, codes.FailedPrecondition, status.Code(statusError(err)))
+	assert.Equal(t, "trying to order more items than there is in the inventory", err.Error())
+}
+
+func Test_Server_UpdateProductDetailsShouldWorkCorrectly(t *testing.T) {
+	db := setupDatabase(t)
+	defer teardownDatabase(db)
+
+	newProduct := &proto.CreateProductRequest{
+		Name:        "Test Product",
+		Description: "This is a test product",
+		Price:       &proto.Money{CurrencyCode: "USD", MinorUnits: 999},
+		Quantity:    10,
+	}
+
+	server.CreateProduct(context.Background(), newProduct)
+
+	product, err := server.UpdateProductDetails(context.Background(), &proto.UpdateProductDetailsRequest{
+		Id:          1,
+		Description: "Updated description",
+		UpdateMask:  &fieldmaskpb.FieldMask{Paths: []string{"description"}},
+	})
+
 	assert.NoError(t, err)
-	assert.NotNil(t, res.Response)
-	assert.Nil(t, res.GetSuccessResponse())
-	assert.NotNil(t, res.GetErrorResponse())
-	assert.Equal(t, "trying to order more items than there is in the inventory", res.GetErrorResponse().Message)
+	assert.NotNil(t, product)
+	assert.Equal(t, int32(1), product.Id)
+	assert.Equal(t, newProduct.Name, product.Name)
+	assert.Equal(t, "Updated description", product.Description)
+	assert.Equal(t, newProduct.Price.MinorUnits, product.Price.MinorUnits)
+	assert.Equal(t, newProduct.Price.CurrencyCode, product.Price.CurrencyCode)
+	assert.Equal(t, newProduct.Quantity, product.Quantity)
+}
+
+func Test_Server_UpdateProductDetailsShouldThrowAnErrorIfProductDoesNotExist(t *testing.T) {
+	db := setupDatabase(t)
+	defer teardownDatabase(db)
+
+	product, err := server.UpdateProductDetails(context.Background(), &proto.UpdateProductDetailsRequest{
+		Id:         1,
+		Price:      &proto.Money{MinorUnits: 1999},
+		UpdateMask: &fieldmaskpb.FieldMask{Paths: []string{"price"}},
+	})
+
+	assert.Error(t, err)
+	assert.Nil(t, product)
+	assert.Equal(t, gorm.ErrRecordNotFound, err)
+}
+
+func Test_Server_AddProductsShouldReturnAbortedIfVersionIsStale(t *testing.T) {
+	db := setupDatabase(t)
+	defer teardownDatabase(db)
+
+	server.CreateProduct(context.Background(), &proto.CreateProductRequest{Name: "Test Product", Price: &proto.Money{CurrencyCode: "USD", MinorUnits: 999}, Quantity: 10})
+
+	product1, err1 := server.AddProducts(context.Background(), &proto.UpdateProductQuantityRequest{Id: 1, Quantity: 5, ExpectedVersion: 1})
+	product2, err2 := server.AddProducts(context.Background(), &proto.UpdateProductQuantityRequest{Id: 1, Quantity: 5, ExpectedVersion: 1})
+
+	assert.NoError(t, err1)
+	assert.Equal(t, int64(2), product1.Version)
+	assert.Error(t, err2)
+	assert.Nil(t, product2)
+	assert.Equal(t, codes.Aborted, status.Code(err2))
+}
+
+func Test_Server_ListLowStockProductsShouldWorkCorrectly(t *testing.T) {
+	db := setupDatabase(t)
+	defer teardownDatabase(db)
+
+	server.CreateProduct(context.Background(), &proto.CreateProductRequest{Name: "Lamp", Price: &proto.Money{MinorUnits: 2999}, Quantity: 10, ReorderThreshold: 5})
+	server.RemoveProducts(context.Background(), &proto.UpdateProductQuantityRequest{Id: 1, Quantity: 6})
+
+	res, err := server.ListLowStockProducts(context.Background(), &emptypb.Empty{})
+
+	assert.NoError(t, err)
+	assert.Equal(t, 1, len(res.Products))
+	assert.Equal(t, int32(5), res.Products[0].ReorderThreshold)
+}
+
+func Test_Server_ListDeletedProductsShouldIncludeDeletionTime(t *testing.T) {
+	db := setupDatabase(t)
+	defer teardownDatabase(db)
+
+	server.CreateProduct(context.Background(), &proto.CreateProductRequest{Name: "Lamp", Price: &proto.Money{MinorUnits: 2999}})
+	server.DeleteProduct(context.Background(), &proto.ProductIdRequest{Id: 1})
+
+	deleted, err1 := server.ListDeletedProducts(context.Background(), &emptypb.Empty{})
+	restored, err2 := server.RestoreProduct(context.Background(), &proto.ProductIdRequest{Id: 1})
+
+	assert.NoError(t, err1)
+	assert.NoError(t, err2)
+	assert.Equal(t, 1, len(deleted.Products))
+	assert.NotNil(t, deleted.Products[0].DeletedAt)
+	assert.Nil(t, restored.DeletedAt)
 }
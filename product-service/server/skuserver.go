@@ -0,0 +1,81 @@
+package server
+
+import (
+	"context"
+	"product-service/models"
+	proto "product-service/proto/product"
+)
+
+func (s *GRPCServer) CreateSku(ctx context.Context, req *proto.CreateSkuRequest) (*proto.Sku, error) {
+	sku, err := s.skus.CreateSku(req.ProductId, req.Code, req.Attributes, priceOverride(req.PriceOverride), req.PriceOverride.GetCurrencyCode(), req.Quantity)
+
+	if err != nil {
+		return nil, err
+	}
+
+	return s.skuResponse(sku)
+}
+
+func (s *GRPCServer) GetSku(ctx context.Context, req *proto.SkuIdRequest) (*proto.Sku, error) {
+	sku, err := s.skus.GetSku(req.Id)
+
+	if err != nil {
+		return nil, err
+	}
+
+	return s.skuResponse(sku)
+}
+
+func (s *GRPCServer) UpdateSku(ctx context.Context, req *proto.UpdateSkuRequest) (*proto.Sku, error) {
+	sku, err := s.skus.UpdateSku(req.Id, req.Code, req.Attributes, priceOverride(req.PriceOverride), req.PriceOverride.GetCurrencyCode(), req.UpdateMask.GetPaths())
+
+	if err != nil {
+		return nil, err
+	}
+
+	return s.skuResponse(sku)
+}
+
+func (s *GRPCServer) DeleteSku(ctx context.Context, req *proto.SkuIdRequest) (*proto.SkuIdRequest, error) {
+	if err := s.skus.DeleteSku(req.Id); err != nil {
+		return nil, err
+	}
+
+	return &proto.SkuIdRequest{Id: req.Id}, nil
+}
+
+func priceOverride(price *proto.Money) *int64 {
+	if price == nil {
+		return nil
+	}
+
+	minorUnits := price.MinorUnits
+
+	return &minorUnits
+}
+
+func (s *GRPCServer) skuResponse(sku *models.Sku) (*proto.Sku, error) {
+	product, err := s.products.GetProduct(int32(sku.ProductID))
+
+	if err != nil {
+		return nil, err
+	}
+
+	return toSkuResponse(sku, product.Currency), nil
+}
+
+func toSkuResponse(sku *models.Sku, currency string) *proto.Sku {
+	response := &proto.Sku{
+		Id:         int32(sku.ID),
+		ProductId:  int32(sku.ProductID),
+		Code:       sku.Code,
+		Attributes: sku.Attributes,
+		Quantity:   sku.Quantity,
+	}
+
+	if sku.PriceMinor != nil {
+		response.PriceOverride = &proto.Money{CurrencyCode: currency, MinorUnits: *sku.PriceMinor}
+	}
+
+	return response
+}
@@ -0,0 +1,49 @@
+package server
+
+import (
+	"context"
+	proto "product-service/proto/product"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_Server_ShouldCreateSkuWorkCorrectly(t *testing.T) {
+	db := setupDatabase(t)
+	defer teardownDatabase(db)
+
+	server.CreateProduct(context.Background(), &proto.CreateProductRequest{Name: "T-Shirt", Price: &proto.Money{CurrencyCode: "EUR", MinorUnits: 1999}})
+
+	sku, err1 := server.CreateSku(context.Background(), &proto.CreateSkuRequest{
+		ProductId:     1,
+		Code:          "TS-M",
+		Attributes:    map[string]string{"size": "M"},
+		PriceOverride: &proto.Money{MinorUnits: 2199},
+		Quantity:      4,
+	})
+	product, err2 := server.GetProduct(context.Background(), &proto.ProductIdRequest{Id: 1})
+
+	assert.NoError(t, err1)
+	assert.NoError(t, err2)
+	assert.Equal(t, "EUR", sku.PriceOverride.CurrencyCode)
+	assert.Equal(t, int64(2199), sku.PriceOverride.MinorUnits)
+	assert.Equal(t, int32(4), product.Quantity)
+	assert.Equal(t, "TS-M", product.Skus[0].Code)
+}
+
+func Test_Server_UpdateProductsShouldDecrementSkuStock(t *testing.T) {
+	db := setupDatabase(t)
+	defer teardownDatabase(db)
+
+	server.CreateProduct(context.Background(), &proto.CreateProductRequest{Name: "T-Shirt", Price: &proto.Money{MinorUnits: 1999}})
+	server.CreateSku(context.Background(), &proto.CreateSkuRequest{ProductId: 1, Code: "TS-M", Quantity: 4})
+
+	res, err := server.UpdateProducts(context.Background(), &proto.UpdateProductRequest{
+		Products: []*proto.UpdateProduct{{Id: 1, SkuId: 1, Quantity: 3}},
+	})
+	sku, _ := server.GetSku(context.Background(), &proto.SkuIdRequest{Id: 1})
+
+	assert.NoError(t, err)
+	assert.NotNil(t, res.GetSuccessResponse())
+	assert.Equal(t, int32(1), sku.Quantity)
+}
@@ -0,0 +1,108 @@
+package server
+
+import (
+	"context"
+	"errors"
+	proto "product-service/proto/product"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func intercept(call func(ctx context.Context) (interface{}, error)) error {
+	_, err := StatusInterceptor(context.Background(), nil, &grpc.UnaryServerInfo{}, func(ctx context.Context, _ interface{}) (interface{}, error) {
+		return call(ctx)
+	})
+
+	return err
+}
+
+func Test_Server_StatusInterceptorShouldMapNotFound(t *testing.T) {
+	db := setupDatabase(t)
+	defer teardownDatabase(db)
+
+	err1 := intercept(func(ctx context.Context) (interface{}, error) {
+		return server.GetProduct(ctx, &proto.ProductIdRequest{Id: 1001})
+	})
+	err2 := intercept(func(ctx context.Context) (interface{}, error) {
+		return server.DeleteCategory(ctx, &proto.CategoryIdRequest{Id: 1001})
+	})
+
+	assert.Equal(t, codes.NotFound, status.Code(err1))
+	assert.Equal(t, codes.NotFound, status.Code(err2))
+	assert.Equal(t, "category with id 1001 does not exist", status.Convert(err2).Message())
+}
+
+func Test_Server_StatusInterceptorShouldMapAlreadyExists(t *testing.T) {
+	db := setupDatabase(t)
+	defer teardownDatabase(db)
+
+	server.CreateCategory(context.Background(), &proto.CreateCategoryRequest{Name: "Clothing"})
+
+	err := intercept(func(ctx context.Context) (interface{}, error) {
+		return server.CreateCategory(ctx, &proto.CreateCategoryRequest{Name: "Clothing"})
+	})
+
+	assert.Equal(t, codes.AlreadyExists, status.Code(err))
+	assert.Equal(t, "category with the same name already exists", status.Convert(err).Message())
+}
+
+func Test_Server_StatusInterceptorShouldMapFailedPrecondition(t *testing.T) {
+	db := setupDatabase(t)
+	defer teardownDatabase(db)
+
+	server.CreateProduct(context.Background(), &proto.CreateProductRequest{Name: "Lamp", Price: &proto.Money{MinorUnits: 2999}, Quantity: 5})
+
+	err1 := intercept(func(ctx context.Context) (interface{}, error) {
+		return server.PurgeProduct(ctx, &proto.ProductIdRequest{Id: 1})
+	})
+	err2 := intercept(func(ctx context.Context) (interface{}, error) {
+		return server.RemoveProducts(ctx, &proto.UpdateProductQuantityRequest{Id: 1, Quantity: 10})
+	})
+
+	assert.Equal(t, codes.FailedPrecondition, status.Code(err1))
+	assert.Equal(t, codes.FailedPrecondition, status.Code(err2))
+	assert.Equal(t, "too many products to be removed", status.Convert(err2).Message())
+}
+
+func Test_Server_StatusInterceptorShouldKeepExistingStatusesAndMapInvalidArguments(t *testing.T) {
+	db := setupDatabase(t)
+	defer teardownDatabase(db)
+
+	server.CreateProduct(context.Background(), &proto.CreateProductRequest{Name: "Lamp", Price: &proto.Money{MinorUnits: 2999}, Quantity: 5})
+
+	err1 := intercept(func(ctx context.Context) (interface{}, error) {
+		return server.AddProducts(ctx, &proto.UpdateProductQuantityRequest{Id: 1, Quantity: 1, ExpectedVersion: 5})
+	})
+	err2 := intercept(func(ctx context.Context) (interface{}, error) {
+		return server.AddProducts(ctx, &proto.UpdateProductQuantityRequest{Id: 1, Quantity: -1})
+	})
+
+	assert.Equal(t, codes.Aborted, status.Code(err1))
+	assert.Equal(t, codes.InvalidArgument, status.Code(err2))
+	assert.Equal(t, "quantity added cannot be less than 0", status.Convert(err2).Message())
+}
+
+func Test_Server_StatusInterceptorShouldMapUnsupportedCurrenciesToInvalidArgument(t *testing.T) {
+	db := setupDatabase(t)
+	defer teardownDatabase(db)
+
+	err := intercept(func(ctx context.Context) (interface{}, error) {
+		return server.CreateProduct(ctx, &proto.CreateProductRequest{Name: "Lamp", Price: &proto.Money{CurrencyCode: "XYZ", MinorUnits: 2999}})
+	})
+
+	assert.Equal(t, codes.InvalidArgument, status.Code(err))
+	assert.Equal(t, "unsupported currency code XYZ", status.Convert(err).Message())
+}
+
+func Test_Server_StatusInterceptorShouldDefaultToInternal(t *testing.T) {
+	err := intercept(func(ctx context.Context) (interface{}, error) {
+		return nil, errors.New("connection reset by peer")
+	})
+
+	assert.Equal(t, codes.Internal, status.Code(err))
+	assert.Equal(t, "connection reset by peer", status.Convert(err).Message())
+}
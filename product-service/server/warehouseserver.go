@@ -0,0 +1,73 @@
+package server
+
+import (
+	"context"
+	"product-service/models"
+	proto "product-service/proto/product"
+
+	"google.golang.org/protobuf/types/known/emptypb"
+)
+
+func (s *GRPCServer) CreateWarehouse(ctx context.Context, req *proto.CreateWarehouseRequest) (*proto.Warehouse, error) {
+	warehouse, err := s.warehouses.CreateWarehouse(req.Name, req.Location.GetLatitude(), req.Location.GetLongitude(), req.Priority)
+
+	if err != nil {
+		return nil, err
+	}
+
+	return toWarehouseResponse(warehouse), nil
+}
+
+func (s *GRPCServer) GetAllWarehouses(ctx context.Context, _ *emptypb.Empty) (*proto.GetAllWarehousesResponse, error) {
+	warehouses, err := s.warehouses.GetAllWarehouses()
+
+	if err != nil {
+		return nil, err
+	}
+
+	warehousesResponse := make([]*proto.Warehouse, len(warehouses))
+
+	for idx := range warehouses {
+		warehousesResponse[idx] = toWarehouseResponse(&warehouses[idx])
+	}
+
+	return &proto.GetAllWarehousesResponse{Warehouses: warehousesResponse}, nil
+}
+
+func (s *GRPCServer) GetWarehouse(ctx context.Context, req *proto.WarehouseIdRequest) (*proto.Warehouse, error) {
+	warehouse, err := s.warehouses.GetWarehouse(req.Id)
+
+	if err != nil {
+		return nil, err
+	}
+
+	return toWarehouseResponse(warehouse), nil
+}
+
+func (s *GRPCServer) DeleteWarehouse(ctx context.Context, req *proto.WarehouseIdRequest) (*proto.WarehouseIdRequest, error) {
+	if err := s.warehouses.DeleteWarehouse(req.Id); err != nil {
+		return nil, err
+	}
+
+	return &proto.WarehouseIdRequest{Id: req.Id}, nil
+}
+
+func (s *GRPCServer) TransferStock(ctx context.Context, req *proto.TransferStockRequest) (*proto.CreateProductResponse, error) {
+	product, err := s.warehouses.TransferStock(req.ProductId, req.SkuId, req.FromWarehouseId, req.ToWarehouseId, req.Quantity, req.ExpectedVersion)
+
+	if err != nil {
+		return nil, versionError(err)
+	}
+
+	return toProductResponse(product), nil
+}
+
+func toWarehouseResponse(warehouse *models.Warehouse) *proto.Warehouse {
+	return &proto.Warehouse{
+		Id:        int32(warehouse.ID),
+		Name:      warehouse.Name,
+		Location:  &proto.Location{Latitude: warehouse.Latitude, Longitude: warehouse.Longitude},
+		Priority:  warehouse.Priority,
+		IsDefault: warehouse.IsDefault,
+	}
+}
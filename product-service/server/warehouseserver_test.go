@@ -0,0 +1,66 @@
+package server
+
+import (
+	"context"
+	proto "product-service/proto/product"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/protobuf/types/known/emptypb"
+)
+
+func Test_Server_ShouldCreateWarehouseWorkCorrectly(t *testing.T) {
+	db := setupDatabase(t)
+	defer teardownDatabase(db)
+
+	warehouse, err1 := server.CreateWarehouse(context.Background(), &proto.CreateWarehouseRequest{
+		Name:     "East",
+		Location: &proto.Location{Latitude: 40.71, Longitude: -74.00},
+		Priority: 1,
+	})
+	warehouses, err2 := server.GetAllWarehouses(context.Background(), &emptypb.Empty{})
+
+	assert.NoError(t, err1)
+	assert.NoError(t, err2)
+	assert.Equal(t, 40.71, warehouse.Location.Latitude)
+	assert.Equal(t, 2, len(warehouses.Warehouses))
+}
+
+func Test_Server_TransferStockShouldExposePerWarehouseStock(t *testing.T) {
+	db := setupDatabase(t)
+	defer teardownDatabase(db)
+
+	server.CreateProduct(context.Background(), &proto.CreateProductRequest{Name: "Lamp", Price: &proto.Money{MinorUnits: 2999}, Quantity: 5})
+	server.CreateWarehouse(context.Background(), &proto.CreateWarehouseRequest{Name: "East"})
+
+	product, err := server.TransferStock(context.Background(), &proto.TransferStockRequest{ProductId: 1, FromWarehouseId: 1, ToWarehouseId: 2, Quantity: 2})
+
+	assert.NoError(t, err)
+	assert.Equal(t, int32(5), product.Quantity)
+	assert.Equal(t, 2, len(product.Stock))
+}
+
+func Test_Server_UpdateProductsShouldUseRequestedAllocationStrategy(t *testing.T) {
+	db := setupDatabase(t)
+	defer teardownDatabase(db)
+
+	server.CreateProduct(context.Background(), &proto.CreateProductRequest{Name: "Lamp", Price: &proto.Money{MinorUnits: 2999}, Quantity: 2})
+	server.CreateWarehouse(context.Background(), &proto.CreateWarehouseRequest{Name: "East"})
+	server.AddProducts(context.Background(), &proto.UpdateProductQuantityRequest{Id: 1, WarehouseId: 2, Quantity: 6})
+
+	res, err := server.UpdateProducts(context.Background(), &proto.UpdateProductRequest{
+		Products:           []*proto.UpdateProduct{{Id: 1, Quantity: 3}},
+		AllocationStrategy: "most_stock",
+	})
+	product, _ := server.GetProduct(context.Background(), &proto.ProductIdRequest{Id: 1})
+
+	assert.NoError(t, err)
+	assert.NotNil(t, res.GetSuccessResponse())
+	assert.Equal(t, int32(5), product.Quantity)
+
+	for _, stock := range product.Stock {
+		if stock.WarehouseId == 2 {
+			assert.Equal(t, int32(3), stock.Quantity)
+		}
+	}
+}
@@ -0,0 +1,42 @@
+package services
+
+import (
+	"log/slog"
+	"product-service/models"
+	"product-service/notifier"
+	"time"
+)
+
+func isLowStock(quantity int32, threshold int32) bool {
+	return threshold > 0 && quantity <= threshold
+}
+
+func (inventory *Inventory) notifyThresholdCrossing(before *models.Product, after *models.Product) {
+	if before == nil || after == nil {
+		return
+	}
+
+	wasLow := isLowStock(before.Quantity, after.ReorderThreshold)
+	isLow := isLowStock(after.Quantity, after.ReorderThreshold)
+
+	if wasLow == isLow {
+		return
+	}
+
+	alert := notifier.Alert{
+		Kind:        notifier.Restocked,
+		ProductID:   after.ID,
+		ProductName: after.Name,
+		Quantity:    after.Quantity,
+		Threshold:   after.ReorderThreshold,
+		At:          time.Now(),
+	}
+
+	if isLow {
+		alert.Kind = notifier.LowStock
+	}
+
+	if err := inventory.notifier.Notify(alert); err != nil {
+		slog.Error("failed to deliver stock alert", "product_id", after.ID, "kind", alert.Kind, "error", err)
+	}
+}
@@ -0,0 +1,81 @@
+package services
+
+import (
+	"product-service/notifier"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"gorm.io/gorm"
+)
+
+type recordingNotifier struct {
+	alerts []notifier.Alert
+}
+
+func (recorder *recordingNotifier) Notify(alert notifier.Alert) error {
+	recorder.alerts = append(recorder.alerts, alert)
+	return nil
+}
+
+func recordAlerts(db *gorm.DB) *recordingNotifier {
+	recorder := &recordingNotifier{}
+	setupServices(db, recorder)
+
+	return recorder
+}
+
+func Test_Service_RemoveProductsShouldAlertWhenCrossingThreshold(t *testing.T) {
+	db := setupDatabase(t)
+	defer teardownDatabase(db)
+
+	recorder := recordAlerts(db)
+	productService.CreateProduct("Lamp", "", 2999, "", 10, 5)
+
+	productService.RemoveProducts(1, 0, 0, 4, 0)
+	productService.RemoveProducts(1, 0, 0, 2, 0)
+	productService.RemoveProducts(1, 0, 0, 1, 0)
+
+	assert.Equal(t, 1, len(recorder.alerts))
+	assert.Equal(t, notifier.LowStock, recorder.alerts[0].Kind)
+	assert.Equal(t, int32(4), recorder.alerts[0].Quantity)
+	assert.Equal(t, int32(5), recorder.alerts[0].Threshold)
+}
+
+func Test_Service_AddProductsShouldAlertWhenRestocked(t *testing.T) {
+	db := setupDatabase(t)
+	defer teardownDatabase(db)
+
+	recorder := recordAlerts(db)
+	productService.CreateProduct("Lamp", "", 2999, "", 2, 5)
+
+	productService.AddProducts(1, 0, 0, 10, 0)
+
+	assert.Equal(t, 1, len(recorder.alerts))
+	assert.Equal(t, notifier.Restocked, recorder.alerts[0].Kind)
+}
+
+func Test_Service_UpdateProductsShouldAlertWhenCrossingThreshold(t *testing.T) {
+	db := setupDatabase(t)
+	defer teardownDatabase(db)
+
+	recorder := recordAlerts(db)
+	productService.CreateProduct("Lamp", "", 2999, "", 10, 3)
+	productService.CreateProduct("Desk", "", 9999, "", 10, 0)
+
+	err := productService.UpdateProducts([]int64{1, 2}, nil, []int32{8, 10}, "", nil)
+
+	assert.NoError(t, err)
+	assert.Equal(t, 1, len(recorder.alerts))
+	assert.Equal(t, int64(1), recorder.alerts[0].ProductID)
+}
+
+func Test_Service_ShouldCreateProductThrowAnErrorIfReorderThresholdIsNegative(t *testing.T) {
+	db := setupDatabase(t)
+	defer teardownDatabase(db)
+
+	product, err := productService.CreateProduct("Lamp", "", 2999, "", 10, -1)
+
+	assert.Error(t, err)
+	assert.Nil(t, product)
+	assert.Equal(t, "reorder threshold cannot be less than 0", err.Error())
+}
@@ -0,0 +1,164 @@
+package services
+
+import (
+	"product-service/models"
+)
+
+type CategoryService struct {
+	categories models.CategoryRepository
+	products   models.ProductRepository
+}
+
+func NewCategoryService(categories models.CategoryRepository, products models.ProductRepository) *CategoryService {
+	return &CategoryService{categories: categories, products: products}
+}
+
+func (service *CategoryService) CreateCategory(name string, description string, parentID int32) (*models.Category, error) {
+	if len(name) == 0 {
+		return nil, models.InvalidArgumentError("category name cannot be empty")
+	}
+
+	var parent *int64
+
+	if parentID != 0 {
+		if _, err := service.categories.GetCategory(parentID); err != nil {
+			return nil, models.NotFoundError("parent category does not exist")
+		}
+
+		id := int64(parentID)
+		parent = &id
+	}
+
+	category, err := service.categories.FindCategoryByName(name, parent)
+
+	if err != nil {
+		return nil, err
+	}
+
+	if category != nil {
+		return nil, models.AlreadyExistsError("category with the same name already exists")
+	}
+
+	newCategory := models.Category{
+		Name:        name,
+		Description: description,
+		ParentID:    parent,
+	}
+
+	return service.categories.CreateCategory(&newCategory)
+}
+
+func (service *CategoryService) GetAllCategories() ([]models.Category, error) {
+	return service.categories.GetAllCategories()
+}
+
+func (service *CategoryService) GetCategory(id int32) (*models.Category, error) {
+	return service.categories.GetCategory(id)
+}
+
+func (service *CategoryService) UpdateCategory(id int32, name string, description string, parentID int32, paths []string) (*models.Category, error) {
+	if len(paths) == 0 {
+		return nil, models.InvalidArgumentError("update mask cannot be empty")
+	}
+
+	category, err := service.categories.GetCategory(id)
+
+	if err != nil {
+		return nil, err
+	}
+
+	fields := make(map[string]interface{})
+	parent := category.ParentID
+
+	for _, path := range paths {
+		switch path {
+		case "name":
+			if len(name) == 0 {
+				return nil, models.InvalidArgumentError("category name cannot be empty")
+			}
+
+			fields["name"] = name
+		case "description":
+			fields["description"] = description
+		case "parent_id":
+			if parentID == 0 {
+				parent = nil
+				fields["parent_id"] = nil
+				continue
+			}
+
+			descendants, err := service.categories.GetCategoryDescendantIds(id)
+
+			if err != nil {
+				return nil, err
+			}
+
+			for _, descendant := range descendants {
+				if descendant == int64(parentID) {
+					return nil, models.InvalidArgumentError("category cannot be moved under itself or its sub-categories")
+				}
+			}
+
+			if _, err := service.categories.GetCategory(parentID); err != nil {
+				return nil, models.NotFoundError("parent category does not exist")
+			}
+
+			newParent := int64(parentID)
+			parent = &newParent
+			fields["parent_id"] = newParent
+		default:
+			return nil, models.InvalidArgumentError("invalid field %s in update mask", path)
+		}
+	}
+
+	_, nameChanged := fields["name"]
+	_, parentChanged := fields["parent_id"]
+
+	if nameChanged || parentChanged {
+		newName := category.Name
+
+		if nameChanged {
+			newName = name
+		}
+
+		existing, err := service.categories.FindCategoryByName(newName, parent)
+
+		if err != nil {
+			return nil, err
+		}
+
+		if existing != nil && existing.ID != category.ID {
+			return nil, models.AlreadyExistsError("category with the same name already exists")
+		}
+	}
+
+	return service.categories.UpdateCategory(id, fields)
+}
+
+func (service *CategoryService) DeleteCategory(id int32) error {
+	return service.categories.DeleteCategory(id)
+}
+
+func (service *CategoryService) AddProductToCategory(productID int32, categoryID int32) error {
+	return service.categories.AddProductToCategory(productID, categoryID)
+}
+
+func (service *CategoryService) RemoveProductFromCategory(productID int32, categoryID int32) error {
+	return service.categories.RemoveProductFromCategory(productID, categoryID)
+}
+
+func (service *CategoryService) GetProductsByCategory(categoryID int32) ([]models.Product, error) {
+	categoryIds, err := service.categories.GetCategoryDescendantIds(categoryID)
+
+	if err != nil {
+		return nil, err
+	}
+
+	productIds, err := service.categories.GetCategoryProductIds(categoryIds)
+
+	if err != nil {
+		return nil, err
+	}
+
+	return service.products.GetProductsByIds(productIds)
+}
@@ -0,0 +1,109 @@
+package services
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_Service_ShouldCreateCategoryWorkCorrectly(t *testing.T) {
+	db := setupDatabase(t)
+	defer teardownDatabase(db)
+
+	root, err1 := categoryService.CreateCategory("Clothing", "All kinds of clothing", 0)
+	child, err2 := categoryService.CreateCategory("Men", "", int32(root.ID))
+
+	assert.NoError(t, err1)
+	assert.NoError(t, err2)
+	assert.Nil(t, root.ParentID)
+	assert.Equal(t, root.ID, *child.ParentID)
+}
+
+func Test_Service_ShouldCreateCategoryThrowAnErrorIfParentDoesNotExist(t *testing.T) {
+	db := setupDatabase(t)
+	defer teardownDatabase(db)
+
+	category, err := categoryService.CreateCategory("Men", "", 1001)
+
+	assert.Error(t, err)
+	assert.Nil(t, category)
+	assert.Equal(t, "parent category does not exist", err.Error())
+}
+
+func Test_Service_ShouldCreateCategoryThrowAnErrorIfNameIsTaken(t *testing.T) {
+	db := setupDatabase(t)
+	defer teardownDatabase(db)
+
+	categoryService.CreateCategory("Clothing", "", 0)
+
+	category, err := categoryService.CreateCategory("Clothing", "", 0)
+
+	assert.Error(t, err)
+	assert.Nil(t, category)
+	assert.Equal(t, "category with the same name already exists", err.Error())
+}
+
+func Test_Service_UpdateCategoryShouldMoveTheCategory(t *testing.T) {
+	db := setupDatabase(t)
+	defer teardownDatabase(db)
+
+	clothing, _ := categoryService.CreateCategory("Clothing", "", 0)
+	shoes, _ := categoryService.CreateCategory("Shoes", "", 0)
+
+	category, err := categoryService.UpdateCategory(int32(shoes.ID), "", "", int32(clothing.ID), []string{"parent_id"})
+
+	assert.NoError(t, err)
+	assert.Equal(t, "Shoes", category.Name)
+	assert.Equal(t, clothing.ID, *category.ParentID)
+}
+
+func Test_Service_UpdateCategoryShouldThrowAnErrorIfMovedUnderItsDescendant(t *testing.T) {
+	db := setupDatabase(t)
+	defer teardownDatabase(db)
+
+	clothing, _ := categoryService.CreateCategory("Clothing", "", 0)
+	men, _ := categoryService.CreateCategory("Men", "", int32(clothing.ID))
+
+	category, err := categoryService.UpdateCategory(int32(clothing.ID), "", "", int32(men.ID), []string{"parent_id"})
+
+	assert.Error(t, err)
+	assert.Nil(t, category)
+	assert.Equal(t, "category cannot be moved under itself or its sub-categories", err.Error())
+}
+
+func Test_Service_UpdateCategoryShouldThrowAnErrorIfFieldIsInvalid(t *testing.T) {
+	db := setupDatabase(t)
+	defer teardownDatabase(db)
+
+	categoryService.CreateCategory("Clothing", "", 0)
+
+	category, err := categoryService.UpdateCategory(1, "", "", 0, []string{"products"})
+
+	assert.Error(t, err)
+	assert.Nil(t, category)
+	assert.Equal(t, "invalid field products in update mask", err.Error())
+}
+
+func Test_Service_GetProductsByCategoryShouldIncludeDescendants(t *testing.T) {
+	db := setupDatabase(t)
+	defer teardownDatabase(db)
+
+	clothing, _ := categoryService.CreateCategory("Clothing", "", 0)
+	shoes, _ := categoryService.CreateCategory("Shoes", "", int32(clothing.ID))
+	electronics, _ := categoryService.CreateCategory("Electronics", "", 0)
+
+	productService.CreateProduct("Sneakers", "", 4999, "", 10, 0)
+	productService.CreateProduct("T-Shirt", "", 1999, "", 10, 0)
+	productService.CreateProduct("Phone", "", 49999, "", 10, 0)
+
+	categoryService.AddProductToCategory(1, int32(shoes.ID))
+	categoryService.AddProductToCategory(2, int32(clothing.ID))
+	categoryService.AddProductToCategory(3, int32(electronics.ID))
+
+	products, err := categoryService.GetProductsByCategory(int32(clothing.ID))
+
+	assert.NoError(t, err)
+	assert.Equal(t, 2, len(products))
+	assert.Equal(t, "Sneakers", products[0].Name)
+	assert.Equal(t, "T-Shirt", products[1].Name)
+}
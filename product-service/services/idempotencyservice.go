@@ -0,0 +1,76 @@
+package services
+
+import (
+	"errors"
+	"product-service/models"
+	"time"
+)
+
+var (
+	ErrIdempotencyKeyReused     = errors.New("idempotency key has already been used with a different request")
+	ErrIdempotencyKeyInProgress = errors.New("a request with the same idempotency key is still in progress")
+)
+
+type IdempotencyService struct {
+	keys      models.IdempotencyRepository
+	retention time.Duration
+	lease     time.Duration
+}
+
+// NewIdempotencyService forgets completed keys after retention and lets a
+// retry take over a reservation once lease has passed without a response.
+func NewIdempotencyService(keys models.IdempotencyRepository, retention time.Duration, lease time.Duration) (*IdempotencyService, error) {
+	if retention <= 0 {
+		return nil, errors.New("idempotency retention must be greater than 0")
+	}
+
+	if lease <= 0 {
+		return nil, errors.New("idempotency lease must be greater than 0")
+	}
+
+	return &IdempotencyService{keys: keys, retention: retention, lease: lease}, nil
+}
+
+// BeginIdempotentRequest scopes the key to the caller, so two callers picking
+// the same key never see each other's responses.
+func (service *IdempotencyService) BeginIdempotentRequest(caller string, key string, method string, fingerprint string) (*models.IdempotencyRecord, error) {
+	if len(key) > 255 {
+		return nil, models.InvalidArgumentError("idempotency key cannot be longer than 255 characters")
+	}
+
+	if _, err := service.keys.PurgeIdempotencyKeys(time.Now().Add(-service.retention)); err != nil {
+		return nil, err
+	}
+
+	record, reserved, err := service.keys.ReserveIdempotencyKey(caller, key, method, fingerprint, service.lease)
+
+	if err != nil {
+		return nil, err
+	}
+
+	if reserved {
+		return nil, nil
+	}
+
+	if record.Method != method || record.Fingerprint != fingerprint {
+		return nil, ErrIdempotencyKeyReused
+	}
+
+	if record.Response == nil {
+		return nil, ErrIdempotencyKeyInProgress
+	}
+
+	return record, nil
+}
+
+func (service *IdempotencyService) CompleteIdempotentRequest(caller string, key string, responseType string, response []byte) error {
+	if response == nil {
+		response = []byte{}
+	}
+
+	return service.keys.CompleteIdempotencyKey(caller, key, responseType, response)
+}
+
+func (service *IdempotencyService) AbandonIdempotentRequest(caller string, key string) error {
+	return service.keys.ReleaseIdempotencyKey(caller, key)
+}
@@ -0,0 +1,117 @@
+package services
+
+import (
+	"product-service/models"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"gorm.io/gorm"
+)
+
+func newIdempotencyService(t *testing.T, db *gorm.DB, retention time.Duration, lease time.Duration) *IdempotencyService {
+	service, err := NewIdempotencyService(models.NewGormIdempotencyRepository(db), retention, lease)
+	assert.NoError(t, err)
+
+	return service
+}
+
+func Test_Service_NewIdempotencyServiceShouldRejectNonPositiveDurations(t *testing.T) {
+	_, err1 := NewIdempotencyService(nil, 0, time.Second)
+	_, err2 := NewIdempotencyService(nil, time.Hour, -time.Second)
+
+	assert.Equal(t, "idempotency retention must be greater than 0", err1.Error())
+	assert.Equal(t, "idempotency lease must be greater than 0", err2.Error())
+}
+
+func Test_Service_BeginIdempotentRequestShouldReplayCompletedRequests(t *testing.T) {
+	db := setupDatabase(t)
+	defer teardownDatabase(db)
+	idempotency := newIdempotencyService(t, db, 24*time.Hour, 30*time.Second)
+
+	first, err1 := idempotency.BeginIdempotentRequest("7", "key-1", "AddProducts", "abc")
+	_, err2 := idempotency.BeginIdempotentRequest("7", "key-1", "AddProducts", "abc")
+	idempotency.CompleteIdempotentRequest("7", "key-1", "product_service.CreateProductResponse", []byte{1, 2})
+	replay, err3 := idempotency.BeginIdempotentRequest("7", "key-1", "AddProducts", "abc")
+
+	assert.NoError(t, err1)
+	assert.Nil(t, first)
+	assert.Equal(t, ErrIdempotencyKeyInProgress, err2)
+	assert.NoError(t, err3)
+	assert.Equal(t, []byte{1, 2}, replay.Response)
+}
+
+func Test_Service_BeginIdempotentRequestShouldRejectADifferentPayload(t *testing.T) {
+	db := setupDatabase(t)
+	defer teardownDatabase(db)
+	idempotency := newIdempotencyService(t, db, 24*time.Hour, 30*time.Second)
+
+	idempotency.BeginIdempotentRequest("7", "key-1", "AddProducts", "abc")
+	idempotency.CompleteIdempotentRequest("7", "key-1", "product_service.CreateProductResponse", []byte{})
+
+	record, err := idempotency.BeginIdempotentRequest("7", "key-1", "AddProducts", "def")
+
+	assert.Nil(t, record)
+	assert.Equal(t, ErrIdempotencyKeyReused, err)
+}
+
+func Test_Service_BeginIdempotentRequestShouldForgetKeysAfterTheRetention(t *testing.T) {
+	db := setupDatabase(t)
+	defer teardownDatabase(db)
+	idempotency := newIdempotencyService(t, db, time.Millisecond, 30*time.Second)
+
+	idempotency.BeginIdempotentRequest("7", "key-1", "AddProducts", "abc")
+	idempotency.CompleteIdempotentRequest("7", "key-1", "product_service.CreateProductResponse", []byte{})
+	time.Sleep(5 * time.Millisecond)
+
+	record, err := idempotency.BeginIdempotentRequest("7", "key-1", "AddProducts", "def")
+
+	assert.NoError(t, err)
+	assert.Nil(t, record)
+}
+
+func Test_Service_AbandonIdempotentRequestShouldReleaseTheKey(t *testing.T) {
+	db := setupDatabase(t)
+	defer teardownDatabase(db)
+	idempotency := newIdempotencyService(t, db, 24*time.Hour, 30*time.Second)
+
+	idempotency.BeginIdempotentRequest("7", "key-1", "AddProducts", "abc")
+	idempotency.AbandonIdempotentRequest("7", "key-1")
+
+	record, err := idempotency.BeginIdempotentRequest("7", "key-1", "AddProducts", "def")
+
+	assert.NoError(t, err)
+	assert.Nil(t, record)
+}
+
+func Test_Service_BeginIdempotentRequestShouldScopeKeysByCaller(t *testing.T) {
+	db := setupDatabase(t)
+	defer teardownDatabase(db)
+	idempotency := newIdempotencyService(t, db, 24*time.Hour, 30*time.Second)
+
+	idempotency.BeginIdempotentRequest("7", "key-1", "AddProducts", "abc")
+	idempotency.CompleteIdempotentRequest("7", "key-1", "product_service.CreateProductResponse", []byte{1, 2})
+
+	record, err := idempotency.BeginIdempotentRequest("8", "key-1", "AddProducts", "def")
+
+	assert.NoError(t, err)
+	assert.Nil(t, record)
+}
+
+func Test_Service_BeginIdempotentRequestShouldReclaimAnExpiredReservation(t *testing.T) {
+	db := setupDatabase(t)
+	defer teardownDatabase(db)
+	idempotency := newIdempotencyService(t, db, 24*time.Hour, time.Millisecond)
+
+	idempotency.BeginIdempotentRequest("7", "key-1", "AddProducts", "abc")
+	_, err1 := idempotency.BeginIdempotentRequest("7", "key-1", "AddProducts", "abc")
+	time.Sleep(5 * time.Millisecond)
+
+	record, err2 := idempotency.BeginIdempotentRequest("7", "key-1", "AddProducts", "abc")
+	_, err3 := idempotency.BeginIdempotentRequest("7", "key-1", "AddProducts", "abc")
+
+	assert.Equal(t, ErrIdempotencyKeyInProgress, err1)
+	assert.NoError(t, err2)
+	assert.Nil(t, record)
+	assert.Equal(t, ErrIdempotencyKeyInProgress, err3)
+}
@@ -0,0 +1,187 @@
+package services
+
+import (
+	"product-service/models"
+	"product-service/utils"
+)
+
+// ImportRow is a single product row. Optional fields are nil when the
+// column was missing, so updating an existing product leaves them unchanged.
+type ImportRow struct {
+	Row              int32
+	Name             string
+	Description      *string
+	PriceMinor       *int64
+	Currency         string
+	Quantity         *int32
+	ReorderThreshold *int32
+}
+
+type ImportRowError struct {
+	Row     int32
+	Name    string
+	Message string
+}
+
+type ImportResult struct {
+	Created int32
+	Updated int32
+	DryRun  bool
+	Errors  []ImportRowError
+}
+
+type Importer struct {
+	service *ProductService
+	result  ImportResult
+	seen    map[string]bool
+}
+
+func NewImporter(service *ProductService, dryRun bool) *Importer {
+	return &Importer{service: service, result: ImportResult{DryRun: dryRun}, seen: make(map[string]bool)}
+}
+
+func (importer *Importer) DryRun() bool {
+	return importer.result.DryRun
+}
+
+func (importer *Importer) Result() ImportResult {
+	return importer.result
+}
+
+func (importer *Importer) Fail(row ImportRow, err error) {
+	importer.result.Errors = append(importer.result.Errors, ImportRowError{Row: row.Row, Name: row.Name, Message: err.Error()})
+}
+
+func (importer *Importer) Import(row ImportRow) {
+	created, err := importer.importRow(row)
+
+	if err != nil {
+		importer.Fail(row, err)
+		return
+	}
+
+	importer.seen[row.Name] = true
+
+	if created {
+		importer.result.Created++
+	} else {
+		importer.result.Updated++
+	}
+}
+
+func (importer *Importer) importRow(row ImportRow) (bool, error) {
+	if len(row.Name) == 0 {
+		return false, models.InvalidArgumentError("product name cannot be empty")
+	}
+
+	if row.PriceMinor != nil && *row.PriceMinor < 0 {
+		return false, models.InvalidArgumentError("price cannot be less than 0")
+	}
+
+	if row.Quantity != nil && *row.Quantity < 0 {
+		return false, models.InvalidArgumentError("quantity cannot be less than 0")
+	}
+
+	if row.ReorderThreshold != nil && *row.ReorderThreshold < 0 {
+		return false, models.InvalidArgumentError("reorder threshold cannot be less than 0")
+	}
+
+	currency, err := utils.NormalizeCurrency(row.Currency)
+
+	if err != nil {
+		return false, err
+	}
+
+	existing, err := importer.service.FindProductByName(row.Name)
+
+	if err != nil {
+		return false, err
+	}
+
+	if existing == nil {
+		if importer.DryRun() {
+			return !importer.seen[row.Name], nil
+		}
+
+		_, err := importer.service.CreateProduct(row.Name, valueOf(row.Description), valueOf(row.PriceMinor), currency, valueOf(row.Quantity), valueOf(row.ReorderThreshold))
+
+		return true, err
+	}
+
+	product, err := importer.service.GetProduct(int32(existing.ID))
+
+	if err != nil {
+		return false, err
+	}
+
+	var delta int32
+
+	if row.Quantity != nil {
+		delta = *row.Quantity - product.Quantity
+	}
+
+	if delta != 0 && len(product.Skus) > 0 {
+		return false, models.ErrProductHasSkus
+	}
+
+	fields := make(map[string]interface{})
+
+	if row.Description != nil {
+		fields["description"] = *row.Description
+	}
+
+	if row.PriceMinor != nil {
+		fields["price_minor"] = *row.PriceMinor
+
+		if len(row.Currency) != 0 {
+			fields["currency"] = currency
+		} else if err := checkDefaultMinorUnits(product.Currency); err != nil {
+			return false, err
+		}
+	}
+
+	if row.ReorderThreshold != nil {
+		fields["reorder_threshold"] = *row.ReorderThreshold
+	}
+
+	if importer.DryRun() || (len(fields) == 0 && delta == 0) {
+		return false, nil
+	}
+
+	_, err = importer.service.UpdateProductDetailsAndStock(int32(product.ID), fields, delta)
+
+	return false, err
+}
+
+// checkDefaultMinorUnits makes sure a price sent without a currency, which the
+// gateway converts with the default currency's minor units, means the same
+// amount in the stored currency, which the product then keeps.
+func checkDefaultMinorUnits(stored string) error {
+	storedExponent, err := utils.CurrencyExponent(stored)
+
+	if err != nil {
+		return err
+	}
+
+	defaultExponent, err := utils.CurrencyExponent(utils.DefaultCurrency)
+
+	if err != nil {
+		return err
+	}
+
+	if storedExponent != defaultExponent {
+		return models.InvalidArgumentError("the price of a %s product must be imported with its currency", stored)
+	}
+
+	return nil
+}
+
+func valueOf[T any](value *T) T {
+	var zero T
+
+	if value == nil {
+		return zero
+	}
+
+	return *value
+}
@@ -0,0 +1,156 @@
+package services
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_Service_ImporterShouldCreateAndUpdateByName(t *testing.T) {
+	db := setupDatabase(t)
+	defer teardownDatabase(db)
+
+	productService.CreateProduct("Lamp", "Old description", 2999, "", 10, 0)
+
+	importer := NewImporter(productService, false)
+	importer.Import(ImportRow{Row: 1, Name: "Lamp", Description: ptr("New description"), PriceMinor: ptr(int64(3499)), Quantity: ptr(int32(4)), ReorderThreshold: ptr(int32(2))})
+	importer.Import(ImportRow{Row: 2, Name: "Desk", PriceMinor: ptr(int64(9999)), Currency: "eur", Quantity: ptr(int32(3))})
+	result := importer.Result()
+
+	lamp, _ := productService.GetProduct(1)
+	desk, _ := productService.GetProduct(2)
+
+	assert.Equal(t, int32(1), result.Created)
+	assert.Equal(t, int32(1), result.Updated)
+	assert.Empty(t, result.Errors)
+	assert.Equal(t, "New description", lamp.Description)
+	assert.Equal(t, int64(3499), lamp.PriceMinor)
+	assert.Equal(t, int32(4), lamp.Quantity)
+	assert.Equal(t, int32(2), lamp.ReorderThreshold)
+	assert.Equal(t, "EUR", desk.Currency)
+}
+
+func Test_Service_ImporterShouldLeaveMissingFieldsUnchanged(t *testing.T) {
+	db := setupDatabase(t)
+	defer teardownDatabase(db)
+
+	productService.CreateProduct("Lamp", "Bright", 2999, "", 10, 3)
+
+	importer := NewImporter(productService, false)
+	importer.Import(ImportRow{Row: 1, Name: "Lamp", PriceMinor: ptr(int64(3499))})
+	importer.Import(ImportRow{Row: 2, Name: "Lamp", Description: ptr("")})
+	result := importer.Result()
+
+	lamp, _ := productService.GetProduct(1)
+
+	assert.Equal(t, int32(2), result.Updated)
+	assert.Empty(t, result.Errors)
+	assert.Equal(t, "", lamp.Description)
+	assert.Equal(t, int64(3499), lamp.PriceMinor)
+	assert.Equal(t, int32(10), lamp.Quantity)
+	assert.Equal(t, int32(3), lamp.ReorderThreshold)
+}
+
+func Test_Service_ImporterShouldKeepTheStoredCurrencyIfTheRowHasNone(t *testing.T) {
+	db := setupDatabase(t)
+	defer teardownDatabase(db)
+
+	productService.CreateProduct("Lamp", "", 2999, "EUR", 10, 0)
+	productService.CreateProduct("Teapot", "", 3000, "JPY", 10, 0)
+
+	importer := NewImporter(productService, false)
+	importer.Import(ImportRow{Row: 1, Name: "Lamp", PriceMinor: ptr(int64(3499))})
+	importer.Import(ImportRow{Row: 2, Name: "Teapot", PriceMinor: ptr(int64(350000))})
+	importer.Import(ImportRow{Row: 3, Name: "Teapot", PriceMinor: ptr(int64(3500)), Currency: "jpy"})
+	result := importer.Result()
+
+	lamp, _ := productService.GetProduct(1)
+	teapot, _ := productService.GetProduct(2)
+
+	assert.Equal(t, int32(2), result.Updated)
+	assert.Equal(t, []ImportRowError{{Row: 2, Name: "Teapot", Message: "the price of a JPY product must be imported with its currency"}}, result.Errors)
+	assert.Equal(t, int64(3499), lamp.PriceMinor)
+	assert.Equal(t, "EUR", lamp.Currency)
+	assert.Equal(t, int64(3500), teapot.PriceMinor)
+	assert.Equal(t, "JPY", teapot.Currency)
+}
+
+func Test_Service_ImporterShouldRollBackARowIfTheStockChangeFails(t *testing.T) {
+	db := setupDatabase(t)
+	defer teardownDatabase(db)
+
+	productService.CreateProduct("Lamp", "Bright", 2999, "", 10, 0)
+	db.Exec("UPDATE stock_levels SET quantity = 0")
+
+	importer := NewImporter(productService, false)
+	importer.Import(ImportRow{Row: 1, Name: "Lamp", Description: ptr("Dim"), PriceMinor: ptr(int64(3499)), Quantity: ptr(int32(4))})
+	result := importer.Result()
+
+	lamp, _ := productService.GetProduct(1)
+
+	assert.Equal(t, int32(0), result.Updated)
+	assert.Equal(t, "too many products to be removed", result.Errors[0].Message)
+	assert.Equal(t, "Bright", lamp.Description)
+	assert.Equal(t, int64(2999), lamp.PriceMinor)
+	assert.Equal(t, int32(10), lamp.Quantity)
+}
+
+func Test_Service_ImporterShouldReportRowErrors(t *testing.T) {
+	db := setupDatabase(t)
+	defer teardownDatabase(db)
+
+	importer := NewImporter(productService, false)
+	importer.Import(ImportRow{Row: 1, Name: "", PriceMinor: ptr(int64(100))})
+	importer.Import(ImportRow{Row: 2, Name: "Lamp", PriceMinor: ptr(int64(-1))})
+	importer.Import(ImportRow{Row: 3, Name: "Desk", PriceMinor: ptr(int64(100)), Currency: "XYZ"})
+	importer.Import(ImportRow{Row: 4, Name: "Chair", PriceMinor: ptr(int64(100)), Quantity: ptr(int32(1))})
+	result := importer.Result()
+
+	assert.Equal(t, int32(1), result.Created)
+	assert.Equal(t, 3, len(result.Errors))
+	assert.Equal(t, ImportRowError{Row: 1, Name: "", Message: "product name cannot be empty"}, result.Errors[0])
+	assert.Equal(t, "price cannot be less than 0", result.Errors[1].Message)
+	assert.Equal(t, "unsupported currency code XYZ", result.Errors[2].Message)
+}
+
+func Test_Service_ImporterShouldNotWriteInDryRun(t *testing.T) {
+	db := setupDatabase(t)
+	defer teardownDatabase(db)
+
+	productService.CreateProduct("Lamp", "", 2999, "", 10, 0)
+
+	importer := NewImporter(productService, true)
+	importer.Import(ImportRow{Row: 1, Name: "Lamp", PriceMinor: ptr(int64(1))})
+	importer.Import(ImportRow{Row: 2, Name: "Desk", PriceMinor: ptr(int64(9999))})
+	importer.Import(ImportRow{Row: 3, Name: "Desk", PriceMinor: ptr(int64(8999))})
+	result := importer.Result()
+
+	products, _ := productService.GetAllProducts()
+
+	assert.True(t, result.DryRun)
+	assert.Equal(t, int32(1), result.Created)
+	assert.Equal(t, int32(2), result.Updated)
+	assert.Equal(t, 1, len(products))
+	assert.Equal(t, int64(2999), products[0].PriceMinor)
+}
+
+func Test_Service_ImporterShouldRejectStockChangesForProductsWithSkus(t *testing.T) {
+	db := setupDatabase(t)
+	defer teardownDatabase(db)
+
+	productService.CreateProduct("T-Shirt", "", 1999, "", 0, 0)
+	skuService.CreateSku(1, "TS-M", nil, nil, "", 4)
+
+	importer := NewImporter(productService, false)
+	importer.Import(ImportRow{Row: 1, Name: "T-Shirt", PriceMinor: ptr(int64(1999)), Quantity: ptr(int32(10))})
+	importer.Import(ImportRow{Row: 2, Name: "T-Shirt", PriceMinor: ptr(int64(2199)), Quantity: ptr(int32(4))})
+	result := importer.Result()
+
+	assert.Equal(t, int32(1), result.Updated)
+	assert.Equal(t, 1, len(result.Errors))
+	assert.Equal(t, "product has skus, stock must be updated per sku", result.Errors[0].Message)
+}
+
+func ptr[T any](value T) *T {
+	return &value
+}
@@ -0,0 +1,51 @@
+package services
+
+import (
+	"product-service/models"
+	"product-service/notifier"
+	"product-service/watcher"
+	"time"
+)
+
+// Inventory fans stock changes out to the low stock notifier and to the
+// subscribers of the inventory stream.
+type Inventory struct {
+	notifier notifier.Notifier
+	hub      *watcher.Hub
+}
+
+func NewInventory(stockNotifier notifier.Notifier) *Inventory {
+	return &Inventory{notifier: stockNotifier, hub: watcher.NewHub()}
+}
+
+func (service *ProductService) WatchInventory(productIDs []int64) ([]models.Product, *watcher.Subscription, error) {
+	subscription := service.inventory.hub.Subscribe(productIDs)
+
+	var products []models.Product
+
+	for _, id := range productIDs {
+		product, err := service.products.GetProduct(int32(id))
+
+		if err != nil {
+			subscription.Close()
+			return nil, nil, models.NotFoundError("product with id %d does not exist", id)
+		}
+
+		products = append(products, *product)
+	}
+
+	return products, subscription, nil
+}
+
+func (inventory *Inventory) publish(product *models.Product) {
+	if product == nil {
+		return
+	}
+
+	inventory.hub.Publish(watcher.Update{
+		ProductID: product.ID,
+		Quantity:  product.Quantity,
+		Version:   product.Version,
+		At:        time.Now(),
+	})
+}
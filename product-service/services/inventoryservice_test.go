@@ -0,0 +1,73 @@
+package services
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_Service_WatchInventoryShouldReceiveStockChanges(t *testing.T) {
+	db := setupDatabase(t)
+	defer teardownDatabase(db)
+
+	productService.CreateProduct("Lamp", "", 2999, "", 5, 0)
+	productService.CreateProduct("Desk", "", 9999, "", 5, 0)
+
+	products, subscription, err := productService.WatchInventory([]int64{1})
+	defer subscription.Close()
+
+	productService.RemoveProducts(1, 0, 0, 2, 0)
+	productService.AddProducts(2, 0, 0, 2, 0)
+	productService.UpdateProducts([]int64{1}, nil, []int32{1}, "", nil)
+
+	updates := subscription.Drain()
+
+	assert.NoError(t, err)
+	assert.Equal(t, int32(5), products[0].Quantity)
+	assert.Equal(t, 1, len(updates))
+	assert.Equal(t, int32(2), updates[0].Quantity)
+}
+
+func Test_Service_WatchInventoryShouldThrowAnErrorIfProductDoesNotExist(t *testing.T) {
+	db := setupDatabase(t)
+	defer teardownDatabase(db)
+
+	products, subscription, err := productService.WatchInventory([]int64{1001})
+
+	assert.Error(t, err)
+	assert.Nil(t, products)
+	assert.Nil(t, subscription)
+	assert.Equal(t, "product with id 1001 does not exist", err.Error())
+}
+
+func Test_Service_WatchInventoryShouldReceiveSkuAndWarehouseStockChanges(t *testing.T) {
+	db := setupDatabase(t)
+	defer teardownDatabase(db)
+
+	productService.CreateProduct("T-Shirt", "", 1999, "", 0, 0)
+	east, _ := warehouseService.CreateWarehouse("East", 40.71, -74.00, 1)
+
+	_, subscription, _ := productService.WatchInventory([]int64{1})
+	defer subscription.Close()
+
+	sku, err := skuService.CreateSku(1, "TS-XL", nil, nil, "", 3)
+	created := subscription.Drain()
+
+	assert.NoError(t, err)
+	assert.Equal(t, 1, len(created))
+	assert.Equal(t, int32(3), created[0].Quantity)
+
+	product, err := warehouseService.TransferStock(1, int32(sku.ID), 1, int32(east.ID), 2, 0)
+	transferred := subscription.Drain()
+
+	assert.NoError(t, err)
+	assert.Equal(t, 1, len(transferred))
+	assert.Equal(t, product.Version, transferred[0].Version)
+
+	err = skuService.DeleteSku(int32(sku.ID))
+	deleted := subscription.Drain()
+
+	assert.NoError(t, err)
+	assert.Equal(t, 1, len(deleted))
+	assert.Equal(t, int32(0), deleted[0].Quantity)
+}
@@ -0,0 +1,104 @@
+package services
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"log/slog"
+	"product-service/events"
+	"product-service/models"
+	"time"
+)
+
+const (
+	outboxMaxAttempts = 10
+	outboxClaimLease  = time.Minute
+)
+
+type OutboxRelay struct {
+	outbox    models.OutboxRepository
+	publisher events.Publisher
+}
+
+func NewOutboxRelay(outbox models.OutboxRepository, publisher events.Publisher) *OutboxRelay {
+	return &OutboxRelay{outbox: outbox, publisher: publisher}
+}
+
+// RelayOutbox claims a batch of pending events and publishes it in insertion
+// order. It stops at the first failure so that consumers never see events out
+// of order, unless the failing event ran out of attempts and was parked.
+func (relay *OutboxRelay) RelayOutbox(batchSize int) (int, error) {
+	claimant, err := newClaimant()
+
+	if err != nil {
+		return 0, err
+	}
+
+	pending, err := relay.outbox.ClaimPendingEvents(claimant, batchSize, outboxClaimLease)
+
+	if err != nil {
+		return 0, err
+	}
+
+	defer func() {
+		if err := relay.outbox.ReleaseClaimedEvents(claimant); err != nil {
+			slog.Error("failed to release claimed outbox events", "error", err)
+		}
+	}()
+
+	published := 0
+
+	for idx := range pending {
+		if err := relay.publisher.Publish(pending[idx].ToEvent()); err != nil {
+			parked, markErr := relay.outbox.MarkEventFailed(pending[idx].ID, err, outboxMaxAttempts)
+
+			if markErr != nil {
+				slog.Error("failed to record the publish failure of an event", "event_id", pending[idx].ID, "error", markErr)
+			}
+
+			if !parked {
+				return published, err
+			}
+
+			slog.Error("parked an outbox event after too many failed attempts", "event_id", pending[idx].ID, "error", err)
+			continue
+		}
+
+		if err := relay.outbox.MarkEventPublished(pending[idx].ID, time.Now()); err != nil {
+			return published, err
+		}
+
+		published++
+	}
+
+	return published, nil
+}
+
+func newClaimant() (string, error) {
+	var bytes [16]byte
+
+	if _, err := rand.Read(bytes[:]); err != nil {
+		return "", err
+	}
+
+	return hex.EncodeToString(bytes[:]), nil
+}
+
+func (relay *OutboxRelay) Start(ctx context.Context, interval time.Duration, batchSize int) {
+	ticker := time.NewTicker(interval)
+
+	go func() {
+		defer ticker.Stop()
+
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+				if _, err := relay.RelayOutbox(batchSize); err != nil {
+					slog.Error("failed to relay outbox events", "error", err)
+				}
+			}
+		}
+	}()
+}
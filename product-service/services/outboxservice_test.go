@@ -0,0 +1,160 @@
+package services
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"product-service/events"
+	"product-service/models"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_Service_RelayOutboxShouldPublishDomainEventsInOrder(t *testing.T) {
+	db := setupDatabase(t)
+	defer teardownDatabase(db)
+
+	var buffer bytes.Buffer
+
+	relay := NewOutboxRelay(models.NewGormOutboxRepository(db), events.NewWriterSink(&buffer))
+
+	productService.CreateProduct("Lamp", "", 2999, "", 5, 0)
+	productService.AddProducts(1, 0, 0, 3, 0)
+	productService.DeleteProduct(1, 0)
+
+	published, err := relay.RelayOutbox(10)
+	again, _ := relay.RelayOutbox(10)
+
+	var types []events.Type
+	var stock events.StockChangedPayload
+
+	for _, line := range bytes.Split(bytes.TrimSpace(buffer.Bytes()), []byte("\n")) {
+		var event events.Event
+		json.Unmarshal(line, &event)
+		types = append(types, event.Type)
+
+		if event.Type == events.StockChanged {
+			json.Unmarshal(event.Payload, &stock)
+		}
+	}
+
+	assert.NoError(t, err)
+	assert.Equal(t, 3, published)
+	assert.Equal(t, 0, again)
+	assert.Equal(t, []events.Type{events.ProductCreated, events.StockChanged, events.ProductDeleted}, types)
+	assert.Equal(t, int32(5), stock.PreviousQuantity)
+	assert.Equal(t, int32(8), stock.Quantity)
+}
+
+func Test_Service_RelayOutboxShouldRetryFailedEvents(t *testing.T) {
+	db := setupDatabase(t)
+	defer teardownDatabase(db)
+
+	bus := events.NewBus()
+	failing := true
+	var received []events.Event
+
+	bus.Subscribe(func(event events.Event) error {
+		if failing {
+			return errors.New("consumer unavailable")
+		}
+
+		received = append(received, event)
+		return nil
+	})
+
+	relay := NewOutboxRelay(models.NewGormOutboxRepository(db), bus)
+
+	productService.CreateProduct("Lamp", "", 2999, "", 5, 0)
+
+	published, err1 := relay.RelayOutbox(10)
+	failing = false
+	republished, err2 := relay.RelayOutbox(10)
+
+	assert.Error(t, err1)
+	assert.Equal(t, 0, published)
+	assert.NoError(t, err2)
+	assert.Equal(t, 1, republished)
+	assert.Equal(t, events.ProductCreated, received[0].Type)
+}
+
+func Test_Service_FailedStockChangeShouldNotWriteAnEvent(t *testing.T) {
+	db := setupDatabase(t)
+	defer teardownDatabase(db)
+
+	relay := NewOutboxRelay(models.NewGormOutboxRepository(db), events.NewBus())
+
+	productService.CreateProduct("Lamp", "", 2999, "", 5, 0)
+	relay.RelayOutbox(10)
+
+	_, err := productService.RemoveProducts(1, 0, 0, 10, 0)
+	published, _ := relay.RelayOutbox(10)
+
+	assert.Error(t, err)
+	assert.Equal(t, 0, published)
+}
+
+func Test_Service_RelayOutboxShouldParkEventsThatKeepFailing(t *testing.T) {
+	db := setupDatabase(t)
+	defer teardownDatabase(db)
+
+	bus := events.NewBus()
+	var received []events.Event
+
+	bus.Subscribe(func(event events.Event) error {
+		if event.Type == events.ProductCreated {
+			return errors.New("consumer rejected the event")
+		}
+
+		received = append(received, event)
+		return nil
+	})
+
+	outbox := models.NewGormOutboxRepository(db)
+	relay := NewOutboxRelay(outbox, bus)
+
+	productService.CreateProduct("Lamp", "", 2999, "", 5, 0)
+	productService.AddProducts(1, 0, 0, 3, 0)
+
+	for attempt := 1; attempt < outboxMaxAttempts; attempt++ {
+		published, err := relay.RelayOutbox(10)
+
+		assert.Error(t, err)
+		assert.Equal(t, 0, published)
+	}
+
+	published, err := relay.RelayOutbox(10)
+	parked, _ := outbox.GetParkedEvents()
+
+	assert.NoError(t, err)
+	assert.Equal(t, 1, published)
+	assert.Equal(t, events.StockChanged, received[0].Type)
+	assert.Equal(t, 1, len(parked))
+	assert.Equal(t, int32(outboxMaxAttempts), parked[0].Attempts)
+	assert.Equal(t, "consumer rejected the event", parked[0].LastError)
+}
+
+func Test_Service_RelayOutboxShouldSkipEventsClaimedByAnotherRelay(t *testing.T) {
+	db := setupDatabase(t)
+	defer teardownDatabase(db)
+
+	outbox := models.NewGormOutboxRepository(db)
+	relay := NewOutboxRelay(outbox, events.NewBus())
+
+	productService.CreateProduct("Lamp", "", 2999, "", 5, 0)
+	productService.CreateProduct("Desk", "", 9999, "", 5, 0)
+
+	claimed, err1 := outbox.ClaimPendingEvents("other-relay", 1, time.Minute)
+	published, err2 := relay.RelayOutbox(10)
+	outbox.ReleaseClaimedEvents("other-relay")
+	republished, err3 := relay.RelayOutbox(10)
+
+	assert.NoError(t, err1)
+	assert.Equal(t, 1, len(claimed))
+	assert.NoError(t, err2)
+	assert.Equal(t, 1, published)
+	assert.NoError(t, err3)
+	assert.Equal(t, 1, republished)
+}
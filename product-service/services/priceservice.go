@@ -0,0 +1,90 @@
+package services
+
+import (
+	"context"
+	"log/slog"
+	"product-service/models"
+	"product-service/utils"
+	"time"
+)
+
+func (service *ProductService) SchedulePriceChange(productID int32, priceMinor int64, currency string, effectiveAt time.Time) (*models.PriceChange, error) {
+	if priceMinor < 0 {
+		return nil, models.InvalidArgumentError("price cannot be less than 0")
+	}
+
+	if !effectiveAt.After(time.Now()) {
+		return nil, models.InvalidArgumentError("effective time must be in the future")
+	}
+
+	product, err := service.products.GetProduct(productID)
+
+	if err != nil {
+		return nil, err
+	}
+
+	if len(currency) == 0 {
+		currency = product.Currency
+	}
+
+	currency, err = utils.NormalizeCurrency(currency)
+
+	if err != nil {
+		return nil, err
+	}
+
+	return service.prices.SchedulePriceChange(productID, priceMinor, currency, effectiveAt)
+}
+
+func (service *ProductService) GetPriceHistory(productID int32, at time.Time) ([]models.PriceChange, *models.PriceChange, error) {
+	if at.IsZero() {
+		at = time.Now()
+	}
+
+	changes, err := service.prices.GetPriceHistory(productID)
+
+	if err != nil {
+		return nil, nil, err
+	}
+
+	if len(changes) == 0 {
+		if _, err := service.products.GetProduct(productID); err != nil {
+			return nil, nil, err
+		}
+	}
+
+	effective, err := service.prices.GetEffectivePrice(productID, at)
+
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return changes, effective, nil
+}
+
+func (service *ProductService) ApplyDuePriceChanges() (int, error) {
+	return service.prices.ApplyDuePriceChanges(time.Now())
+}
+
+func (service *ProductService) StartPriceScheduler(ctx context.Context, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+
+	go func() {
+		defer ticker.Stop()
+
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+				applied, err := service.ApplyDuePriceChanges()
+
+				if err != nil {
+					slog.Error("failed to apply scheduled price changes", "error", err)
+				} else if applied > 0 {
+					slog.Info("applied scheduled price changes", "count", applied)
+				}
+			}
+		}
+	}()
+}
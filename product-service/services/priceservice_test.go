@@ -0,0 +1,45 @@
+package services
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_Service_SchedulePriceChangeShouldDefaultToProductCurrency(t *testing.T) {
+	db := setupDatabase(t)
+	defer teardownDatabase(db)
+
+	productService.CreateProduct("Sneakers", "", 4999, "EUR", 10, 0)
+
+	change, err := productService.SchedulePriceChange(1, 3999, "", time.Now().Add(time.Hour))
+
+	assert.NoError(t, err)
+	assert.Equal(t, "EUR", change.Currency)
+	assert.Nil(t, change.AppliedAt)
+}
+
+func Test_Service_SchedulePriceChangeShouldThrowAnErrorIfTimeIsInThePast(t *testing.T) {
+	db := setupDatabase(t)
+	defer teardownDatabase(db)
+
+	productService.CreateProduct("Sneakers", "", 4999, "", 10, 0)
+
+	change, err := productService.SchedulePriceChange(1, 3999, "", time.Now().Add(-time.Hour))
+
+	assert.Error(t, err)
+	assert.Nil(t, change)
+	assert.Equal(t, "effective time must be in the future", err.Error())
+}
+
+func Test_Service_GetPriceHistoryShouldThrowAnErrorIfProductDoesNotExist(t *testing.T) {
+	db := setupDatabase(t)
+	defer teardownDatabase(db)
+
+	changes, effective, err := productService.GetPriceHistory(1001, time.Time{})
+
+	assert.Error(t, err)
+	assert.Nil(t, changes)
+	assert.Nil(t, effective)
+}
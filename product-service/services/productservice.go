@@ -1,49 +1,206 @@
 package services
 
 import (
-	"errors"
 	"product-service/models"
+	"product-service/utils"
 )
 
-func CreateProduct(name string, description string, price float64, quantity int32) (*models.Product, error) {
+type ProductService struct {
+	products  models.ProductRepository
+	prices    models.PriceRepository
+	inventory *Inventory
+}
+
+func NewProductService(products models.ProductRepository, prices models.PriceRepository, inventory *Inventory) *ProductService {
+	return &ProductService{products: products, prices: prices, inventory: inventory}
+}
+
+func (service *ProductService) CreateProduct(name string, description string, priceMinor int64, currency string, quantity int32, reorderThreshold int32) (*models.Product, error) {
+	if priceMinor < 0 {
+		return nil, models.InvalidArgumentError("price cannot be less than 0")
+	}
+
+	if reorderThreshold < 0 {
+		return nil, models.InvalidArgumentError("reorder threshold cannot be less than 0")
+	}
+
+	currency, err := utils.NormalizeCurrency(currency)
+
+	if err != nil {
+		return nil, err
+	}
+
 	newProduct := models.Product{
-		Name:        name,
-		Description: description,
-		Price:       price,
-		Quantity:    quantity,
+		Name:             name,
+		Description:      description,
+		PriceMinor:       priceMinor,
+		Currency:         currency,
+		Quantity:         quantity,
+		ReorderThreshold: reorderThreshold,
 	}
 
-	return models.CreateProduct(&newProduct)
+	return service.products.CreateProduct(&newProduct)
+}
+
+func (service *ProductService) GetAllProducts() ([]models.Product, error) {
+	return service.products.GetAllProducts()
+}
+
+func (service *ProductService) GetProduct(id int32) (*models.Product, error) {
+	return service.products.GetProduct(id)
 }
 
-func GetAllProducts() ([]models.Product, error) {
-	return models.GetAllProducts()
+func (service *ProductService) FindProductByName(name string) (*models.Product, error) {
+	return service.products.FindProductByName(name)
 }
 
-func GetProduct(id int32) (*models.Product, error) {
-	return models.GetProduct(id)
+func (service *ProductService) GetLowStockProducts() ([]models.Product, error) {
+	return service.products.GetLowStockProducts()
 }
 
-func DeleteProduct(id int32) error {
-	return models.DeleteProduct(id)
+func (service *ProductService) UpdateProductDetails(id int32, name string, description string, priceMinor int64, currency string, reorderThreshold int32, paths []string, expectedVersion int64) (*models.Product, error) {
+	if len(paths) == 0 {
+		return nil, models.InvalidArgumentError("update mask cannot be empty")
+	}
+
+	fields := make(map[string]interface{})
+
+	for _, path := range paths {
+		switch path {
+		case "name":
+			if len(name) == 0 {
+				return nil, models.InvalidArgumentError("product name cannot be empty")
+			}
+
+			product, err := service.products.FindProductByName(name)
+
+			if err != nil {
+				return nil, err
+			}
+
+			if product != nil && product.ID != int64(id) {
+				return nil, models.AlreadyExistsError("product with the same name already exists")
+			}
+
+			fields["name"] = name
+		case "description":
+			fields["description"] = description
+		case "price":
+			if priceMinor < 0 {
+				return nil, models.InvalidArgumentError("price cannot be less than 0")
+			}
+
+			fields["price_minor"] = priceMinor
+
+			if len(currency) != 0 {
+				normalizedCurrency, err := utils.NormalizeCurrency(currency)
+
+				if err != nil {
+					return nil, err
+				}
+
+				fields["currency"] = normalizedCurrency
+			}
+		case "reorder_threshold":
+			if reorderThreshold < 0 {
+				return nil, models.InvalidArgumentError("reorder threshold cannot be less than 0")
+			}
+
+			fields["reorder_threshold"] = reorderThreshold
+		default:
+			return nil, models.InvalidArgumentError("invalid field %s in update mask", path)
+		}
+	}
+
+	return service.products.UpdateProductDetails(id, fields, expectedVersion)
 }
 
-func AddProducts(id int32, quantity int32) (*models.Product, error) {
+func (service *ProductService) UpdateProductDetailsAndStock(id int32, fields map[string]interface{}, quantityDelta int32) (*models.Product, error) {
+	before, _ := service.products.GetProduct(id)
+	product, err := service.products.UpdateProductDetailsAndStock(id, fields, quantityDelta)
+
+	if err != nil {
+		return nil, err
+	}
+
+	if quantityDelta != 0 {
+		service.inventory.notifyThresholdCrossing(before, product)
+		service.inventory.publish(product)
+	}
+
+	return product, nil
+}
+
+func (service *ProductService) DeleteProduct(id int32, expectedVersion int64) error {
+	return service.products.DeleteProduct(id, expectedVersion)
+}
+
+func (service *ProductService) GetDeletedProducts() ([]models.Product, error) {
+	return service.products.GetDeletedProducts()
+}
+
+func (service *ProductService) RestoreProduct(id int32) (*models.Product, error) {
+	return service.products.RestoreProduct(id)
+}
+
+func (service *ProductService) PurgeProduct(id int32) error {
+	return service.products.PurgeProduct(id)
+}
+
+func (service *ProductService) AddProducts(id int32, skuID int32, warehouseID int32, quantity int32, expectedVersion int64) (*models.Product, error) {
 	if quantity <= 0 {
-		return nil, errors.New("quantity added cannot be less than 0")
+		return nil, models.InvalidArgumentError("quantity added cannot be less than 0")
 	}
 
-	return models.AddProducts(id, quantity)
+	before, _ := service.products.GetProduct(id)
+	product, err := service.products.AddProducts(id, skuID, warehouseID, quantity, expectedVersion)
+
+	if err != nil {
+		return nil, err
+	}
+
+	service.inventory.notifyThresholdCrossing(before, product)
+	service.inventory.publish(product)
+
+	return product, nil
 }
 
-func RemoveProducts(id int32, quantity int32) (*models.Product, error) {
+func (service *ProductService) RemoveProducts(id int32, skuID int32, warehouseID int32, quantity int32, expectedVersion int64) (*models.Product, error) {
 	if quantity <= 0 {
-		return nil, errors.New("quantity removed cannot be less than 0")
+		return nil, models.InvalidArgumentError("quantity removed cannot be less than 0")
 	}
 
-	return models.RemoveProducts(id, quantity)
+	before, _ := service.products.GetProduct(id)
+	product, err := service.products.RemoveProducts(id, skuID, warehouseID, quantity, expectedVersion)
+
+	if err != nil {
+		return nil, err
+	}
+
+	service.inventory.notifyThresholdCrossing(before, product)
+	service.inventory.publish(product)
+
+	return product, nil
 }
 
-func UpdateProducts(ids []int64, quantities []int32) error {
-	return models.UpdateProducts(ids, quantities)
+func (service *ProductService) UpdateProducts(ids []int64, skuIds []int64, quantities []int32, strategy string, destination *models.Location) error {
+	before := make(map[int64]*models.Product)
+
+	for _, id := range ids {
+		if _, ok := before[id]; !ok {
+			before[id], _ = service.products.GetProduct(int32(id))
+		}
+	}
+
+	if err := service.products.UpdateProducts(ids, skuIds, quantities, strategy, destination); err != nil {
+		return err
+	}
+
+	for id, product := range before {
+		after, _ := service.products.GetProduct(int32(id))
+		service.inventory.notifyThresholdCrossing(product, after)
+		service.inventory.publish(after)
+	}
+
+	return nil
 }
@@ -2,6 +2,7 @@ package services
 
 import (
 	"product-service/models"
+	"product-service/notifier"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
@@ -9,15 +10,43 @@ import (
 	"gorm.io/gorm"
 )
 
+var (
+	productService   *ProductService
+	skuService       *SkuService
+	promotionService *PromotionService
+	categoryService  *CategoryService
+	warehouseService *WarehouseService
+)
+
 func setupDatabase(t *testing.T) *gorm.DB {
 	db, err := gorm.Open(sqlite.Open("file:yourDbName?mode=memory&cache=shared"), &gorm.Config{})
 	assert.NoError(t, err)
 
-	models.InitProductModel(db)
+	assert.NoError(t, models.InitProductModel(db))
+	assert.NoError(t, models.InitCategoryModel(db))
+	assert.NoError(t, models.InitSkuModel(db))
+	assert.NoError(t, models.InitWarehouseModel(db))
+	assert.NoError(t, models.InitPriceHistoryModel(db))
+	assert.NoError(t, models.InitPromotionModel(db))
+	assert.NoError(t, models.InitIdempotencyModel(db))
+	assert.NoError(t, models.InitOutboxModel(db))
+
+	setupServices(db, notifier.LogNotifier{})
 
 	return db
 }
 
+func setupServices(db *gorm.DB, stockNotifier notifier.Notifier) {
+	products := models.NewGormProductRepository(db, models.AllocationPriority)
+	inventory := NewInventory(stockNotifier)
+
+	productService = NewProductService(products, models.NewGormPriceRepository(db), inventory)
+	skuService = NewSkuService(models.NewGormSkuRepository(db), products, inventory)
+	promotionService = NewPromotionService(models.NewGormPromotionRepository(db), products)
+	categoryService = NewCategoryService(models.NewGormCategoryRepository(db), products)
+	warehouseService = NewWarehouseService(models.NewGormWarehouseRepository(db), inventory)
+}
+
 func teardownDatabase(db *gorm.DB) {
 	_ = db.Migrator().DropTable(&models.Product{})
 	sql, _ := db.DB()
@@ -32,17 +61,17 @@ func Test_Service_ShouldCreateProductWorkCorrectly(t *testing.T) {
 		ID:          1,
 		Name:        "Test Product",
 		Description: "This is a test product",
-		Price:       9.99,
+		PriceMinor:  999,
 		Quantity:    10,
 	}
 
-	createdProduct, err := CreateProduct(product.Name, product.Description, product.Price, product.Quantity)
+	createdProduct, err := productService.CreateProduct(product.Name, product.Description, product.PriceMinor, product.Currency, product.Quantity, 0)
 
 	assert.NoError(t, err)
 	assert.NotNil(t, createdProduct)
 	assert.Equal(t, product.Name, createdProduct.Name)
 	assert.Equal(t, product.Description, createdProduct.Description)
-	assert.Equal(t, product.Price, createdProduct.Price)
+	assert.Equal(t, product.PriceMinor, createdProduct.PriceMinor)
 	assert.Equal(t, product.Quantity, createdProduct.Quantity)
 }
 
@@ -53,22 +82,22 @@ func Test_Service_ShouldCreateProductThrowAnErrorIfFailedToCreateANewProduct(t *
 	product := &models.Product{
 		Name:        "Test Product",
 		Description: "This is a test product",
-		Price:       9.99,
+		PriceMinor:  999,
 		Quantity:    10,
 	}
 
-	createdProduct1, err1 := CreateProduct(product.Name, product.Description, product.Price, product.Quantity)
-	createdProduct2, err2 := CreateProduct(product.Name, product.Description, product.Price, product.Quantity)
+	createdProduct1, err1 := productService.CreateProduct(product.Name, product.Description, product.PriceMinor, product.Currency, product.Quantity, 0)
+	createdProduct2, err2 := productService.CreateProduct(product.Name, product.Description, product.PriceMinor, product.Currency, product.Quantity, 0)
 
 	assert.NoError(t, err1)
 	assert.NotNil(t, createdProduct1)
 	assert.Equal(t, product.Name, createdProduct1.Name)
 	assert.Equal(t, product.Description, createdProduct1.Description)
-	assert.Equal(t, product.Price, createdProduct1.Price)
+	assert.Equal(t, product.PriceMinor, createdProduct1.PriceMinor)
 	assert.Equal(t, product.Quantity, createdProduct1.Quantity)
 	assert.Error(t, err2)
 	assert.Nil(t, createdProduct2)
-	assert.Equal(t, "error in creating a new product", err2.Error())
+	assert.Equal(t, "error in creating a new product: UNIQUE constraint failed: products.name", err2.Error())
 }
 
 func Test_Service_GetAllProductsShouldWorkCorrectly(t *testing.T) {
@@ -78,21 +107,21 @@ func Test_Service_GetAllProductsShouldWorkCorrectly(t *testing.T) {
 	product1 := &models.Product{
 		Name:        "Test Product",
 		Description: "This is a test product",
-		Price:       9.99,
+		PriceMinor:  999,
 		Quantity:    10,
 	}
 
 	product2 := &models.Product{
 		Name:        "Test Product 2",
 		Description: "This is a test product 2",
-		Price:       8.75,
+		PriceMinor:  875,
 		Quantity:    4,
 	}
 
-	CreateProduct(product1.Name, product1.Description, product1.Price, product1.Quantity)
-	CreateProduct(product2.Name, product2.Description, product2.Price, product2.Quantity)
+	productService.CreateProduct(product1.Name, product1.Description, product1.PriceMinor, product1.Currency, product1.Quantity, 0)
+	productService.CreateProduct(product2.Name, product2.Description, product2.PriceMinor, product2.Currency, product2.Quantity, 0)
 
-	products, err := GetAllProducts()
+	products, err := productService.GetAllProducts()
 
 	assert.NoError(t, err)
 	assert.NotEmpty(t, products)
@@ -100,11 +129,11 @@ func Test_Service_GetAllProductsShouldWorkCorrectly(t *testing.T) {
 	assert.NotNil(t, products[0])
 	assert.Equal(t, product1.Name, products[0].Name)
 	assert.Equal(t, product1.Description, products[0].Description)
-	assert.Equal(t, product1.Price, products[0].Price)
+	assert.Equal(t, product1.PriceMinor, products[0].PriceMinor)
 	assert.Equal(t, product1.Quantity, products[0].Quantity)
 	assert.Equal(t, product2.Name, products[1].Name)
 	assert.Equal(t, product2.Description, products[1].Description)
-	assert.Equal(t, product2.Price, products[1].Price)
+	assert.Equal(t, product2.PriceMinor, products[1].PriceMinor)
 	assert.Equal(t, product2.Quantity, products[1].Quantity)
 
 }
@@ -116,13 +145,13 @@ func Test_Service_GetProductShouldWorkCorrectly(t *testing.T) {
 	newProduct := &models.Product{
 		Name:        "Test Product",
 		Description: "This is a test product",
-		Price:       9.99,
+		PriceMinor:  999,
 		Quantity:    10,
 	}
 
-	createdProduct, err1 := CreateProduct(newProduct.Name, newProduct.Description, newProduct.Price, newProduct.Quantity)
+	createdProduct, err1 := productService.CreateProduct(newProduct.Name, newProduct.Description, newProduct.PriceMinor, newProduct.Currency, newProduct.Quantity, 0)
 
-	product, err2 := GetProduct(int32(createdProduct.ID))
+	product, err2 := productService.GetProduct(int32(createdProduct.ID))
 
 	assert.NoError(t, err1)
 	assert.NoError(t, err2)
@@ -131,7 +160,7 @@ func Test_Service_GetProductShouldWorkCorrectly(t *testing.T) {
 	assert.Equal(t, createdProduct.ID, product.ID)
 	assert.Equal(t, createdProduct.Name, product.Name)
 	assert.Equal(t, createdProduct.Description, product.Description)
-	assert.Equal(t, createdProduct.Price, product.Price)
+	assert.Equal(t, createdProduct.PriceMinor, product.PriceMinor)
 	assert.Equal(t, createdProduct.Quantity, product.Quantity)
 }
 
@@ -139,7 +168,7 @@ func Test_Service_GetProductShouldThrowAnErrorIfTheProductDoesNotExist(t *testin
 	db := setupDatabase(t)
 	defer teardownDatabase(db)
 
-	product, err := GetProduct(100)
+	product, err := productService.GetProduct(100)
 
 	assert.Error(t, err)
 	assert.Nil(t, product)
@@ -153,15 +182,15 @@ func Test_Service_ShouldDeleteProductWorkCorrectly(t *testing.T) {
 	newProduct := &models.Product{
 		Name:        "Test Product",
 		Description: "This is a test product",
-		Price:       9.99,
+		PriceMinor:  999,
 		Quantity:    10,
 	}
 
-	createdProduct, err1 := CreateProduct(newProduct.Name, newProduct.Description, newProduct.Price, newProduct.Quantity)
+	createdProduct, err1 := productService.CreateProduct(newProduct.Name, newProduct.Description, newProduct.PriceMinor, newProduct.Currency, newProduct.Quantity, 0)
 
-	err2 := DeleteProduct(int32(createdProduct.ID))
+	err2 := productService.DeleteProduct(int32(createdProduct.ID), 0)
 
-	deletedProduct, err3 := GetProduct(int32(createdProduct.ID))
+	deletedProduct, err3 := productService.GetProduct(int32(createdProduct.ID))
 
 	assert.NoError(t, err1)
 	assert.NoError(t, err2)
@@ -175,7 +204,7 @@ func Test_Service_ShouldDeleteProductThrowAnErrorIfProductDoesNotExist(t *testin
 	db := setupDatabase(t)
 	defer teardownDatabase(db)
 
-	err := DeleteProduct(1001)
+	err := productService.DeleteProduct(1001, 0)
 
 	assert.Error(t, err)
 	assert.Equal(t, "product with id 1001 does not exist", err.Error())
@@ -188,13 +217,13 @@ func Test_Service_AddProductsShouldWorkCorrectly(t *testing.T) {
 	newProduct := &models.Product{
 		Name:        "Test Product",
 		Description: "This is a test product",
-		Price:       9.99,
+		PriceMinor:  999,
 		Quantity:    10,
 	}
 
-	CreateProduct(newProduct.Name, newProduct.Description, newProduct.Price, newProduct.Quantity)
+	productService.CreateProduct(newProduct.Name, newProduct.Description, newProduct.PriceMinor, newProduct.Currency, newProduct.Quantity, 0)
 
-	product, err := AddProducts(1, 5)
+	product, err := productService.AddProducts(1, 0, 0, 5, 0)
 
 	assert.NoError(t, err)
 	assert.NotNil(t, product)
@@ -202,14 +231,14 @@ func Test_Service_AddProductsShouldWorkCorrectly(t *testing.T) {
 	assert.Equal(t, int64(1), product.ID)
 	assert.Equal(t, newProduct.Name, product.Name)
 	assert.Equal(t, newProduct.Description, product.Description)
-	assert.Equal(t, newProduct.Price, product.Price)
+	assert.Equal(t, newProduct.PriceMinor, product.PriceMinor)
 }
 
 func Test_Service_AddProductsShouldThrowAnErrorIfQuantityIsLessThanZero(t *testing.T) {
 	db := setupDatabase(t)
 	defer teardownDatabase(db)
 
-	product, err := AddProducts(1, -5)
+	product, err := productService.AddProducts(1, 0, 0, -5, 0)
 
 	assert.Error(t, err)
 	assert.Nil(t, product)
@@ -220,7 +249,7 @@ func Test_Service_AddProductsShouldThrowAnErrorIfProductDoesNotExist(t *testing.
 	db := setupDatabase(t)
 	defer teardownDatabase(db)
 
-	product, err := AddProducts(1, 5)
+	product, err := productService.AddProducts(1, 0, 0, 5, 0)
 
 	assert.Error(t, err)
 	assert.Nil(t, product)
@@ -234,13 +263,13 @@ func Test_Service_RemoveProductsShouldWorkCorrectly(t *testing.T) {
 	newProduct := &models.Product{
 		Name:        "Test Product",
 		Description: "This is a test product",
-		Price:       9.99,
+		PriceMinor:  999,
 		Quantity:    15,
 	}
 
-	CreateProduct(newProduct.Name, newProduct.Description, newProduct.Price, newProduct.Quantity)
+	productService.CreateProduct(newProduct.Name, newProduct.Description, newProduct.PriceMinor, newProduct.Currency, newProduct.Quantity, 0)
 
-	product, err := RemoveProducts(1, 5)
+	product, err := productService.RemoveProducts(1, 0, 0, 5, 0)
 
 	assert.NoError(t, err)
 	assert.NotNil(t, product)
@@ -248,14 +277,14 @@ func Test_Service_RemoveProductsShouldWorkCorrectly(t *testing.T) {
 	assert.Equal(t, int64(1), product.ID)
 	assert.Equal(t, newProduct.Name, product.Name)
 	assert.Equal(t, newProduct.Description, product.Description)
-	assert.Equal(t, newProduct.Price, product.Price)
+	assert.Equal(t, newProduct.PriceMinor, product.PriceMinor)
 }
 
 func Test_Service_RemoveProductsShouldThrowErrorIfQuantityIsLessThanZero(t *testing.T) {
 	db := setupDatabase(t)
 	defer teardownDatabase(db)
 
-	product, err := RemoveProducts(1, -5)
+	product, err := productService.RemoveProducts(1, 0, 0, -5, 0)
 
 	assert.Error(t, err)
 	assert.Nil(t, product)
@@ -269,13 +298,13 @@ func Test_Service_RemoveProductsShouldThrowAnErrorIfInsufficentQuantityLeft(t *t
 	newProduct := &models.Product{
 		Name:        "Test Product",
 		Description: "This is a test product",
-		Price:       9.99,
+		PriceMinor:  999,
 		Quantity:    5,
 	}
 
-	CreateProduct(newProduct.Name, newProduct.Description, newProduct.Price, newProduct.Quantity)
+	productService.CreateProduct(newProduct.Name, newProduct.Description, newProduct.PriceMinor, newProduct.Currency, newProduct.Quantity, 0)
 
-	product, err := RemoveProducts(1, 10)
+	product, err := productService.RemoveProducts(1, 0, 0, 10, 0)
 
 	assert.Error(t, err)
 	assert.Nil(t, product)
@@ -286,7 +315,7 @@ func Test_Service_RemoveProductsShouldThrowAnErrorIfProductDoesNotExist(t *testi
 	db := setupDatabase(t)
 	defer teardownDatabase(db)
 
-	product, err := RemoveProducts(1, 5)
+	product, err := productService.RemoveProducts(1, 0, 0, 5, 0)
 
 	assert.Error(t, err)
 	assert.Nil(t, product)
@@ -300,25 +329,25 @@ func TestUpdateProductsShouldWorkCorrectly(t *testing.T) {
 	newProduct1 := &models.Product{
 		Name:        "Test Product",
 		Description: "This is a test product",
-		Price:       9.99,
+		PriceMinor:  999,
 		Quantity:    10,
 	}
 
 	newProduct2 := &models.Product{
 		Name:        "Test Product 2",
 		Description: "This is a test product 2",
-		Price:       8.75,
+		PriceMinor:  875,
 		Quantity:    4,
 	}
 
 	ids := []int64{1, 2}
 	quantities := []int32{6, 2}
 
-	CreateProduct(newProduct1.Name, newProduct1.Description, newProduct1.Price, newProduct1.Quantity)
-	CreateProduct(newProduct2.Name, newProduct2.Description, newProduct2.Price, newProduct2.Quantity)
+	productService.CreateProduct(newProduct1.Name, newProduct1.Description, newProduct1.PriceMinor, newProduct1.Currency, newProduct1.Quantity, 0)
+	productService.CreateProduct(newProduct2.Name, newProduct2.Description, newProduct2.PriceMinor, newProduct2.Currency, newProduct2.Quantity, 0)
 
-	err1 := UpdateProducts(ids, quantities)
-	products, err2 := GetAllProducts()
+	err1 := productService.UpdateProducts(ids, nil, quantities, "", nil)
+	products, err2 := productService.GetAllProducts()
 
 	assert.NoError(t, err1)
 	assert.NoError(t, err2)
@@ -327,12 +356,12 @@ func TestUpdateProductsShouldWorkCorrectly(t *testing.T) {
 	assert.NotNil(t, products[0])
 	assert.Equal(t, newProduct1.Name, products[0].Name)
 	assert.Equal(t, newProduct1.Description, products[0].Description)
-	assert.Equal(t, newProduct1.Price, products[0].Price)
+	assert.Equal(t, newProduct1.PriceMinor, products[0].PriceMinor)
 	assert.Equal(t, int32(4), products[0].Quantity)
 	assert.NotNil(t, products[1])
 	assert.Equal(t, newProduct2.Name, products[1].Name)
 	assert.Equal(t, newProduct2.Description, products[1].Description)
-	assert.Equal(t, newProduct2.Price, products[1].Price)
+	assert.Equal(t, newProduct2.PriceMinor, products[1].PriceMinor)
 	assert.Equal(t, int32(2), products[1].Quantity)
 }
 
@@ -343,24 +372,24 @@ func TestUpdateProductsShouldThrowErrorIfNotAllIdsAreValid(t *testing.T) {
 	newProduct1 := &models.Product{
 		Name:        "Test Product",
 		Description: "This is a test product",
-		Price:       9.99,
+		PriceMinor:  999,
 		Quantity:    10,
 	}
 
 	newProduct2 := &models.Product{
 		Name:        "Test Product 2",
 		Description: "This is a test product 2",
-		Price:       8.75,
+		PriceMinor:  875,
 		Quantity:    4,
 	}
 
 	ids := []int64{}
 	quantities := []int32{}
 
-	CreateProduct(newProduct1.Name, newProduct1.Description, newProduct1.Price, newProduct1.Quantity)
-	CreateProduct(newProduct2.Name, newProduct2.Description, newProduct2.Price, newProduct2.Quantity)
+	productService.CreateProduct(newProduct1.Name, newProduct1.Description, newProduct1.PriceMinor, newProduct1.Currency, newProduct1.Quantity, 0)
+	productService.CreateProduct(newProduct2.Name, newProduct2.Description, newProduct2.PriceMinor, newProduct2.Currency, newProduct2.Quantity, 0)
 
-	err := UpdateProducts(ids, quantities)
+	err := productService.UpdateProducts(ids, nil, quantities, "", nil)
 
 	assert.Error(t, err)
 	assert.Equal(t, "empty id set passed", err.Error())
@@ -373,24 +402,24 @@ func TestUpdateProductsShouldThrowErrorIfIdsAndQuantitiesAreOfDifferentLength(t
 	newProduct1 := &models.Product{
 		Name:        "Test Product",
 		Description: "This is a test product",
-		Price:       9.99,
+		PriceMinor:  999,
 		Quantity:    10,
 	}
 
 	newProduct2 := &models.Product{
 		Name:        "Test Product 2",
 		Description: "This is a test product 2",
-		Price:       8.75,
+		PriceMinor:  875,
 		Quantity:    4,
 	}
 
 	ids := []int64{1, 2}
 	quantities := []int32{6}
 
-	CreateProduct(newProduct1.Name, newProduct1.Description, newProduct1.Price, newProduct1.Quantity)
-	CreateProduct(newProduct2.Name, newProduct2.Description, newProduct2.Price, newProduct2.Quantity)
+	productService.CreateProduct(newProduct1.Name, newProduct1.Description, newProduct1.PriceMinor, newProduct1.Currency, newProduct1.Quantity, 0)
+	productService.CreateProduct(newProduct2.Name, newProduct2.Description, newProduct2.PriceMinor, newProduct2.Currency, newProduct2.Quantity, 0)
 
-	err := UpdateProducts(ids, quantities)
+	err := productService.UpdateProducts(ids, nil, quantities, "", nil)
 
 	assert.Error(t, err)
 	assert.Equal(t, "items no.s are mismatched", err.Error())
@@ -403,24 +432,24 @@ func TestUpdateProductsShouldThrowErrorIfSomeIdsAreInvalid(t *testing.T) {
 	newProduct1 := &models.Product{
 		Name:        "Test Product",
 		Description: "This is a test product",
-		Price:       9.99,
+		PriceMinor:  999,
 		Quantity:    10,
 	}
 
 	newProduct2 := &models.Product{
 		Name:        "Test Product 2",
 		Description: "This is a test product 2",
-		Price:       8.75,
+		PriceMinor:  875,
 		Quantity:    4,
 	}
 
 	ids := []int64{1, 3}
 	quantities := []int32{6, 2}
 
-	CreateProduct(newProduct1.Name, newProduct1.Description, newProduct1.Price, newProduct1.Quantity)
-	CreateProduct(newProduct2.Name, newProduct2.Description, newProduct2.Price, newProduct2.Quantity)
+	productService.CreateProduct(newProduct1.Name, newProduct1.Description, newProduct1.PriceMinor, newProduct1.Currency, newProduct1.Quantity, 0)
+	productService.CreateProduct(newProduct2.Name, newProduct2.Description, newProduct2.PriceMinor, newProduct2.Currency, newProduct2.Quantity, 0)
 
-	err := UpdateProducts(ids, quantities)
+	err := productService.UpdateProducts(ids, nil, quantities, "", nil)
 
 	assert.Error(t, err)
 	assert.Equal(t, "some ids are invalid", err.Error())
@@ -433,25 +462,160 @@ func TestUpdateProductsShouldBuyMoreItemsThanPrsentInTheInventory(t *testing.T)
 	newProduct1 := &models.Product{
 		Name:        "Test Product",
 		Description: "This is a test product",
-		Price:       9.99,
+		PriceMinor:  999,
 		Quantity:    10,
 	}
 
 	newProduct2 := &models.Product{
 		Name:        "Test Product 2",
 		Description: "This is a test product 2",
-		Price:       8.75,
+		PriceMinor:  875,
 		Quantity:    4,
 	}
 
 	ids := []int64{1, 2}
 	quantities := []int32{11, 2}
 
-	CreateProduct(newProduct1.Name, newProduct1.Description, newProduct1.Price, newProduct1.Quantity)
-	CreateProduct(newProduct2.Name, newProduct2.Description, newProduct2.Price, newProduct2.Quantity)
+	productService.CreateProduct(newProduct1.Name, newProduct1.Description, newProduct1.PriceMinor, newProduct1.Currency, newProduct1.Quantity, 0)
+	productService.CreateProduct(newProduct2.Name, newProduct2.Description, newProduct2.PriceMinor, newProduct2.Currency, newProduct2.Quantity, 0)
 
-	err := UpdateProducts(ids, quantities)
+	err := productService.UpdateProducts(ids, nil, quantities, "", nil)
 
 	assert.Error(t, err)
 	assert.Equal(t, "trying to order more items than there is in the inventory", err.Error())
 }
+
+func Test_Service_UpdateProductDetailsShouldWorkCorrectly(t *testing.T) {
+	db := setupDatabase(t)
+	defer teardownDatabase(db)
+
+	newProduct := &models.Product{
+		Name:        "Test Product",
+		Description: "This is a test product",
+		PriceMinor:  999,
+		Quantity:    10,
+	}
+
+	productService.CreateProduct(newProduct.Name, newProduct.Description, newProduct.PriceMinor, newProduct.Currency, newProduct.Quantity, 0)
+
+	product, err := productService.UpdateProductDetails(1, "Updated Product", "Ignored description", 1999, "", 0, []string{"name", "price"}, 0)
+
+	assert.NoError(t, err)
+	assert.NotNil(t, product)
+	assert.Equal(t, "Updated Product", product.Name)
+	assert.Equal(t, newProduct.Description, product.Description)
+	assert.Equal(t, int64(1999), product.PriceMinor)
+}
+
+func Test_Service_UpdateProductDetailsShouldAllowKeepingTheSameName(t *testing.T) {
+	db := setupDatabase(t)
+	defer teardownDatabase(db)
+
+	productService.CreateProduct("Test Product", "This is a test product", 999, "", 10, 0)
+
+	product, err := productService.UpdateProductDetails(1, "Test Product", "Updated description", 0, "", 0, []string{"name", "description"}, 0)
+
+	assert.NoError(t, err)
+	assert.NotNil(t, product)
+	assert.Equal(t, "Test Product", product.Name)
+	assert.Equal(t, "Updated description", product.Description)
+	assert.Equal(t, int64(999), product.PriceMinor)
+}
+
+func Test_Service_UpdateProductDetailsShouldThrowAnErrorIfUpdateMaskIsEmpty(t *testing.T) {
+	db := setupDatabase(t)
+	defer teardownDatabase(db)
+
+	product, err := productService.UpdateProductDetails(1, "Updated Product", "", 0, "", 0, nil, 0)
+
+	assert.Error(t, err)
+	assert.Nil(t, product)
+	assert.Equal(t, "update mask cannot be empty", err.Error())
+}
+
+func Test_Service_UpdateProductDetailsShouldThrowAnErrorIfFieldIsInvalid(t *testing.T) {
+	db := setupDatabase(t)
+	defer teardownDatabase(db)
+
+	product, err := productService.UpdateProductDetails(1, "", "", 0, "", 0, []string{"quantity"}, 0)
+
+	assert.Error(t, err)
+	assert.Nil(t, product)
+	assert.Equal(t, "invalid field quantity in update mask", err.Error())
+}
+
+func Test_Service_UpdateProductDetailsShouldThrowAnErrorIfPriceIsNegative(t *testing.T) {
+	db := setupDatabase(t)
+	defer teardownDatabase(db)
+
+	productService.CreateProduct("Test Product", "This is a test product", 999, "", 10, 0)
+
+	product, err := productService.UpdateProductDetails(1, "", "", -1, "", 0, []string{"price"}, 0)
+
+	assert.Error(t, err)
+	assert.Nil(t, product)
+	assert.Equal(t, "price cannot be less than 0", err.Error())
+}
+
+func Test_Service_UpdateProductDetailsShouldThrowAnErrorIfNameIsTaken(t *testing.T) {
+	db := setupDatabase(t)
+	defer teardownDatabase(db)
+
+	productService.CreateProduct("Test Product 1", "This is a test product", 999, "", 10, 0)
+	productService.CreateProduct("Test Product 2", "This is a test product", 999, "", 10, 0)
+
+	product, err := productService.UpdateProductDetails(1, "Test Product 2", "", 0, "", 0, []string{"name"}, 0)
+
+	assert.Error(t, err)
+	assert.Nil(t, product)
+	assert.Equal(t, "product with the same name already exists", err.Error())
+}
+
+func Test_Service_ShouldCreateProductNormalizeTheCurrency(t *testing.T) {
+	db := setupDatabase(t)
+	defer teardownDatabase(db)
+
+	product1, err1 := productService.CreateProduct("Test Product 1", "This is a test product", 999, "", 10, 0)
+	product2, err2 := productService.CreateProduct("Test Product 2", "This is a test product", 1500, "jpy", 10, 0)
+
+	assert.NoError(t, err1)
+	assert.NoError(t, err2)
+	assert.Equal(t, "USD", product1.Currency)
+	assert.Equal(t, "JPY", product2.Currency)
+	assert.Equal(t, int64(1500), product2.PriceMinor)
+}
+
+func Test_Service_ShouldCreateProductThrowAnErrorIfPriceIsNegative(t *testing.T) {
+	db := setupDatabase(t)
+	defer teardownDatabase(db)
+
+	product, err := productService.CreateProduct("Test Product", "This is a test product", -1, "USD", 10, 0)
+
+	assert.Error(t, err)
+	assert.Nil(t, product)
+	assert.Equal(t, "price cannot be less than 0", err.Error())
+}
+
+func Test_Service_ShouldCreateProductThrowAnErrorIfCurrencyIsUnsupported(t *testing.T) {
+	db := setupDatabase(t)
+	defer teardownDatabase(db)
+
+	product, err := productService.CreateProduct("Test Product", "This is a test product", 999, "XYZ", 10, 0)
+
+	assert.Error(t, err)
+	assert.Nil(t, product)
+	assert.Equal(t, "unsupported currency code XYZ", err.Error())
+}
+
+func Test_Service_UpdateProductDetailsShouldUpdateTheCurrencyWithThePrice(t *testing.T) {
+	db := setupDatabase(t)
+	defer teardownDatabase(db)
+
+	productService.CreateProduct("Test Product", "This is a test product", 999, "USD", 10, 0)
+
+	product, err := productService.UpdateProductDetails(1, "", "", 850, "eur", 0, []string{"price"}, 0)
+
+	assert.NoError(t, err)
+	assert.Equal(t, int64(850), product.PriceMinor)
+	assert.Equal(t, "EUR", product.Currency)
+}
@@ -0,0 +1,239 @@
+package services
+
+import (
+	"product-service/models"
+	"product-service/utils"
+	"strings"
+	"time"
+)
+
+type PromotionService struct {
+	promotions models.PromotionRepository
+	products   models.ProductRepository
+}
+
+func NewPromotionService(promotions models.PromotionRepository, products models.ProductRepository) *PromotionService {
+	return &PromotionService{promotions: promotions, products: products}
+}
+
+type CartItem struct {
+	ProductID int64
+	Quantity  int32
+}
+
+type PricedLine struct {
+	ProductID      int64
+	Name           string
+	Quantity       int32
+	UnitPriceMinor int64
+	SubtotalMinor  int64
+	DiscountMinor  int64
+	TotalMinor     int64
+	Promotion      *models.Promotion
+}
+
+type CartPrice struct {
+	Lines         []PricedLine
+	Currency      string
+	SubtotalMinor int64
+	DiscountMinor int64
+	TotalMinor    int64
+}
+
+func (service *PromotionService) CreatePromotion(newPromotion models.Promotion) (*models.Promotion, error) {
+	if len(newPromotion.Name) == 0 {
+		return nil, models.InvalidArgumentError("promotion name cannot be empty")
+	}
+
+	var product *models.Product
+
+	if newPromotion.ProductID != nil {
+		existing, err := service.products.GetProduct(int32(*newPromotion.ProductID))
+
+		if err != nil {
+			return nil, models.NotFoundError("product with id %d does not exist", *newPromotion.ProductID)
+		}
+
+		product = existing
+	}
+
+	switch newPromotion.Type {
+	case models.PromotionPercentage:
+		if newPromotion.PercentOff <= 0 || newPromotion.PercentOff > 100 {
+			return nil, models.InvalidArgumentError("percent off must be between 1 and 100")
+		}
+	case models.PromotionFixedAmount:
+		if newPromotion.AmountOffMinor <= 0 {
+			return nil, models.InvalidArgumentError("amount off must be greater than 0")
+		}
+
+		if len(newPromotion.Currency) == 0 && product != nil {
+			newPromotion.Currency = product.Currency
+		}
+
+		currency, err := utils.NormalizeCurrency(newPromotion.Currency)
+
+		if err != nil {
+			return nil, err
+		}
+
+		newPromotion.Currency = currency
+	case models.PromotionBuyXGetY:
+		if newPromotion.BuyQuantity <= 0 || newPromotion.GetQuantity <= 0 {
+			return nil, models.InvalidArgumentError("buy and get quantities must be greater than 0")
+		}
+	default:
+		return nil, models.InvalidArgumentError("unknown promotion type %s", newPromotion.Type)
+	}
+
+	if newPromotion.StartsAt != nil && newPromotion.EndsAt != nil && !newPromotion.EndsAt.After(*newPromotion.StartsAt) {
+		return nil, models.InvalidArgumentError("promotion must end after it starts")
+	}
+
+	if newPromotion.CouponCode != nil {
+		code := normalizeCouponCode(*newPromotion.CouponCode)
+
+		if len(code) == 0 {
+			newPromotion.CouponCode = nil
+		} else {
+			existing, err := service.promotions.FindPromotionByCouponCode(code)
+
+			if err != nil {
+				return nil, err
+			}
+
+			if existing != nil {
+				return nil, models.AlreadyExistsError("coupon code already exists")
+			}
+
+			newPromotion.CouponCode = &code
+		}
+	}
+
+	return service.promotions.CreatePromotion(&newPromotion)
+}
+
+func (service *PromotionService) GetAllPromotions() ([]models.Promotion, error) {
+	return service.promotions.GetAllPromotions()
+}
+
+func (service *PromotionService) DeletePromotion(id int32) error {
+	return service.promotions.DeletePromotion(id)
+}
+
+func (service *PromotionService) PriceCart(items []CartItem, couponCodes []string) (*CartPrice, error) {
+	if len(items) == 0 {
+		return nil, models.InvalidArgumentError("cart is empty")
+	}
+
+	codes := make([]string, 0, len(couponCodes))
+
+	for _, code := range couponCodes {
+		if code = normalizeCouponCode(code); len(code) != 0 {
+			codes = append(codes, code)
+		}
+	}
+
+	promotions, err := service.promotions.GetActivePromotions(time.Now(), codes)
+
+	if err != nil {
+		return nil, err
+	}
+
+	for _, code := range codes {
+		if !hasCoupon(promotions, code) {
+			return nil, models.InvalidArgumentError("coupon code %s is not valid", code)
+		}
+	}
+
+	cart := &CartPrice{}
+
+	for _, item := range items {
+		if item.Quantity <= 0 {
+			return nil, models.InvalidArgumentError("quantity must be greater than 0")
+		}
+
+		product, err := service.products.GetProduct(int32(item.ProductID))
+
+		if err != nil {
+			return nil, models.NotFoundError("product with id %d does not exist", item.ProductID)
+		}
+
+		if len(cart.Currency) == 0 {
+			cart.Currency = product.Currency
+		} else if cart.Currency != product.Currency {
+			return nil, models.InvalidArgumentError("cart contains products in multiple currencies")
+		}
+
+		line := PricedLine{
+			ProductID:      product.ID,
+			Name:           product.Name,
+			Quantity:       item.Quantity,
+			UnitPriceMinor: product.PriceMinor,
+			SubtotalMinor:  product.PriceMinor * int64(item.Quantity),
+		}
+
+		for idx := range promotions {
+			discount := promotionDiscount(&promotions[idx], product, item.Quantity)
+
+			if discount > line.DiscountMinor {
+				line.DiscountMinor = discount
+				line.Promotion = &promotions[idx]
+			}
+		}
+
+		line.TotalMinor = line.SubtotalMinor - line.DiscountMinor
+
+		cart.Lines = append(cart.Lines, line)
+		cart.SubtotalMinor += line.SubtotalMinor
+		cart.DiscountMinor += line.DiscountMinor
+		cart.TotalMinor += line.TotalMinor
+	}
+
+	return cart, nil
+}
+
+func promotionDiscount(promotion *models.Promotion, product *models.Product, quantity int32) int64 {
+	if promotion.ProductID != nil && *promotion.ProductID != product.ID {
+		return 0
+	}
+
+	subtotal := product.PriceMinor * int64(quantity)
+
+	switch promotion.Type {
+	case models.PromotionPercentage:
+		return subtotal * int64(promotion.PercentOff) / 100
+	case models.PromotionFixedAmount:
+		if promotion.Currency != product.Currency {
+			return 0
+		}
+
+		amount := promotion.AmountOffMinor
+
+		if amount > product.PriceMinor {
+			amount = product.PriceMinor
+		}
+
+		return amount * int64(quantity)
+	case models.PromotionBuyXGetY:
+		free := quantity / (promotion.BuyQuantity + promotion.GetQuantity) * promotion.GetQuantity
+
+		return product.PriceMinor * int64(free)
+	}
+
+	return 0
+}
+
+func hasCoupon(promotions []models.Promotion, code string) bool {
+	for _, promotion := range promotions {
+		if promotion.CouponCode != nil && *promotion.CouponCode == code {
+			return true
+		}
+	}
+
+	return false
+}
+
+func normalizeCouponCode(code string) string {
+	return strings.ToUpper(strings.TrimSpace(code))
+}
@@ -0,0 +1,127 @@
+package services
+
+import (
+	"product-service/models"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_Service_PriceCartShouldApplyPercentageAndFixedAmountPromotions(t *testing.T) {
+	db := setupDatabase(t)
+	defer teardownDatabase(db)
+
+	productService.CreateProduct("Sneakers", "", 5000, "", 10, 0)
+	productService.CreateProduct("Socks", "", 500, "", 10, 0)
+
+	sneakers, socks := int64(1), int64(2)
+
+	promotionService.CreatePromotion(models.Promotion{Name: "Sneaker sale", Type: models.PromotionPercentage, PercentOff: 20, ProductID: &sneakers})
+	promotionService.CreatePromotion(models.Promotion{Name: "Socks deal", Type: models.PromotionFixedAmount, AmountOffMinor: 100, ProductID: &socks})
+
+	cart, err := promotionService.PriceCart([]CartItem{{ProductID: 1, Quantity: 2}, {ProductID: 2, Quantity: 3}}, nil)
+
+	assert.NoError(t, err)
+	assert.Equal(t, "USD", cart.Currency)
+	assert.Equal(t, int64(2000), cart.Lines[0].DiscountMinor)
+	assert.Equal(t, int64(300), cart.Lines[1].DiscountMinor)
+	assert.Equal(t, int64(11500), cart.SubtotalMinor)
+	assert.Equal(t, int64(2300), cart.DiscountMinor)
+	assert.Equal(t, int64(9200), cart.TotalMinor)
+}
+
+func Test_Service_PriceCartShouldApplyBuyXGetYAndPickTheBestPromotion(t *testing.T) {
+	db := setupDatabase(t)
+	defer teardownDatabase(db)
+
+	productService.CreateProduct("Socks", "", 500, "", 10, 0)
+
+	promotionService.CreatePromotion(models.Promotion{Name: "Buy 2 get 1", Type: models.PromotionBuyXGetY, BuyQuantity: 2, GetQuantity: 1})
+	promotionService.CreatePromotion(models.Promotion{Name: "Ten off", Type: models.PromotionPercentage, PercentOff: 10})
+
+	cart, err := promotionService.PriceCart([]CartItem{{ProductID: 1, Quantity: 7}}, nil)
+
+	assert.NoError(t, err)
+	assert.Equal(t, int64(1000), cart.Lines[0].DiscountMinor)
+	assert.Equal(t, "Buy 2 get 1", cart.Lines[0].Promotion.Name)
+}
+
+func Test_Service_PriceCartShouldOnlyApplyCouponsWhenProvided(t *testing.T) {
+	db := setupDatabase(t)
+	defer teardownDatabase(db)
+
+	productService.CreateProduct("Sneakers", "", 5000, "", 10, 0)
+
+	code := "welcome"
+	promotionService.CreatePromotion(models.Promotion{Name: "Welcome", Type: models.PromotionPercentage, PercentOff: 50, CouponCode: &code})
+
+	withoutCoupon, err1 := promotionService.PriceCart([]CartItem{{ProductID: 1, Quantity: 1}}, nil)
+	withCoupon, err2 := promotionService.PriceCart([]CartItem{{ProductID: 1, Quantity: 1}}, []string{" Welcome "})
+	invalid, err3 := promotionService.PriceCart([]CartItem{{ProductID: 1, Quantity: 1}}, []string{"NOPE"})
+
+	assert.NoError(t, err1)
+	assert.NoError(t, err2)
+	assert.Equal(t, int64(0), withoutCoupon.DiscountMinor)
+	assert.Equal(t, int64(2500), withCoupon.DiscountMinor)
+	assert.Error(t, err3)
+	assert.Nil(t, invalid)
+	assert.Equal(t, "coupon code NOPE is not valid", err3.Error())
+}
+
+func Test_Service_PriceCartShouldIgnoreExpiredPromotions(t *testing.T) {
+	db := setupDatabase(t)
+	defer teardownDatabase(db)
+
+	productService.CreateProduct("Sneakers", "", 5000, "", 10, 0)
+
+	startsAt, endsAt := time.Now().Add(-2*time.Hour), time.Now().Add(-time.Hour)
+	promotionService.CreatePromotion(models.Promotion{Name: "Flash sale", Type: models.PromotionPercentage, PercentOff: 30, StartsAt: &startsAt, EndsAt: &endsAt})
+
+	cart, err := promotionService.PriceCart([]CartItem{{ProductID: 1, Quantity: 1}}, nil)
+
+	assert.NoError(t, err)
+	assert.Equal(t, int64(5000), cart.TotalMinor)
+	assert.Nil(t, cart.Lines[0].Promotion)
+}
+
+func Test_Service_PriceCartShouldThrowAnErrorForMixedCurrencies(t *testing.T) {
+	db := setupDatabase(t)
+	defer teardownDatabase(db)
+
+	productService.CreateProduct("Sneakers", "", 5000, "USD", 10, 0)
+	productService.CreateProduct("Scarf", "", 2000, "EUR", 10, 0)
+
+	cart, err := promotionService.PriceCart([]CartItem{{ProductID: 1, Quantity: 1}, {ProductID: 2, Quantity: 1}}, nil)
+
+	assert.Error(t, err)
+	assert.Nil(t, cart)
+	assert.Equal(t, "cart contains products in multiple currencies", err.Error())
+}
+
+func Test_Service_CreatePromotionShouldValidateRules(t *testing.T) {
+	db := setupDatabase(t)
+	defer teardownDatabase(db)
+
+	_, err1 := promotionService.CreatePromotion(models.Promotion{Name: "Too much", Type: models.PromotionPercentage, PercentOff: 150})
+	_, err2 := promotionService.CreatePromotion(models.Promotion{Name: "Nothing", Type: models.PromotionBuyXGetY, BuyQuantity: 2})
+	_, err3 := promotionService.CreatePromotion(models.Promotion{Name: "Mystery", Type: "mystery"})
+
+	assert.Equal(t, "percent off must be between 1 and 100", err1.Error())
+	assert.Equal(t, "buy and get quantities must be greater than 0", err2.Error())
+	assert.Equal(t, "unknown promotion type mystery", err3.Error())
+}
+
+func Test_Service_CreatePromotionShouldThrowAnErrorIfCouponCodeIsTaken(t *testing.T) {
+	db := setupDatabase(t)
+	defer teardownDatabase(db)
+
+	first, second := "welcome", "WELCOME"
+	promotionService.CreatePromotion(models.Promotion{Name: "Welcome", Type: models.PromotionPercentage, PercentOff: 10, CouponCode: &first})
+
+	promotion, err := promotionService.CreatePromotion(models.Promotion{Name: "Welcome again", Type: models.PromotionPercentage, PercentOff: 10, CouponCode: &second})
+
+	assert.Error(t, err)
+	assert.Nil(t, promotion)
+	assert.Equal(t, "coupon code already exists", err.Error())
+}
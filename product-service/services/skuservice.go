@@ -0,0 +1,171 @@
+package services
+
+import (
+	"product-service/models"
+	"product-service/utils"
+)
+
+type SkuService struct {
+	skus      models.SkuRepository
+	products  models.ProductRepository
+	inventory *Inventory
+}
+
+func NewSkuService(skus models.SkuRepository, products models.ProductRepository, inventory *Inventory) *SkuService {
+	return &SkuService{skus: skus, products: products, inventory: inventory}
+}
+
+func (service *SkuService) CreateSku(productID int32, code string, attributes map[string]string, priceOverride *int64, currency string, quantity int32) (*models.Sku, error) {
+	if len(code) == 0 {
+		return nil, models.InvalidArgumentError("sku code cannot be empty")
+	}
+
+	if quantity < 0 {
+		return nil, models.InvalidArgumentError("quantity cannot be less than 0")
+	}
+
+	product, err := service.products.GetProduct(productID)
+
+	if err != nil {
+		return nil, err
+	}
+
+	if err := validateSkuPrice(product, priceOverride, currency); err != nil {
+		return nil, err
+	}
+
+	if err := service.validateSkuCode(code, 0); err != nil {
+		return nil, err
+	}
+
+	newSku := models.Sku{
+		ProductID:  product.ID,
+		Code:       code,
+		Attributes: attributes,
+		PriceMinor: priceOverride,
+		Quantity:   quantity,
+	}
+
+	sku, err := service.skus.CreateSku(&newSku)
+
+	if err != nil {
+		return nil, err
+	}
+
+	after, _ := service.products.GetProduct(productID)
+	service.inventory.notifyThresholdCrossing(product, after)
+	service.inventory.publish(after)
+
+	return sku, nil
+}
+
+func (service *SkuService) GetSku(id int32) (*models.Sku, error) {
+	return service.skus.GetSku(id)
+}
+
+func (service *SkuService) UpdateSku(id int32, code string, attributes map[string]string, priceOverride *int64, currency string, paths []string) (*models.Sku, error) {
+	if len(paths) == 0 {
+		return nil, models.InvalidArgumentError("update mask cannot be empty")
+	}
+
+	sku, err := service.skus.GetSku(id)
+
+	if err != nil {
+		return nil, err
+	}
+
+	fields := make(map[string]interface{})
+
+	for _, path := range paths {
+		switch path {
+		case "code":
+			if len(code) == 0 {
+				return nil, models.InvalidArgumentError("sku code cannot be empty")
+			}
+
+			if err := service.validateSkuCode(code, sku.ID); err != nil {
+				return nil, err
+			}
+
+			fields["code"] = code
+		case "attributes":
+			fields["attributes"] = models.SkuAttributes(attributes)
+		case "price_override":
+			product, err := service.products.GetProduct(int32(sku.ProductID))
+
+			if err != nil {
+				return nil, err
+			}
+
+			if err := validateSkuPrice(product, priceOverride, currency); err != nil {
+				return nil, err
+			}
+
+			fields["price_minor"] = priceOverride
+		default:
+			return nil, models.InvalidArgumentError("invalid field %s in update mask", path)
+		}
+	}
+
+	return service.skus.UpdateSku(id, fields)
+}
+
+func (service *SkuService) DeleteSku(id int32) error {
+	sku, err := service.skus.GetSku(id)
+
+	if err != nil {
+		return models.NotFoundError("sku with id %d does not exist", id)
+	}
+
+	before, _ := service.products.GetProduct(int32(sku.ProductID))
+
+	if err := service.skus.DeleteSku(id); err != nil {
+		return err
+	}
+
+	after, _ := service.products.GetProduct(int32(sku.ProductID))
+	service.inventory.notifyThresholdCrossing(before, after)
+	service.inventory.publish(after)
+
+	return nil
+}
+
+func (service *SkuService) validateSkuCode(code string, id int64) error {
+	sku, err := service.skus.FindSkuByCode(code)
+
+	if err != nil {
+		return err
+	}
+
+	if sku != nil && sku.ID != id {
+		return models.AlreadyExistsError("sku with the same code already exists")
+	}
+
+	return nil
+}
+
+func validateSkuPrice(product *models.Product, priceOverride *int64, currency string) error {
+	if priceOverride == nil {
+		return nil
+	}
+
+	if *priceOverride < 0 {
+		return models.InvalidArgumentError("price cannot be less than 0")
+	}
+
+	if len(currency) == 0 {
+		return nil
+	}
+
+	currency, err := utils.NormalizeCurrency(currency)
+
+	if err != nil {
+		return err
+	}
+
+	if currency != product.Currency {
+		return models.InvalidArgumentError("sku price must be in the product currency %s", product.Currency)
+	}
+
+	return nil
+}
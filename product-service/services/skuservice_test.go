@@ -0,0 +1,65 @@
+package services
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_Service_ShouldCreateSkuWorkCorrectly(t *testing.T) {
+	db := setupDatabase(t)
+	defer teardownDatabase(db)
+
+	productService.CreateProduct("T-Shirt", "", 1999, "USD", 0, 0)
+
+	price := int64(2499)
+	sku, err := skuService.CreateSku(1, "TS-XL", map[string]string{"size": "XL"}, &price, "usd", 2)
+
+	assert.NoError(t, err)
+	assert.Equal(t, "TS-XL", sku.Code)
+	assert.Equal(t, int64(2499), *sku.PriceMinor)
+}
+
+func Test_Service_ShouldCreateSkuThrowAnErrorIfCodeIsTaken(t *testing.T) {
+	db := setupDatabase(t)
+	defer teardownDatabase(db)
+
+	productService.CreateProduct("T-Shirt", "", 1999, "USD", 0, 0)
+	skuService.CreateSku(1, "TS-XL", nil, nil, "", 2)
+
+	sku, err := skuService.CreateSku(1, "TS-XL", nil, nil, "", 2)
+
+	assert.Error(t, err)
+	assert.Nil(t, sku)
+	assert.Equal(t, "sku with the same code already exists", err.Error())
+}
+
+func Test_Service_ShouldCreateSkuThrowAnErrorIfCurrencyDiffers(t *testing.T) {
+	db := setupDatabase(t)
+	defer teardownDatabase(db)
+
+	productService.CreateProduct("T-Shirt", "", 1999, "USD", 0, 0)
+
+	price := int64(2499)
+	sku, err := skuService.CreateSku(1, "TS-XL", nil, &price, "EUR", 2)
+
+	assert.Error(t, err)
+	assert.Nil(t, sku)
+	assert.Equal(t, "sku price must be in the product currency USD", err.Error())
+}
+
+func Test_Service_UpdateSkuShouldClearPriceOverride(t *testing.T) {
+	db := setupDatabase(t)
+	defer teardownDatabase(db)
+
+	productService.CreateProduct("T-Shirt", "", 1999, "USD", 0, 0)
+
+	price := int64(2499)
+	skuService.CreateSku(1, "TS-XL", nil, &price, "", 2)
+
+	sku, err := skuService.UpdateSku(1, "", map[string]string{"size": "XXL"}, nil, "", []string{"attributes", "price_override"})
+
+	assert.NoError(t, err)
+	assert.Nil(t, sku.PriceMinor)
+	assert.Equal(t, "XXL", sku.Attributes["size"])
+}
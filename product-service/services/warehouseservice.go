@@ -0,0 +1,71 @@
+package services
+
+import (
+	"product-service/models"
+)
+
+type WarehouseService struct {
+	warehouses models.WarehouseRepository
+	inventory  *Inventory
+}
+
+func NewWarehouseService(warehouses models.WarehouseRepository, inventory *Inventory) *WarehouseService {
+	return &WarehouseService{warehouses: warehouses, inventory: inventory}
+}
+
+func (service *WarehouseService) CreateWarehouse(name string, latitude float64, longitude float64, priority int32) (*models.Warehouse, error) {
+	if len(name) == 0 {
+		return nil, models.InvalidArgumentError("warehouse name cannot be empty")
+	}
+
+	if latitude < -90 || latitude > 90 || longitude < -180 || longitude > 180 {
+		return nil, models.InvalidArgumentError("invalid warehouse location")
+	}
+
+	warehouse, err := service.warehouses.FindWarehouseByName(name)
+
+	if err != nil {
+		return nil, err
+	}
+
+	if warehouse != nil {
+		return nil, models.AlreadyExistsError("warehouse with the same name already exists")
+	}
+
+	newWarehouse := models.Warehouse{
+		Name:      name,
+		Latitude:  latitude,
+		Longitude: longitude,
+		Priority:  priority,
+	}
+
+	return service.warehouses.CreateWarehouse(&newWarehouse)
+}
+
+func (service *WarehouseService) GetAllWarehouses() ([]models.Warehouse, error) {
+	return service.warehouses.GetAllWarehouses()
+}
+
+func (service *WarehouseService) GetWarehouse(id int32) (*models.Warehouse, error) {
+	return service.warehouses.GetWarehouse(id)
+}
+
+func (service *WarehouseService) DeleteWarehouse(id int32) error {
+	return service.warehouses.DeleteWarehouse(id)
+}
+
+func (service *WarehouseService) TransferStock(productID int32, skuID int32, fromWarehouseID int32, toWarehouseID int32, quantity int32, expectedVersion int64) (*models.Product, error) {
+	if quantity <= 0 {
+		return nil, models.InvalidArgumentError("quantity transferred cannot be less than 0")
+	}
+
+	product, err := service.warehouses.TransferStock(productID, skuID, fromWarehouseID, toWarehouseID, quantity, expectedVersion)
+
+	if err != nil {
+		return nil, err
+	}
+
+	service.inventory.publish(product)
+
+	return product, nil
+}
@@ -0,0 +1,53 @@
+package services
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_Service_ShouldCreateWarehouseWorkCorrectly(t *testing.T) {
+	db := setupDatabase(t)
+	defer teardownDatabase(db)
+
+	warehouse, err := warehouseService.CreateWarehouse("East", 40.71, -74.00, 1)
+
+	assert.NoError(t, err)
+	assert.Equal(t, "East", warehouse.Name)
+	assert.False(t, warehouse.IsDefault)
+}
+
+func Test_Service_ShouldCreateWarehouseThrowAnErrorIfNameIsTaken(t *testing.T) {
+	db := setupDatabase(t)
+	defer teardownDatabase(db)
+
+	warehouseService.CreateWarehouse("East", 40.71, -74.00, 1)
+
+	warehouse, err := warehouseService.CreateWarehouse("East", 0, 0, 0)
+
+	assert.Error(t, err)
+	assert.Nil(t, warehouse)
+	assert.Equal(t, "warehouse with the same name already exists", err.Error())
+}
+
+func Test_Service_ShouldCreateWarehouseThrowAnErrorIfLocationIsInvalid(t *testing.T) {
+	db := setupDatabase(t)
+	defer teardownDatabase(db)
+
+	warehouse, err := warehouseService.CreateWarehouse("North", 91, 0, 0)
+
+	assert.Error(t, err)
+	assert.Nil(t, warehouse)
+	assert.Equal(t, "invalid warehouse location", err.Error())
+}
+
+func Test_Service_TransferStockShouldThrowAnErrorIfQuantityIsLessThanZero(t *testing.T) {
+	db := setupDatabase(t)
+	defer teardownDatabase(db)
+
+	product, err := warehouseService.TransferStock(1, 0, 1, 2, 0, 0)
+
+	assert.Error(t, err)
+	assert.Nil(t, product)
+	assert.Equal(t, "quantity transferred cannot be less than 0", err.Error())
+}
@@ -0,0 +1,21 @@
+package utils
+
+import (
+	"currency"
+)
+
+const (
+	DefaultCurrency = currency.DefaultCurrency
+)
+
+var (
+	ErrUnsupportedCurrency = currency.ErrUnsupported
+)
+
+func NormalizeCurrency(code string) (string, error) {
+	return currency.Normalize(code)
+}
+
+func CurrencyExponent(code string) (int32, error) {
+	return currency.Exponent(code)
+}
@@ -0,0 +1,29 @@
+package utils
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNormalizeCurrencyShouldDefaultToUSD(t *testing.T) {
+	currency, err := NormalizeCurrency("")
+
+	assert.NoError(t, err)
+	assert.Equal(t, DefaultCurrency, currency)
+}
+
+func TestNormalizeCurrencyShouldUppercaseTheCode(t *testing.T) {
+	currency, err := NormalizeCurrency("eur")
+
+	assert.NoError(t, err)
+	assert.Equal(t, "EUR", currency)
+}
+
+func TestNormalizeCurrencyShouldThrowAnErrorIfCodeIsUnsupported(t *testing.T) {
+	currency, err := NormalizeCurrency("XYZ")
+
+	assert.Error(t, err)
+	assert.Equal(t, "", currency)
+	assert.Equal(t, "unsupported currency code XYZ", err.Error())
+}
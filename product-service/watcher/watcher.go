@@ -0,0 +1,143 @@
+package watcher
+
+import (
+	"sync"
+	"time"
+)
+
+type Update struct {
+	ProductID int64
+	Quantity  int32
+	Version   int64
+	At        time.Time
+}
+
+type Hub struct {
+	mutex       sync.RWMutex
+	nextID      int
+	subscribers map[int]*Subscription
+}
+
+// Subscription keeps at most one pending update per product, so a slow
+// reader skips intermediate counts but always ends up with the latest one.
+type Subscription struct {
+	id         int
+	hub        *Hub
+	productIDs map[int64]bool
+	mutex      sync.Mutex
+	pending    map[int64]Update
+	order      []int64
+	ready      chan struct{}
+	closed     bool
+	once       sync.Once
+}
+
+func NewHub() *Hub {
+	return &Hub{subscribers: make(map[int]*Subscription)}
+}
+
+func (hub *Hub) Subscribe(productIDs []int64) *Subscription {
+	hub.mutex.Lock()
+	defer hub.mutex.Unlock()
+
+	subscription := &Subscription{
+		id:      hub.nextID,
+		hub:     hub,
+		pending: make(map[int64]Update),
+		ready:   make(chan struct{}, 1),
+	}
+
+	if len(productIDs) > 0 {
+		subscription.productIDs = make(map[int64]bool, len(productIDs))
+
+		for _, id := range productIDs {
+			subscription.productIDs[id] = true
+		}
+	}
+
+	hub.subscribers[subscription.id] = subscription
+	hub.nextID++
+
+	return subscription
+}
+
+// Publish never blocks on a slow subscriber; an update replaces the one still
+// pending for the same product instead of queueing behind it.
+func (hub *Hub) Publish(update Update) {
+	hub.mutex.RLock()
+	defer hub.mutex.RUnlock()
+
+	for _, subscription := range hub.subscribers {
+		if subscription.productIDs != nil && !subscription.productIDs[update.ProductID] {
+			continue
+		}
+
+		subscription.push(update)
+	}
+}
+
+func (hub *Hub) Subscribers() int {
+	hub.mutex.RLock()
+	defer hub.mutex.RUnlock()
+
+	return len(hub.subscribers)
+}
+
+func (subscription *Subscription) push(update Update) {
+	subscription.mutex.Lock()
+	defer subscription.mutex.Unlock()
+
+	if subscription.closed {
+		return
+	}
+
+	if pending, ok := subscription.pending[update.ProductID]; !ok {
+		subscription.order = append(subscription.order, update.ProductID)
+	} else if pending.Version > update.Version {
+		return
+	}
+
+	subscription.pending[update.ProductID] = update
+
+	select {
+	case subscription.ready <- struct{}{}:
+	default:
+	}
+}
+
+// Ready is signalled when updates are pending and closed once the
+// subscription is closed.
+func (subscription *Subscription) Ready() <-chan struct{} {
+	return subscription.ready
+}
+
+// Drain returns the pending updates in the order their products first
+// changed and clears them.
+func (subscription *Subscription) Drain() []Update {
+	subscription.mutex.Lock()
+	defer subscription.mutex.Unlock()
+
+	updates := make([]Update, 0, len(subscription.order))
+
+	for _, id := range subscription.order {
+		updates = append(updates, subscription.pending[id])
+		delete(subscription.pending, id)
+	}
+
+	subscription.order = nil
+
+	return updates
+}
+
+func (subscription *Subscription) Close() {
+	subscription.once.Do(func() {
+		subscription.hub.mutex.Lock()
+		delete(subscription.hub.subscribers, subscription.id)
+		subscription.hub.mutex.Unlock()
+
+		subscription.mutex.Lock()
+		subscription.closed = true
+		close(subscription.ready)
+		subscription.mutex.Unlock()
+	})
+}
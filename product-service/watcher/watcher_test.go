@@ -0,0 +1,58 @@
+package watcher
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestHubShouldDeliverUpdatesToMatchingSubscribers(t *testing.T) {
+	hub := NewHub()
+
+	filtered := hub.Subscribe([]int64{1})
+	everything := hub.Subscribe(nil)
+
+	hub.Publish(Update{ProductID: 1, Quantity: 5})
+	hub.Publish(Update{ProductID: 2, Quantity: 7})
+
+	<-filtered.Ready()
+	updates := filtered.Drain()
+
+	assert.Equal(t, 1, len(updates))
+	assert.Equal(t, int32(5), updates[0].Quantity)
+	assert.Equal(t, 2, len(everything.Drain()))
+	assert.Empty(t, everything.Drain())
+}
+
+func TestHubShouldKeepTheLatestUpdatePerProductForSlowSubscribers(t *testing.T) {
+	hub := NewHub()
+
+	subscription := hub.Subscribe(nil)
+
+	hub.Publish(Update{ProductID: 1, Quantity: 5, Version: 1})
+	hub.Publish(Update{ProductID: 2, Quantity: 7, Version: 1})
+	hub.Publish(Update{ProductID: 1, Quantity: 4, Version: 2})
+	hub.Publish(Update{ProductID: 1, Quantity: 5, Version: 1})
+
+	updates := subscription.Drain()
+
+	assert.Equal(t, 2, len(updates))
+	assert.Equal(t, Update{ProductID: 1, Quantity: 4, Version: 2}, updates[0])
+	assert.Equal(t, Update{ProductID: 2, Quantity: 7, Version: 1}, updates[1])
+}
+
+func TestSubscriptionCloseShouldUnsubscribe(t *testing.T) {
+	hub := NewHub()
+
+	subscription := hub.Subscribe(nil)
+	subscription.Close()
+	subscription.Close()
+
+	hub.Publish(Update{ProductID: 1, Quantity: 5})
+
+	_, open := <-subscription.Ready()
+
+	assert.False(t, open)
+	assert.Empty(t, subscription.Drain())
+	assert.Equal(t, 0, hub.Subscribers())
+}